@@ -0,0 +1,339 @@
+package cache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	keyServers      = "servers"
+	keyKeys         = "keys"
+	keyIPs          = "ips"
+	keyRDns         = "rdns"
+	keyFailovers    = "failovers"
+	keySubnets      = "subnets"
+	keyVSwitches    = "vswitches"
+	keyStorageBoxes = "storageboxes"
+)
+
+// Client decorates a RobotClient and keeps list responses on disk for a
+// limited time, mutating calls invalidate the affected lists
+type Client struct {
+	robot.RobotClient
+	dir     string
+	ttl     time.Duration
+	enabled bool
+	refresh bool
+}
+
+type entry struct {
+	Created time.Time       `json:"created"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func NewClient(robotClient robot.RobotClient, dir string, ttl time.Duration) *Client {
+	return &Client{
+		RobotClient: robotClient,
+		dir:         dir,
+		ttl:         ttl,
+		enabled:     ttl > 0,
+	}
+}
+
+// Dir returns the directory used for the cache of the given profile, the
+// user cache directory is used if no base directory is configured
+func Dir(baseDir string, profile string) (string, error) {
+	if baseDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+
+		baseDir = filepath.Join(userCacheDir, "hrobot-cli")
+	}
+
+	return filepath.Join(baseDir, profile), nil
+}
+
+// SetEnabled turns reading and writing of cached responses on or off
+func (c *Client) SetEnabled(enabled bool) {
+	c.enabled = enabled && c.ttl > 0
+}
+
+// SetRefresh ignores existing entries, fresh responses are still stored
+func (c *Client) SetRefresh(refresh bool) {
+	c.refresh = refresh
+}
+
+// Clear removes all cached responses of the profile
+func (c *Client) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+func (c *Client) ServerGetList() ([]models.Server, error) {
+	var servers []models.Server
+	if c.load(keyServers, &servers) {
+		return servers, nil
+	}
+
+	servers, err := c.RobotClient.ServerGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyServers, servers)
+	return servers, nil
+}
+
+func (c *Client) KeyGetList() ([]models.Key, error) {
+	var keys []models.Key
+	if c.load(keyKeys, &keys) {
+		return keys, nil
+	}
+
+	keys, err := c.RobotClient.KeyGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyKeys, keys)
+	return keys, nil
+}
+
+func (c *Client) IPGetList() ([]models.IP, error) {
+	var ips []models.IP
+	if c.load(keyIPs, &ips) {
+		return ips, nil
+	}
+
+	ips, err := c.RobotClient.IPGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyIPs, ips)
+	return ips, nil
+}
+
+func (c *Client) RDnsGetList() ([]models.Rdns, error) {
+	var rdnsList []models.Rdns
+	if c.load(keyRDns, &rdnsList) {
+		return rdnsList, nil
+	}
+
+	rdnsList, err := c.RobotClient.RDnsGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyRDns, rdnsList)
+	return rdnsList, nil
+}
+
+func (c *Client) FailoverGetList() ([]models.Failover, error) {
+	var failovers []models.Failover
+	if c.load(keyFailovers, &failovers) {
+		return failovers, nil
+	}
+
+	failovers, err := c.RobotClient.FailoverGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyFailovers, failovers)
+	return failovers, nil
+}
+
+func (c *Client) SubnetGetList() ([]robot.Subnet, error) {
+	var subnets []robot.Subnet
+	if c.load(keySubnets, &subnets) {
+		return subnets, nil
+	}
+
+	subnets, err := c.RobotClient.SubnetGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keySubnets, subnets)
+	return subnets, nil
+}
+
+func (c *Client) VSwitchGetList() ([]robot.VSwitch, error) {
+	var vSwitches []robot.VSwitch
+	if c.load(keyVSwitches, &vSwitches) {
+		return vSwitches, nil
+	}
+
+	vSwitches, err := c.RobotClient.VSwitchGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyVSwitches, vSwitches)
+	return vSwitches, nil
+}
+
+func (c *Client) StorageBoxGetList() ([]robot.StorageBox, error) {
+	var storageBoxes []robot.StorageBox
+	if c.load(keyStorageBoxes, &storageBoxes) {
+		return storageBoxes, nil
+	}
+
+	storageBoxes, err := c.RobotClient.StorageBoxGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(keyStorageBoxes, storageBoxes)
+	return storageBoxes, nil
+}
+
+func (c *Client) ServerSetName(ip string, input *models.ServerSetNameInput) (*models.Server, error) {
+	defer c.invalidate(keyServers)
+	return c.RobotClient.ServerSetName(ip, input)
+}
+
+func (c *Client) ServerReverse(ip string) (*models.Cancellation, error) {
+	defer c.invalidate(keyServers)
+	return c.RobotClient.ServerReverse(ip)
+}
+
+func (c *Client) BootRescueSet(ip string, input *models.RescueSetInput) (*models.Rescue, error) {
+	defer c.invalidate(keyServers)
+	return c.RobotClient.BootRescueSet(ip, input)
+}
+
+func (c *Client) RDnsSet(ip string, ptr string) (*models.Rdns, error) {
+	defer c.invalidate(keyRDns)
+	return c.RobotClient.RDnsSet(ip, ptr)
+}
+
+func (c *Client) IPUpdate(ip string, input *robot.IPUpdateInput) (*robot.IP, error) {
+	defer c.invalidate(keyIPs)
+	return c.RobotClient.IPUpdate(ip, input)
+}
+
+func (c *Client) IPMacGenerate(ip string) (*robot.Mac, error) {
+	defer c.invalidate(keyIPs)
+	return c.RobotClient.IPMacGenerate(ip)
+}
+
+func (c *Client) IPMacDelete(ip string) (*robot.Mac, error) {
+	defer c.invalidate(keyIPs)
+	return c.RobotClient.IPMacDelete(ip)
+}
+
+func (c *Client) VSwitchCreate(input *robot.VSwitchInput) (*robot.VSwitch, error) {
+	defer c.invalidate(keyVSwitches)
+	return c.RobotClient.VSwitchCreate(input)
+}
+
+func (c *Client) VSwitchUpdate(id int, input *robot.VSwitchInput) error {
+	defer c.invalidate(keyVSwitches)
+	return c.RobotClient.VSwitchUpdate(id, input)
+}
+
+func (c *Client) VSwitchCancel(id int, cancellationDate string) error {
+	defer c.invalidate(keyVSwitches)
+	return c.RobotClient.VSwitchCancel(id, cancellationDate)
+}
+
+func (c *Client) StorageBoxUpdate(id int, input *robot.StorageBoxInput) (*robot.StorageBox, error) {
+	defer c.invalidate(keyStorageBoxes)
+	return c.RobotClient.StorageBoxUpdate(id, input)
+}
+
+func (c *Client) AddonTransactionCreate(input *robot.AddonOrderInput) (*robot.AddonTransaction, error) {
+	defer c.invalidate(keyServers, keyIPs, keySubnets)
+	return c.RobotClient.AddonTransactionCreate(input)
+}
+
+// AddonTransactionGet invalidates the lists affected by the order once it is
+// ready, the new IP's and subnets are provisioned after the order was placed
+func (c *Client) AddonTransactionGet(id string) (*robot.AddonTransaction, error) {
+	transaction, err := c.RobotClient.AddonTransactionGet(id)
+	if err == nil && transaction.Status == robot.TransactionStatusReady {
+		c.invalidate(keyServers, keyIPs, keySubnets)
+	}
+
+	return transaction, err
+}
+
+func (c *Client) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *Client) load(key string, v interface{}) bool {
+	if !c.enabled || c.refresh {
+		return false
+	}
+
+	bytes, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	var cached entry
+	if err := json.Unmarshal(bytes, &cached); err != nil {
+		return false
+	}
+
+	if time.Since(cached.Created) > c.ttl {
+		return false
+	}
+
+	return json.Unmarshal(cached.Data, v) == nil
+}
+
+// store writes the response to disk, failing to do so is not an error for
+// the caller as the response itself is still valid
+func (c *Client) store(key string, v interface{}) {
+	if !c.enabled {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	bytes, err := json.Marshal(entry{Created: time.Now(), Data: data})
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+
+	// write to a temporary file first so concurrent readers never see partial entries
+	tmpFile, err := ioutil.TempFile(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+
+	_, err = tmpFile.Write(bytes)
+	closeErr := tmpFile.Close()
+	if err != nil || closeErr != nil {
+		os.Remove(tmpFile.Name())
+		return
+	}
+
+	if err := os.Rename(tmpFile.Name(), c.path(key)); err != nil {
+		os.Remove(tmpFile.Name())
+	}
+}
+
+func (c *Client) invalidate(keys ...string) {
+	for _, key := range keys {
+		os.Remove(c.path(key))
+	}
+}
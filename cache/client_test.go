@@ -0,0 +1,190 @@
+package cache_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cache"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type CacheSuite struct {
+	dir string
+}
+
+var _ = Suite(&CacheSuite{})
+
+func (s *CacheSuite) SetUpTest(c *C) {
+	s.dir = filepath.Join(c.MkDir(), "default")
+}
+
+func (s *CacheSuite) TestServerGetListCached(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.Server{
+		{
+			ServerIP:   "123.123.123.123",
+			ServerName: "app-prod-42",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(result, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+
+	servers, err := cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+	c.Assert(servers, DeepEquals, result)
+
+	servers, err = cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+	c.Assert(servers, DeepEquals, result)
+}
+
+func (s *CacheSuite) TestServerGetListRefresh(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Times(2).Return([]models.Server{}, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+	cachedClient.SetRefresh(true)
+
+	_, err := cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+}
+
+func (s *CacheSuite) TestServerGetListDisabled(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Times(2).Return([]models.Server{}, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+	cachedClient.SetEnabled(false)
+
+	_, err := cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+
+	_, err = os.Stat(s.dir)
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *CacheSuite) TestServerGetListExpired(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return([]models.Server{}, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+
+	_, err := cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+
+	expired := `{"created":"2019-01-01T00:00:00Z","data":[{"server_ip":"1.2.3.4"}]}`
+	err = ioutil.WriteFile(filepath.Join(s.dir, "servers.json"), []byte(expired), 0600)
+	c.Assert(err, IsNil)
+
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return([]models.Server{}, nil)
+
+	servers, err := cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+	c.Assert(servers, HasLen, 0)
+}
+
+func (s *CacheSuite) TestServerSetNameInvalidates(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	input := &models.ServerSetNameInput{Name: "app-prod-43"}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Times(2).Return([]models.Server{}, nil)
+	mockRobotClient.EXPECT().ServerSetName("123.123.123.123", input).Times(1).Return(&models.Server{}, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+
+	_, err := cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.ServerSetName("123.123.123.123", input)
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.ServerGetList()
+	c.Assert(err, IsNil)
+}
+
+func (s *CacheSuite) TestAddonTransactionGetReadyInvalidates(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().IPGetList().Times(2).Return([]models.IP{}, nil)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().AddonTransactionGet("B1").Times(1).Return(&robot.AddonTransaction{Status: robot.TransactionStatusInProcess}, nil),
+		mockRobotClient.EXPECT().AddonTransactionGet("B1").Times(1).Return(&robot.AddonTransaction{Status: robot.TransactionStatusReady}, nil),
+	)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+
+	_, err := cachedClient.IPGetList()
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.AddonTransactionGet("B1")
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.IPGetList()
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.AddonTransactionGet("B1")
+	c.Assert(err, IsNil)
+
+	_, err = cachedClient.IPGetList()
+	c.Assert(err, IsNil)
+}
+
+func (s *CacheSuite) TestClear(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().KeyGetList().Times(2).Return([]models.Key{}, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, s.dir, time.Minute)
+
+	_, err := cachedClient.KeyGetList()
+	c.Assert(err, IsNil)
+
+	c.Assert(cachedClient.Clear(), IsNil)
+
+	_, err = cachedClient.KeyGetList()
+	c.Assert(err, IsNil)
+}
+
+func (s *CacheSuite) TestDir(c *C) {
+	dir, err := cache.Dir("/tmp/hrobot-cli", "staging")
+	c.Assert(err, IsNil)
+	c.Assert(dir, Equals, "/tmp/hrobot-cli/staging")
+}
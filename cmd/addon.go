@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (app *RobotApp) NewAddonListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "addon:list [server]",
+		Short: "Print addons available for single server",
+		Long: `Print the addons like additional IP's and subnets which can be ordered for single server with
+monthly and setup price (net, EUR), server can be given by name, number or IP as argument or chosen
+interactively.`,
+		Annotations: argCompletion(completeServers),
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			server, err := app.serverArg(args)
+			if err != nil {
+				return err
+			}
+
+			products, err := app.client.AddonProductGetList(server.ServerNumber)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"id", "name", "type", "location", "price / month", "setup"})
+
+			for _, product := range products {
+				t.AppendRow(table.Row{
+					product.ID,
+					product.Name,
+					product.Type,
+					product.Price.Location,
+					product.Price.Price.Net,
+					product.Price.PriceSetup.Net,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "", "Total", len(products)})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewAddonOrderCmd() *cobra.Command {
+	var productID, reason, gateway string
+	var test, wait bool
+	var interval, timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "addon:order [server]",
+		Short: "Order addon for single server",
+		Long: `Order an addon like an additional IP or subnet for single server, server can be given by name,
+number or IP as argument or chosen interactively. Additional IP's require a reason, it is asked for
+if not given by flag. With --test the order is only validated by the webservice and nothing is
+ordered, with --wait the order is polled until the new IP's or subnets are provisioned.`,
+		Annotations: argCompletion(completeServers),
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if wait && interval <= 0 {
+				return fmt.Errorf("interval must be positive, got %s", interval)
+			}
+
+			server, err := app.serverArg(args)
+			if err != nil {
+				return err
+			}
+
+			products, err := app.client.AddonProductGetList(server.ServerNumber)
+			if err != nil {
+				return err
+			}
+
+			product, err := app.chooseAddonProduct(products, productID)
+			if err != nil {
+				return err
+			}
+
+			if reason == "" && strings.HasPrefix(product.Type, "ip_") {
+				reasonPrompt := promptui.Prompt{
+					Label: "Reason for the additional IP",
+					Validate: func(input string) error {
+						if strings.TrimSpace(input) == "" {
+							return errors.New("reason must not be empty")
+						}
+						return nil
+					},
+					Stdout: app.promptOutput(),
+				}
+
+				reason, err = reasonPrompt.Run()
+				if err != nil {
+					app.logger.Errorln("Prompt failed: ", err)
+					return nil
+				}
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"server", fmt.Sprintf("%s (%d)", server.ServerName, server.ServerNumber)})
+			t.AppendRow(table.Row{"addon", fmt.Sprintf("%s (%s)", product.Name, product.ID)})
+			t.AppendRow(table.Row{"price / month", product.Price.Price.Net})
+			t.AppendRow(table.Row{"setup", product.Price.PriceSetup.Net})
+			t.AppendRow(table.Row{"reason", reason})
+			t.AppendRow(table.Row{"gateway", gateway})
+			t.AppendRow(table.Row{"test order", test})
+
+			t.Render()
+
+			label := fmt.Sprintf("Really order %s for %s for %s EUR per month", product.Name, server.ServerName, product.Price.Price.Net)
+			if test {
+				label = fmt.Sprintf("Send test order for %s for %s", product.Name, server.ServerName)
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     label,
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			transaction, err := app.client.AddonTransactionCreate(&robot.AddonOrderInput{
+				ServerNumber: server.ServerNumber,
+				ProductID:    product.ID,
+				Reason:       reason,
+				Gateway:      gateway,
+				Test:         test,
+			})
+			if err != nil {
+				return err
+			}
+
+			if test {
+				app.logger.Infof("Test order for %s was accepted, nothing was ordered", product.Name)
+				return nil
+			}
+
+			app.logger.Infof("Ordered %s for %s, transaction %s is %s", product.Name, server.ServerName, transaction.ID, transaction.Status)
+
+			if !wait {
+				return nil
+			}
+
+			return app.waitForAddonTransaction(transaction.ID, interval, timeout)
+		},
+	}
+
+	cmd.Flags().StringVar(&productID, "product", "", "id of the addon, e.g. additional_ipv4")
+	cmd.Flags().StringVar(&reason, "reason", "", "reason for additional IP's")
+	cmd.Flags().StringVar(&gateway, "gateway", "", "gateway of additional subnets")
+	cmd.Flags().BoolVar(&test, "test", false, "only validate the order, nothing is ordered")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the addon is provisioned")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "interval between status checks while waiting")
+	cmd.Flags().DurationVar(&timeout, "wait-timeout", 24*time.Hour, "maximum time to wait for the addon")
+
+	return cmd
+}
+
+// waitForAddonTransaction polls the addon order until it is ready and prints
+// the provisioned resources
+func (app *RobotApp) waitForAddonTransaction(id string, interval time.Duration, timeout time.Duration) error {
+	start := time.Now()
+
+	for {
+		transaction, err := app.client.AddonTransactionGet(id)
+		if err != nil {
+			return err
+		}
+
+		switch transaction.Status {
+		case robot.TransactionStatusCancelled:
+			return fmt.Errorf("order %s was cancelled", id)
+		case robot.TransactionStatusReady:
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"type", "id"})
+			for _, resource := range transaction.Resources {
+				t.AppendRow(table.Row{resource.Type, resource.ID})
+			}
+
+			t.Render()
+
+			color.Green(fmt.Sprintf("Order %s is ready, the new resources are listed by ip:list, subnet:list and server:get", id))
+			return nil
+		}
+
+		if time.Since(start)+interval > timeout {
+			return fmt.Errorf("order %s not completed after %s, status is %s", id, timeout, transaction.Status)
+		}
+
+		app.logger.Infof("Order %s is %s, checking again in %s ...", id, transaction.Status, interval)
+		time.Sleep(interval)
+	}
+}
+
+// chooseAddonProduct returns the addon with the given id, the addon is chosen
+// interactively if no id is given
+func (app *RobotApp) chooseAddonProduct(products []robot.AddonProduct, id string) (*robot.AddonProduct, error) {
+	if len(products) == 0 {
+		return nil, errors.New("no addons available for server")
+	}
+
+	if id != "" {
+		for i := range products {
+			if products[i].ID == id {
+				return &products[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("addon %q not available for server", id)
+	}
+
+	prompt := promptui.Select{
+		Label: "Select addon",
+		Items: products,
+		Size:  10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "→ {{ .Name | green }} ({{ .ID | yellow }} - {{ .Price.Price.Net | yellow }} EUR)",
+			Inactive: "  {{ .Name | cyan }} ({{ .ID | red }} - {{ .Price.Price.Net | blue }} EUR)",
+			Selected: "→ {{ .Name | cyan }}",
+		},
+		Stdout: app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &products[chosenIdx], nil
+}
@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestAddonListCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	products := []robot.AddonProduct{
+		{
+			ID:   "additional_ipv4",
+			Name: "Additional IP address",
+			Type: "ip_ipv4",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().AddonProductGetList(321).Times(1).Return(products, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "addon:list", "app-prod-84")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestAddonOrderCommandUnknownProduct(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	products := []robot.AddonProduct{
+		{
+			ID:   "additional_ipv4",
+			Name: "Additional IP address",
+			Type: "ip_ipv4",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().AddonProductGetList(321).Times(1).Return(products, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "addon:order", "321", "--product", "subnet_ipv6_64", "--test")
+	c.Assert(err, ErrorMatches, `addon "subnet_ipv6_64" not available for server`)
+}
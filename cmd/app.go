@@ -2,58 +2,163 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
 
-	client "github.com/nl2go/hrobot-go"
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-cli/notify"
+	"github.com/nl2go/hrobot-cli/robot"
 )
 
 const version = "0.1.1"
 const userAgent = "hrobot-cli/" + version
 
 type RobotApp struct {
-	logger *log.Logger
-	client client.RobotClient
+	logger   *log.Logger
+	client   robot.RobotClient
+	history  *history.Store
+	notifier *notify.Notifier
+	// out receives results like tables and reports, errOut prompts and
+	// messages, so results can be parsed from stdout
+	out    io.Writer
+	errOut io.Writer
 }
 
-func NewRobotApp(robotClient client.RobotClient, logger *log.Logger) *RobotApp {
+func NewRobotApp(robotClient robot.RobotClient, logger *log.Logger) *RobotApp {
 	robotClient.SetUserAgent(userAgent)
 
 	return &RobotApp{
 		logger: logger,
 		client: robotClient,
+		out:    os.Stdout,
+		errOut: os.Stderr,
 	}
 }
 
+// SetOutput changes the outputs for results and for prompts and messages,
+// stdout and stderr by default
+func (app *RobotApp) SetOutput(out, errOut io.Writer) {
+	app.out = out
+	app.errOut = errOut
+}
+
+// SetHistory enables the local history of actions like resets
+func (app *RobotApp) SetHistory(store *history.Store) {
+	app.history = store
+}
+
+// SetNotifier enables notifications about mutating actions like resets
+func (app *RobotApp) SetNotifier(notifier *notify.Notifier) {
+	app.notifier = notifier
+}
+
 func (app *RobotApp) Run() error {
 	rootCmd := app.NewRootCommand(app.logger)
-	rootCmd.SetErr(app.logger.Out)
+	rootCmd.SetOut(app.out)
+	rootCmd.SetErr(app.errOut)
 
 	err := rootCmd.Execute()
 	return err
 }
 
 func (app *RobotApp) NewRootCommand(logger *log.Logger) *cobra.Command {
+	var noCache, refresh bool
+
 	rootCmd := &cobra.Command{
 		Use:   "hrobot-cli",
 		Short: fmt.Sprintf("CLI application for the Hetzner Robot API - version %s", version),
+		// errors are logged by the caller of Run
+		SilenceErrors: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// flags are parsed at this point, errors from here on are no usage errors
+			cmd.SilenceUsage = true
+
+			if cachedClient, ok := app.client.(cacheControl); ok {
+				cachedClient.SetEnabled(!noCache)
+				cachedClient.SetRefresh(refresh)
+			}
+		},
 	}
 
+	rootCmd.SetGlobalNormalizationFunc(normalizeSelectorFlags)
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "bypass the local response cache")
+	rootCmd.PersistentFlags().BoolVar(&refresh, "refresh", false, "ignore cached responses and fetch fresh data")
+
 	rootCmd.AddCommand(app.NewServerGetListCmd())
 	rootCmd.AddCommand(app.NewServerGetCmd())
 	rootCmd.AddCommand(app.NewServerReversalCmd())
 	rootCmd.AddCommand(app.NewServerSetNameCmd())
 	rootCmd.AddCommand(app.NewServerActivateRescueCmd())
 	rootCmd.AddCommand(app.NewServerResetCmd())
+	rootCmd.AddCommand(app.NewServerResetStatusCmd())
+	rootCmd.AddCommand(app.NewServerResetHistoryCmd())
+	rootCmd.AddCommand(app.NewServerWolCmd())
 	rootCmd.AddCommand(app.NewServerGenerateAnsibleInventoryCmd())
 	rootCmd.AddCommand(app.NewKeyGetListCmd())
 	rootCmd.AddCommand(app.NewIPGetListCmd())
+	rootCmd.AddCommand(app.NewIPGetCmd())
+	rootCmd.AddCommand(app.NewIPTrafficWarningsCmd())
+	rootCmd.AddCommand(app.NewIPMacGetCmd())
+	rootCmd.AddCommand(app.NewIPMacGenerateCmd())
+	rootCmd.AddCommand(app.NewIPMacDeleteCmd())
+	rootCmd.AddCommand(app.NewSubnetGetListCmd())
+	rootCmd.AddCommand(app.NewSubnetGetCmd())
+	rootCmd.AddCommand(app.NewIPv6PlanCmd())
+	rootCmd.AddCommand(app.NewSubnetMacGetCmd())
+	rootCmd.AddCommand(app.NewSubnetMacSetCmd())
+	rootCmd.AddCommand(app.NewSubnetMacDeleteCmd())
 	rootCmd.AddCommand(app.NewRdnsGetListCmd())
 	rootCmd.AddCommand(app.NewRdnsGetCmd())
+	rootCmd.AddCommand(app.NewFirewallGetCmd())
+	rootCmd.AddCommand(app.NewFirewallApplyCmd())
+	rootCmd.AddCommand(app.NewFirewallDisableCmd())
+	rootCmd.AddCommand(app.NewFirewallTemplateListCmd())
+	rootCmd.AddCommand(app.NewFirewallTemplateCreateCmd())
+	rootCmd.AddCommand(app.NewFirewallTemplateApplyToCmd())
+	rootCmd.AddCommand(app.NewStorageBoxGetListCmd())
+	rootCmd.AddCommand(app.NewStorageBoxGetCmd())
+	rootCmd.AddCommand(app.NewStorageBoxUpdateCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSubaccountListCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSubaccountCreateCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSubaccountUpdateCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSubaccountDeleteCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSnapshotListCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSnapshotCreateCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSnapshotDeleteCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSnapshotRevertCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSnapshotPlanGetCmd())
+	rootCmd.AddCommand(app.NewStorageBoxSnapshotPlanSetCmd())
+	rootCmd.AddCommand(app.NewVSwitchGetListCmd())
+	rootCmd.AddCommand(app.NewVSwitchGetCmd())
+	rootCmd.AddCommand(app.NewVSwitchCreateCmd())
+	rootCmd.AddCommand(app.NewVSwitchUpdateCmd())
+	rootCmd.AddCommand(app.NewVSwitchAddServersCmd())
+	rootCmd.AddCommand(app.NewVSwitchRemoveServersCmd())
+	rootCmd.AddCommand(app.NewVSwitchCancelCmd())
+	rootCmd.AddCommand(app.NewOrderProductsCmd())
+	rootCmd.AddCommand(app.NewOrderServerCmd())
+	rootCmd.AddCommand(app.NewOrderTransactionsCmd())
+	rootCmd.AddCommand(app.NewMarketListCmd())
+	rootCmd.AddCommand(app.NewMarketWatchCmd())
+	rootCmd.AddCommand(app.NewMarketOrderCmd())
+	rootCmd.AddCommand(app.NewAddonListCmd())
+	rootCmd.AddCommand(app.NewAddonOrderCmd())
+	rootCmd.AddCommand(app.NewReportCostCmd())
+	rootCmd.AddCommand(app.NewOverviewCmd())
+	rootCmd.AddCommand(app.NewLintCmd())
+	rootCmd.AddCommand(app.NewExporterCmd())
+	rootCmd.AddCommand(app.NewSnapshotSaveCmd())
+	rootCmd.AddCommand(app.NewSnapshotDiffCmd())
 	rootCmd.AddCommand(app.NewFailoverGetListCmd())
 	rootCmd.AddCommand(app.NewFailoverGetCmd())
+	rootCmd.AddCommand(app.NewTrafficShowCmd())
+	rootCmd.AddCommand(app.NewCacheClearCmd())
+	rootCmd.AddCommand(app.NewCompletionCmd())
+	rootCmd.AddCommand(app.NewCompleteCmd())
 	rootCmd.AddCommand(app.NewVersionCmd())
 
 	return rootCmd
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/retry"
+)
+
+const (
+	bulkStatusOK      = "ok"
+	bulkStatusFailed  = "failed"
+	bulkStatusSkipped = "skipped"
+)
+
+// bulkTask is a single item of an operation applied to multiple items
+type bulkTask struct {
+	Item   string
+	Action string
+	Run    func() error
+}
+
+type bulkResult struct {
+	Item   string `json:"item"`
+	Action string `json:"action"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type bulkReport struct {
+	Total   int          `json:"total"`
+	OK      int          `json:"ok"`
+	Failed  int          `json:"failed"`
+	Skipped int          `json:"skipped"`
+	Items   []bulkResult `json:"items"`
+}
+
+type bulkOptions struct {
+	concurrency int
+	output      string
+}
+
+// skipError marks a task as skipped instead of failed
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+func bulkSkip(format string, a ...interface{}) error {
+	return &skipError{reason: fmt.Sprintf(format, a...)}
+}
+
+func addBulkFlags(cmd *cobra.Command, opts *bulkOptions) {
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 1, "number of items processed in parallel")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", outputTable, "format of the result summary (table, json)")
+}
+
+func validateBulkOptions(opts *bulkOptions) error {
+	if opts.concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", opts.concurrency)
+	}
+
+	return validateOutput(opts.output, outputTable, outputJSON)
+}
+
+// executeBulk runs the tasks, prints the summary and returns an error if any task failed
+func (app *RobotApp) executeBulk(tasks []bulkTask, opts *bulkOptions) error {
+	return app.reportBulk(runBulk(tasks, opts.concurrency), opts)
+}
+
+// reportBulk prints the summary of the results to the output for results,
+// the only output of bulk commands on stdout, and returns an error if any task
+// failed
+func (app *RobotApp) reportBulk(results []bulkResult, opts *bulkOptions) error {
+	report := newBulkReport(results)
+
+	switch opts.output {
+	case outputJSON:
+		if err := writeJSON(app.out, report); err != nil {
+			return err
+		}
+	default:
+		renderBulkReport(app.out, report)
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d items failed", report.Failed, report.Total)
+	}
+
+	return nil
+}
+
+// runBulk processes the tasks with the given number of workers. Once the retry
+// budget for a request is used up, tasks that have not started yet are skipped
+// as they would run into the same rate limit.
+func runBulk(tasks []bulkTask, concurrency int) []bulkResult {
+	results := make([]bulkResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = bulkResult{
+			Item:   task.Item,
+			Action: task.Action,
+			Status: bulkStatusSkipped,
+			Error:  "not attempted",
+		}
+	}
+
+	var aborted int32
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				if atomic.LoadInt32(&aborted) == 1 {
+					continue
+				}
+
+				status, err := runBulkTask(tasks[i])
+				results[i].Status = status
+				results[i].Error = ""
+				if err != nil {
+					results[i].Error = err.Error()
+				}
+
+				var exhaustedErr *retry.ExhaustedError
+				if errors.As(err, &exhaustedErr) {
+					atomic.StoreInt32(&aborted, 1)
+				}
+			}
+		}()
+	}
+
+	for i := range tasks {
+		queue <- i
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return results
+}
+
+func runBulkTask(task bulkTask) (string, error) {
+	err := task.Run()
+	if err == nil {
+		return bulkStatusOK, nil
+	}
+
+	var skipErr *skipError
+	if errors.As(err, &skipErr) {
+		return bulkStatusSkipped, skipErr
+	}
+
+	return bulkStatusFailed, err
+}
+
+func newBulkReport(results []bulkResult) *bulkReport {
+	report := &bulkReport{
+		Total: len(results),
+		Items: results,
+	}
+
+	for _, result := range results {
+		switch result.Status {
+		case bulkStatusOK:
+			report.OK++
+		case bulkStatusFailed:
+			report.Failed++
+		case bulkStatusSkipped:
+			report.Skipped++
+		}
+	}
+
+	return report
+}
+
+func renderBulkReport(w io.Writer, report *bulkReport) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+
+	t.AppendHeader(table.Row{"item", "action", "status", "error"})
+
+	for _, result := range report.Items {
+		t.AppendRow(table.Row{
+			result.Item,
+			result.Action,
+			result.Status,
+			result.Error,
+		})
+	}
+
+	t.AppendFooter(table.Row{"", "", "ok", report.OK})
+	t.AppendFooter(table.Row{"", "", "failed", report.Failed})
+	t.AppendFooter(table.Row{"", "", "skipped", report.Skipped})
+	t.SetCaption("Summary")
+	t.Render()
+}
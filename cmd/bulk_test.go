@@ -0,0 +1,144 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/retry"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestRunBulkStatuses(c *C) {
+	tasks := []cmd.BulkTask{
+		{
+			Item: "123.123.123.123",
+			Run:  func() error { return nil },
+		},
+		{
+			Item: "124.124.124.124",
+			Run:  func() error { return errors.New("server not found") },
+		},
+		{
+			Item: "125.125.125.125",
+			Run:  func() error { return cmd.BulkSkip("already named %s", "app-prod-42") },
+		},
+	}
+
+	results := cmd.RunBulk(tasks, 1)
+
+	c.Assert(results, HasLen, 3)
+	c.Assert(results[0].Status, Equals, "ok")
+	c.Assert(results[1].Status, Equals, "failed")
+	c.Assert(results[1].Error, Equals, "server not found")
+	c.Assert(results[2].Status, Equals, "skipped")
+	c.Assert(results[2].Error, Equals, "already named app-prod-42")
+}
+
+func (s *AppSuite) TestRunBulkConcurrency(c *C) {
+	var calls int32
+
+	var tasks []cmd.BulkTask
+	for i := 0; i < 20; i++ {
+		tasks = append(tasks, cmd.BulkTask{
+			Item: "123.123.123.123",
+			Run: func() error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			},
+		})
+	}
+
+	results := cmd.RunBulk(tasks, 4)
+
+	c.Assert(atomic.LoadInt32(&calls), Equals, int32(20))
+	for _, result := range results {
+		c.Assert(result.Status, Equals, "ok")
+	}
+}
+
+func (s *AppSuite) TestRunBulkAbortsWhenRetriesExhausted(c *C) {
+	tasks := []cmd.BulkTask{
+		{
+			Item: "123.123.123.123",
+			Run:  func() error { return nil },
+		},
+		{
+			Item: "124.124.124.124",
+			Run: func() error {
+				return &retry.ExhaustedError{Call: "ServerSetName", Attempts: 6, Err: errors.New("rate limit exceeded")}
+			},
+		},
+		{
+			Item: "125.125.125.125",
+			Run:  func() error { return nil },
+		},
+	}
+
+	results := cmd.RunBulk(tasks, 1)
+
+	c.Assert(results[0].Status, Equals, "ok")
+	c.Assert(results[1].Status, Equals, "failed")
+	c.Assert(results[2].Status, Equals, "skipped")
+	c.Assert(results[2].Error, Equals, "not attempted")
+}
+
+func (s *AppSuite) TestBulkJSONReportOnlyOutputOnStdout(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	servers := []models.Server{
+		{
+			ServerIP:     "127.0.0.1",
+			ServerNumber: 321,
+			ServerName:   "batch-1",
+		},
+	}
+
+	wol := &robot.Wol{
+		ServerIP:     "127.0.0.1",
+		ServerNumber: 321,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().WolGet("127.0.0.1").Times(1).Return(wol, nil)
+	mockRobotClient.EXPECT().WolSend("127.0.0.1").Times(1).Return(wol, nil)
+
+	var stdout, stderr bytes.Buffer
+
+	logger := log.New()
+	logger.SetOutput(&stderr)
+
+	app := cmd.NewRobotApp(mockRobotClient, logger)
+	app.SetOutput(&stdout, &stderr)
+
+	_, err = executeCommand(app.NewRootCommand(logger), "server:wol", "--servers", "batch-1", "--wait-port", strconv.Itoa(port), "--output", "json")
+	c.Assert(err, IsNil)
+
+	var report struct {
+		Total int `json:"total"`
+		OK    int `json:"ok"`
+	}
+	c.Assert(json.Unmarshal(stdout.Bytes(), &report), IsNil)
+	c.Assert(report.Total, Equals, 1)
+	c.Assert(report.OK, Equals, 1)
+	c.Assert(stderr.String(), Matches, "(?s).*Waiting for port.*")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// cacheControl is implemented by robot clients keeping a local response cache
+type cacheControl interface {
+	SetEnabled(enabled bool)
+	SetRefresh(refresh bool)
+	Clear() error
+}
+
+func (app *RobotApp) NewCacheClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cache:clear",
+		Short: "Clear local response cache",
+		Long:  "Clear the locally cached responses of the current profile",
+		Run: func(cmd *cobra.Command, args []string) {
+			cachedClient, ok := app.client.(cacheControl)
+			if !ok {
+				app.logger.Infoln("Response cache is not enabled.")
+				return
+			}
+
+			if err := cachedClient.Clear(); err != nil {
+				app.logger.Errorln("Error while clearing cache:", err)
+				return
+			}
+
+			color.Cyan("Response cache cleared successfully.")
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package cmd_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cache"
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestCacheClearCommandNotEnabled(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "cache:clear")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestServerListCommandNoCache(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(2).Return([]models.Server{}, nil)
+
+	cachedClient := cache.NewClient(mockRobotClient, c.MkDir(), time.Minute)
+	app := cmd.NewRobotApp(cachedClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:list", "--no-cache")
+	c.Assert(err, IsNil)
+
+	_, err = executeCommand(rootCmd, "server:list", "--no-cache")
+	c.Assert(err, IsNil)
+}
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// completion kinds of flags and arguments, the values are fetched from the
+// webservice when completing, list responses are served by the response cache
+const (
+	completionAnnotation = "hrobot_cli_completion"
+
+	completeServers = "servers"
+	completeIPs     = "ips"
+	completeKeys    = "keys"
+)
+
+// the scripts delegate to the hidden __complete command, cobra v0.0.5 only
+// supports custom completion functions for bash and has no fish support
+var completionScripts = map[string]string{
+	"bash": `# bash completion for %[1]s
+
+__%[2]s_complete() {
+    local cur words cword
+    if declare -F _get_comp_words_by_ref >/dev/null 2>&1; then
+        _get_comp_words_by_ref -n =: cur words cword
+    else
+        cur="${COMP_WORDS[COMP_CWORD]}"
+        words=("${COMP_WORDS[@]}")
+        cword=$COMP_CWORD
+    fi
+
+    local IFS=$'\n'
+    COMPREPLY=($(command %[1]s __complete "${words[@]:1:cword}" 2>/dev/null))
+
+    if [[ $cur == *=* && $COMP_WORDBREAKS == *=* ]]; then
+        COMPREPLY=("${COMPREPLY[@]#*=}")
+    fi
+
+    if declare -F __ltrim_colon_completions >/dev/null 2>&1; then
+        __ltrim_colon_completions "$cur"
+    fi
+}
+
+complete -o default -F __%[2]s_complete %[1]s
+`,
+	"zsh": `#compdef %[1]s
+
+_%[1]s() {
+    local -a candidates
+    candidates=(${(f)"$(command %[1]s __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+
+    if (( ${#candidates} )); then
+        compadd -- "${candidates[@]}"
+    else
+        _files
+    fi
+}
+
+if [ "$funcstack[1]" = "_%[1]s" ]; then
+    _%[1]s "$@"
+else
+    compdef _%[1]s %[1]s
+fi
+`,
+	"fish": `# fish completion for %[1]s
+
+function __%[2]s_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    set -l candidates (command %[1]s __complete $tokens[2..-1] 2>/dev/null)
+
+    if test (count $candidates) -gt 0
+        printf '%%s\n' $candidates
+    else
+        __fish_complete_path (commandline -ct)
+    end
+end
+
+complete -c %[1]s -f -a '(__%[2]s_complete)'
+`,
+}
+
+func markFlagCompletion(cmd *cobra.Command, name string, kind string) {
+	// the flag is defined by the caller, an unknown flag is a programming error
+	if err := cmd.Flags().SetAnnotation(name, completionAnnotation, []string{kind}); err != nil {
+		panic(err)
+	}
+}
+
+// argCompletion returns the annotations completing the first argument
+func argCompletion(kind string) map[string]string {
+	return map[string]string{completionAnnotation: kind}
+}
+
+func (app *RobotApp) NewCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Print shell completion script",
+		Long: `Print the completion script for bash, zsh or fish. Besides commands and flags, server names,
+numbers and IP's (--server, --servers), IP's and failover IP's (--ip, --ips) and ssh keys (--key,
+--keys) are completed from the webservice, the lists are served by the response cache.
+
+  bash:  source <(hrobot-cli completion bash)
+  zsh:   hrobot-cli completion zsh > "${fpath[1]}/_hrobot-cli"
+  fish:  hrobot-cli completion fish > ~/.config/fish/completions/hrobot-cli.fish`,
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Args:      cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			script, ok := completionScripts[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown shell %q, use one of: bash, zsh, fish", args[0])
+			}
+
+			name := cmd.Root().Name()
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), script, name, strings.Replace(name, "-", "_", -1))
+			return err
+		},
+	}
+}
+
+func (app *RobotApp) NewCompleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:                "__complete [words] [current word]",
+		Short:              "Print completion candidates for the command line",
+		Hidden:             true,
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			// log output like warnings of retried requests would end up in the
+			// candidates read by the shell
+			out := app.logger.Out
+			app.logger.SetOutput(ioutil.Discard)
+			defer app.logger.SetOutput(out)
+
+			for _, candidate := range app.complete(cmd.Root(), args) {
+				fmt.Fprintln(cmd.OutOrStdout(), candidate)
+			}
+		},
+	}
+}
+
+// complete returns the candidates for the last word, the words before are
+// the command line without the program name
+func (app *RobotApp) complete(root *cobra.Command, args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	words, current := args[:len(args)-1], args[len(args)-1]
+
+	cmd := root
+	positional := 0
+	var pending *pflag.Flag
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+
+		if strings.HasPrefix(word, "-") {
+			flag := lookupCompletionFlag(cmd, word)
+			if flag != nil && flag.NoOptDefVal == "" && !strings.Contains(word, "=") {
+				if i+1 < len(words) {
+					i++
+				} else {
+					pending = flag
+				}
+			}
+
+			continue
+		}
+
+		if cmd == root {
+			if sub, _, err := root.Find([]string{word}); err == nil && sub != root {
+				cmd = sub
+				continue
+			}
+		}
+
+		positional++
+	}
+
+	if pending != nil {
+		return app.completeValues(pending.Annotations[completionAnnotation], "", current)
+	}
+
+	if strings.HasPrefix(current, "-") {
+		if idx := strings.Index(current, "="); idx >= 0 {
+			flag := lookupCompletionFlag(cmd, current[:idx])
+			if flag == nil {
+				return nil
+			}
+
+			return app.completeValues(flag.Annotations[completionAnnotation], current[:idx+1], current[idx+1:])
+		}
+
+		return completeFlags(cmd, current)
+	}
+
+	if cmd == root {
+		var names []string
+		for _, sub := range root.Commands() {
+			if sub.IsAvailableCommand() && strings.HasPrefix(sub.Name(), current) {
+				names = append(names, sub.Name())
+			}
+		}
+
+		return names
+	}
+
+	if positional == 0 {
+		if kind, ok := cmd.Annotations[completionAnnotation]; ok {
+			return app.completeValues([]string{kind}, "", current)
+		}
+
+		var valid []string
+		for _, arg := range cmd.ValidArgs {
+			if strings.HasPrefix(arg, current) {
+				valid = append(valid, arg)
+			}
+		}
+
+		return valid
+	}
+
+	return nil
+}
+
+func lookupCompletionFlag(cmd *cobra.Command, word string) *pflag.Flag {
+	if idx := strings.Index(word, "="); idx >= 0 {
+		word = word[:idx]
+	}
+
+	var flag *pflag.Flag
+	switch {
+	case strings.HasPrefix(word, "--"):
+		flag = cmd.Flags().Lookup(word[2:])
+		if flag == nil {
+			flag = cmd.InheritedFlags().Lookup(word[2:])
+		}
+	case len(word) == 2:
+		flag = cmd.Flags().ShorthandLookup(word[1:])
+		if flag == nil {
+			flag = cmd.InheritedFlags().ShorthandLookup(word[1:])
+		}
+	}
+
+	return flag
+}
+
+func completeFlags(cmd *cobra.Command, current string) []string {
+	var names []string
+
+	add := func(flag *pflag.Flag) {
+		name := "--" + flag.Name
+		if !flag.Hidden && strings.HasPrefix(name, current) {
+			names = append(names, name)
+		}
+	}
+
+	cmd.Flags().VisitAll(add)
+	cmd.InheritedFlags().VisitAll(add)
+
+	sort.Strings(names)
+	return uniqueSorted(names)
+}
+
+// completeValues completes the last element of a comma separated list,
+// elements given before are not offered again
+func (app *RobotApp) completeValues(annotation []string, prefix string, current string) []string {
+	if len(annotation) == 0 {
+		return nil
+	}
+
+	given := ""
+	partial := current
+	if idx := strings.LastIndex(current, ","); idx >= 0 {
+		given, partial = current[:idx+1], current[idx+1:]
+	}
+
+	used := make(map[string]bool)
+	for _, value := range strings.Split(given, ",") {
+		used[value] = true
+	}
+
+	var candidates []string
+	for _, value := range app.completionValues(annotation[0]) {
+		if strings.HasPrefix(value, partial) && !used[value] {
+			candidates = append(candidates, prefix+given+value)
+		}
+	}
+
+	return candidates
+}
+
+// completionValues fetches the values of the kind, errors result in no
+// values as completion must not print errors
+func (app *RobotApp) completionValues(kind string) []string {
+	var values []string
+
+	switch kind {
+	case completeServers:
+		servers, _ := app.client.ServerGetList()
+		for _, server := range servers {
+			values = append(values, server.ServerName, strconv.Itoa(server.ServerNumber), server.ServerIP)
+		}
+	case completeIPs:
+		ips, _ := app.client.IPGetList()
+		for _, ip := range ips {
+			values = append(values, ip.IP)
+		}
+
+		failovers, _ := app.client.FailoverGetList()
+		for _, failover := range failovers {
+			values = append(values, failover.IP)
+		}
+	case completeKeys:
+		keys, _ := app.client.KeyGetList()
+		for _, key := range keys {
+			values = append(values, key.Name, key.Fingerprint)
+		}
+	}
+
+	var nonEmpty []string
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+
+	sort.Strings(nonEmpty)
+	return uniqueSorted(nonEmpty)
+}
+
+// uniqueSorted removes duplicates of a sorted slice
+func uniqueSorted(values []string) []string {
+	var unique []string
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,183 @@
+package cmd_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+var completionServers = []models.Server{
+	{ServerIP: "123.123.123.123", ServerNumber: 42, ServerName: "app-prod-42"},
+	{ServerIP: "124.124.124.124", ServerNumber: 84, ServerName: "app-prod-84"},
+	{ServerIP: "125.125.125.125", ServerNumber: 126, ServerName: "db-prod-126"},
+}
+
+func completionCandidates(output string) []string {
+	if output == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+}
+
+func (s *AppSuite) TestCompleteCommands(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	output, err := executeCommand(rootCmd, "__complete", "server:res")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"server:rescue", "server:reset", "server:reset:history", "server:reset:status"})
+
+	output, err = executeCommand(rootCmd, "__complete", "server:reset", "--ba")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"--batch-size"})
+
+	output, err = executeCommand(rootCmd, "__complete", "completion", "")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"bash", "zsh", "fish"})
+}
+
+func (s *AppSuite) TestCompleteServers(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(5).Return(completionServers, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	output, err := executeCommand(rootCmd, "__complete", "server:reset", "--type", "sw", "--servers", "app")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"app-prod-42", "app-prod-84"})
+
+	output, err = executeCommand(rootCmd, "__complete", "server:reset", "--servers=app-prod-42,")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{
+		"--servers=app-prod-42,123.123.123.123",
+		"--servers=app-prod-42,124.124.124.124",
+		"--servers=app-prod-42,125.125.125.125",
+		"--servers=app-prod-42,126",
+		"--servers=app-prod-42,42",
+		"--servers=app-prod-42,84",
+		"--servers=app-prod-42,app-prod-84",
+		"--servers=app-prod-42,db-prod-126",
+	})
+
+	output, err = executeCommand(rootCmd, "__complete", "firewall:get", "12")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"123.123.123.123", "124.124.124.124", "125.125.125.125", "126"})
+
+	// the first argument is given already
+	output, err = executeCommand(rootCmd, "__complete", "firewall:get", "42", "")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), HasLen, 0)
+
+	output, err = executeCommand(rootCmd, "__complete", "ip:traffic-warnings", "--servers", "db")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"db-prod-126"})
+
+	output, err = executeCommand(rootCmd, "__complete", "server:reset", "--server", "app-prod-8")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"app-prod-84"})
+}
+
+func (s *AppSuite) TestCompleteIPsAndKeys(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	ips := []models.IP{{IP: "123.123.123.123"}, {IP: "123.123.123.124"}}
+	failovers := []models.Failover{{IP: "123.123.123.200"}}
+	keys := []models.Key{{Name: "deploy", Fingerprint: "aa:bb:cc"}}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPGetList().Times(2).Return(ips, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(2).Return(failovers, nil)
+	mockRobotClient.EXPECT().KeyGetList().Times(2).Return(keys, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	output, err := executeCommand(rootCmd, "__complete", "ip:traffic-warnings", "--ips", "123.123.123.123,")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"123.123.123.123,123.123.123.124", "123.123.123.123,123.123.123.200"})
+
+	output, err = executeCommand(rootCmd, "__complete", "ip:traffic-warnings", "--ip=123.123.123.2")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"--ip=123.123.123.200"})
+
+	output, err = executeCommand(rootCmd, "__complete", "order:server", "--keys", "")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"aa:bb:cc", "deploy"})
+
+	output, err = executeCommand(rootCmd, "__complete", "server:rescue", "--key", "de")
+	c.Assert(err, IsNil)
+	c.Assert(completionCandidates(output), DeepEquals, []string{"deploy"})
+}
+
+func (s *AppSuite) TestCompleteFailedRequest(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	output, err := executeCommand(rootCmd, "__complete", "server:reset", "--servers", "")
+	c.Assert(err, IsNil)
+	c.Assert(output, Equals, "")
+}
+
+func (s *AppSuite) TestCompletionCommand(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	output, err := executeCommand(rootCmd, "completion", "bash")
+	c.Assert(err, IsNil)
+	c.Assert(output, Matches, `(?s).*complete -o default -F __hrobot_cli_complete hrobot-cli\n`)
+
+	output, err = executeCommand(rootCmd, "completion", "zsh")
+	c.Assert(err, IsNil)
+	c.Assert(output, Matches, `(?s)#compdef hrobot-cli\n.*`)
+
+	output, err = executeCommand(rootCmd, "completion", "fish")
+	c.Assert(err, IsNil)
+	c.Assert(output, Matches, `(?s).*complete -c hrobot-cli -f -a '\(__hrobot_cli_complete\)'\n`)
+
+	_, err = executeCommand(rootCmd, "completion", "powershell")
+	c.Assert(err, ErrorMatches, `unknown shell "powershell", use one of: bash, zsh, fish`)
+}
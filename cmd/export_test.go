@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// internals exported for the tests of package cmd_test only
+type (
+	BulkTask = bulkTask
+	CostRow  = costRow
+)
+
+var (
+	RunBulk      = runBulk
+	BulkSkip     = bulkSkip
+	NewHostRange = newHostRange
+
+	DiffFirewallRules  = diffFirewallRules
+	SortMarketProducts = sortMarketProducts
+)
+
+// BuildCostReport prices the resources with the given price file only
+func BuildCostReport(servers []models.Server, ips []models.IP, subnets []robot.Subnet, pricesPath string, by string) ([]CostRow, CostRow, error) {
+	file, err := loadPriceFile(pricesPath)
+	if err != nil {
+		return nil, CostRow{}, err
+	}
+
+	report := buildCostReport(servers, ips, subnets, &costPricer{file: file}, by)
+
+	return report.Rows, report.Total, nil
+}
+
+// SetLookupHost replaces the resolver of the rdns-resolve check, the returned
+// function restores it
+func SetLookupHost(fn func(host string) ([]string, error)) func() {
+	previous := lookupHost
+	lookupHost = fn
+
+	return func() {
+		lookupHost = previous
+	}
+}
+
+// ExecuteRolling runs the action like server:reset without confirmation
+func (app *RobotApp) ExecuteRolling(command string, servers []models.Server, action string, run func(server models.Server) error) error {
+	return app.executeRolling(command, servers, action, run, &rollingOptions{}, &bulkOptions{concurrency: 1, output: outputTable})
+}
+
+// ActivateRescue activates the rescue system like server:rescue without prompts
+func (app *RobotApp) ActivateRescue(server models.Server, input *models.RescueSetInput) error {
+	return app.activateRescue(server, input, false)
+}
+
+// CheckRescueOptions fetches the rescue options of the servers and checks the
+// chosen ones for each server like server:rescue
+func (app *RobotApp) CheckRescueOptions(servers []models.Server, osName string, arch int) ([]error, error) {
+	options, err := app.fetchRescueOptions(servers)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(servers))
+	for i, server := range servers {
+		errs[i] = checkRescueOptions(options, server, osName, arch)
+	}
+
+	return errs, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/exporter"
+)
+
+// minExporterInterval keeps the exporter well below the request limits of
+// the webservice, the server list allows 200 requests per hour
+const minExporterInterval = time.Minute
+
+func (app *RobotApp) NewExporterCmd() *cobra.Command {
+	var listen string
+	var opts exporter.Options
+
+	cmd := &cobra.Command{
+		Use:   "exporter",
+		Short: "Serve metrics of the account for Prometheus",
+		Long: `Poll the webservice every --interval and serve the metrics on http://<listen>/metrics in the
+Prometheus text format:
+
+  hrobot_servers                     servers by datacenter, product and status
+  hrobot_server_cancelled            cancellation state per server
+  hrobot_failover_active_server      server the failover IP is routed to
+  hrobot_failover_routed_to_owner    failover IP is routed to the server it belongs to
+  hrobot_traffic_in_gigabytes        traffic of the current month per IP (--traffic)
+  hrobot_traffic_out_gigabytes
+  hrobot_rescue_active               rescue system active per server (--rescue)
+  hrobot_api_requests_total          requests and failed requests per API call
+  hrobot_api_errors_total
+
+The response cache is bypassed. Rate limited requests are retried by the client, metrics of a
+failed poll keep their previous value. The rescue status costs one request per server and poll,
+only enable it with an interval that keeps the requests within the limit of 500 per hour.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Interval < minExporterInterval {
+				return fmt.Errorf("interval must be at least %s, got %s", minExporterInterval, opts.Interval)
+			}
+
+			if cachedClient, ok := app.client.(cacheControl); ok {
+				cachedClient.SetRefresh(true)
+			}
+
+			e := exporter.New(app.client, app.logger, opts)
+
+			stop := make(chan struct{})
+			defer close(stop)
+			go e.Run(stop)
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", e)
+
+			app.logger.Infof("Serving metrics on %s/metrics", listen)
+			return http.ListenAndServe(listen, mux)
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", ":9777", "address to serve the metrics on")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", 5*time.Minute, "time between polls of the webservice")
+	cmd.Flags().BoolVar(&opts.Traffic, "traffic", true, "collect the traffic of the current month per IP")
+	cmd.Flags().BoolVar(&opts.Rescue, "rescue", false, "collect the rescue status of every server")
+
+	return cmd
+}
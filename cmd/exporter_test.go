@@ -0,0 +1,26 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/test/mock"
+)
+
+func (s *AppSuite) TestExporterCommandIntervalTooShort(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "exporter", "--interval", "10s")
+	c.Assert(err, ErrorMatches, "interval must be at least 1m0s, got 10s")
+}
@@ -63,6 +63,7 @@ func (app *RobotApp) NewFailoverGetCmd() *cobra.Command {
 				Size:              10,
 				Templates:         getFailoverSelectTemplates(),
 				StartInSearchMode: true,
+				Stdout:            app.promptOutput(),
 			}
 
 			choosenIdx, _, err := prompt.Run()
@@ -0,0 +1,623 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// maximum number of rules per direction accepted by the webservice
+const firewallMaxRules = 10
+
+// firewallFile is the desired firewall configuration of a rule file, options
+// which are not set keep their current value
+type firewallFile struct {
+	FilterIPv6   *bool `yaml:"filter_ipv6"`
+	WhitelistHOS *bool `yaml:"whitelist_hos"`
+	Rules        struct {
+		Input []robot.FirewallRule `yaml:"input"`
+	} `yaml:"rules"`
+}
+
+// firewallPlan holds the changes needed to get from the current to the
+// desired firewall configuration of a server
+type firewallPlan struct {
+	server  models.Server
+	lines   []string
+	changed bool
+	input   *robot.FirewallInput
+}
+
+func (app *RobotApp) NewFirewallGetCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "firewall:get [server]",
+		Short: "Print firewall of single server",
+		Long: `Print status, options and rules of the firewall of single server in hetzner account, server can
+be given by name, number or IP as argument or chosen interactively. The yaml output can be used
+as rule file for firewall:apply.`,
+		Annotations: argCompletion(completeServers),
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputTable, outputYAML); err != nil {
+				return err
+			}
+
+			server, err := app.serverArg(args)
+			if err != nil {
+				return err
+			}
+
+			firewall, err := app.client.FirewallGet(server.ServerIP)
+			if err != nil {
+				return err
+			}
+
+			if output == outputYAML {
+				var file firewallFile
+				file.FilterIPv6 = &firewall.FilterIPv6
+				file.WhitelistHOS = &firewall.WhitelistHOS
+				file.Rules.Input = firewall.Rules.Input
+
+				return printYAML(file)
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"server ip", firewall.ServerIP})
+			t.AppendRow(table.Row{"server number", firewall.ServerNumber})
+			t.AppendRow(table.Row{"status", firewall.Status})
+			t.AppendRow(table.Row{"filter ipv6", firewall.FilterIPv6})
+			t.AppendRow(table.Row{"whitelist hetzner services", firewall.WhitelistHOS})
+			t.AppendRow(table.Row{"port", firewall.Port})
+
+			t.Render()
+
+			renderFirewallRules("Input rules", firewall.Rules.Input)
+			if len(firewall.Rules.Output) > 0 {
+				renderFirewallRules("Output rules", firewall.Rules.Output)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format (table, yaml)")
+
+	return cmd
+}
+
+func (app *RobotApp) NewFirewallApplyCmd() *cobra.Command {
+	var sel serverSelector
+	var opts bulkOptions
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "firewall:apply",
+		Short: "Apply firewall rule file to selected servers",
+		Long: `Apply the input rules of a rule file to the firewalls of selected servers and activate them.
+The changes between the current and the desired rules are shown as plan before anything is changed,
+servers without changes are skipped. Servers can be chosen interactively or by flags.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			desired, err := loadFirewallFile(file)
+			if err != nil {
+				return err
+			}
+
+			chosenServers, err := app.selectServers(&sel)
+			if err != nil {
+				return err
+			}
+
+			plans, err := app.planFirewalls(chosenServers, func(current *robot.Firewall) *robot.FirewallInput {
+				input := &robot.FirewallInput{
+					Status:       robot.FirewallStatusActive,
+					FilterIPv6:   current.FilterIPv6,
+					WhitelistHOS: current.WhitelistHOS,
+					Rules: robot.FirewallRules{
+						Input:  desired.Rules.Input,
+						Output: current.Rules.Output,
+					},
+				}
+
+				if desired.FilterIPv6 != nil {
+					input.FilterIPv6 = *desired.FilterIPv6
+				}
+
+				if desired.WhitelistHOS != nil {
+					input.WhitelistHOS = *desired.WhitelistHOS
+				}
+
+				return input
+			})
+			if err != nil {
+				return err
+			}
+
+			return app.executeFirewallPlans(plans, &opts, func(plan *firewallPlan) error {
+				_, err := app.client.FirewallSet(plan.server.ServerIP, plan.input)
+				return err
+			})
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "rule file in yaml format")
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+func (app *RobotApp) NewFirewallDisableCmd() *cobra.Command {
+	var sel serverSelector
+	var opts bulkOptions
+
+	cmd := &cobra.Command{
+		Use:   "firewall:disable",
+		Short: "Disable firewall of selected servers",
+		Long: `Disable the firewalls of selected servers in hetzner account, the rules are kept. Servers can be
+chosen interactively or by flags.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			chosenServers, err := app.selectServers(&sel)
+			if err != nil {
+				return err
+			}
+
+			plans, err := app.planFirewalls(chosenServers, func(current *robot.Firewall) *robot.FirewallInput {
+				return &robot.FirewallInput{
+					Status:       robot.FirewallStatusDisabled,
+					FilterIPv6:   current.FilterIPv6,
+					WhitelistHOS: current.WhitelistHOS,
+					Rules:        current.Rules,
+				}
+			})
+			if err != nil {
+				return err
+			}
+
+			return app.executeFirewallPlans(plans, &opts, func(plan *firewallPlan) error {
+				_, err := app.client.FirewallSet(plan.server.ServerIP, plan.input)
+				return err
+			})
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+func (app *RobotApp) NewFirewallTemplateListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "firewall:template:list",
+		Short: "Print list of firewall templates",
+		Long:  "Print list of firewall templates in the hetzner account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templates, err := app.client.FirewallTemplateGetList()
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"id", "name", "filter_ipv6", "whitelist_hos", "default"})
+
+			for _, template := range templates {
+				t.AppendRow(table.Row{
+					template.ID,
+					template.Name,
+					template.FilterIPv6,
+					template.WhitelistHOS,
+					template.IsDefault,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "Total", len(templates)})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewFirewallTemplateCreateCmd() *cobra.Command {
+	var name, file string
+	var isDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "firewall:template:create",
+		Short: "Create firewall template from rule file",
+		Long:  "Create a firewall template in the hetzner account from the input rules of a rule file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if name == "" {
+				return errors.New("name must not be empty")
+			}
+
+			desired, err := loadFirewallFile(file)
+			if err != nil {
+				return err
+			}
+
+			input := &robot.FirewallTemplateInput{
+				Name: name,
+				// the webservice enables the whitelist by default
+				WhitelistHOS: true,
+				IsDefault:    isDefault,
+				Rules: robot.FirewallRules{
+					Input: desired.Rules.Input,
+				},
+			}
+
+			if desired.FilterIPv6 != nil {
+				input.FilterIPv6 = *desired.FilterIPv6
+			}
+
+			if desired.WhitelistHOS != nil {
+				input.WhitelistHOS = *desired.WhitelistHOS
+			}
+
+			template, err := app.client.FirewallTemplateCreate(input)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Created firewall template %d (%s)", template.ID, template.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name of the template")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "rule file in yaml format")
+	cmd.Flags().BoolVar(&isDefault, "default", false, "use the template for new servers")
+
+	return cmd
+}
+
+func (app *RobotApp) NewFirewallTemplateApplyToCmd() *cobra.Command {
+	var sel serverSelector
+	var opts bulkOptions
+
+	cmd := &cobra.Command{
+		Use:   "firewall:template:apply-to <template-id>",
+		Short: "Apply firewall template to selected servers",
+		Long: `Apply a firewall template to the firewalls of selected servers and activate them. The changes
+between the current rules and the rules of the template are shown as plan before anything is changed,
+servers without changes are skipped. Servers can be chosen interactively or by flags.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid template id %q", args[0])
+			}
+
+			template, err := app.client.FirewallTemplateGet(id)
+			if err != nil {
+				return err
+			}
+
+			chosenServers, err := app.selectServers(&sel)
+			if err != nil {
+				return err
+			}
+
+			plans, err := app.planFirewalls(chosenServers, func(current *robot.Firewall) *robot.FirewallInput {
+				return &robot.FirewallInput{
+					Status:       robot.FirewallStatusActive,
+					FilterIPv6:   template.FilterIPv6,
+					WhitelistHOS: template.WhitelistHOS,
+					Rules:        template.Rules,
+				}
+			})
+			if err != nil {
+				return err
+			}
+
+			return app.executeFirewallPlans(plans, &opts, func(plan *firewallPlan) error {
+				_, err := app.client.FirewallApplyTemplate(plan.server.ServerIP, template.ID)
+				return err
+			})
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+// planFirewalls fetches the current firewalls of the servers and prints the
+// changes needed to get to the desired configuration
+func (app *RobotApp) planFirewalls(servers []models.Server, desired func(*robot.Firewall) *robot.FirewallInput) ([]*firewallPlan, error) {
+	var plans []*firewallPlan
+
+	for _, server := range servers {
+		current, err := app.client.FirewallGet(server.ServerIP)
+		if err != nil {
+			return nil, err
+		}
+
+		plan := newFirewallPlan(server, current, desired(current))
+		renderFirewallPlan(app.errOut, plan)
+
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// executeFirewallPlans asks for a confirmation of the changed servers and
+// applies the plans, servers without changes are skipped
+func (app *RobotApp) executeFirewallPlans(plans []*firewallPlan, opts *bulkOptions, apply func(*firewallPlan) error) error {
+	var changedServers []models.Server
+	for _, plan := range plans {
+		if plan.changed {
+			changedServers = append(changedServers, plan.server)
+		}
+	}
+
+	if len(changedServers) == 0 {
+		app.logger.Infoln("No changes, the firewalls are up to date")
+		return nil
+	}
+
+	if err := app.confirmServers(changedServers, "change the firewall of"); err != nil {
+		app.logger.Errorln("Prompt failed: ", err)
+		return nil
+	}
+
+	var tasks []bulkTask
+	for _, plan := range plans {
+		plan := plan
+
+		tasks = append(tasks, bulkTask{
+			Item:   plan.server.ServerIP,
+			Action: "update firewall",
+			Run: func() error {
+				if !plan.changed {
+					return bulkSkip("no changes")
+				}
+
+				return apply(plan)
+			},
+		})
+	}
+
+	return app.executeBulk(tasks, opts)
+}
+
+func newFirewallPlan(server models.Server, current *robot.Firewall, input *robot.FirewallInput) *firewallPlan {
+	plan := &firewallPlan{
+		server: server,
+		input:  input,
+	}
+
+	if current.Status != input.Status {
+		plan.lines = append(plan.lines, fmt.Sprintf("  status: %s → %s", current.Status, input.Status))
+	}
+
+	if current.FilterIPv6 != input.FilterIPv6 {
+		plan.lines = append(plan.lines, fmt.Sprintf("  filter_ipv6: %t → %t", current.FilterIPv6, input.FilterIPv6))
+	}
+
+	if current.WhitelistHOS != input.WhitelistHOS {
+		plan.lines = append(plan.lines, fmt.Sprintf("  whitelist_hos: %t → %t", current.WhitelistHOS, input.WhitelistHOS))
+	}
+
+	plan.changed = len(plan.lines) > 0
+
+	for _, line := range diffFirewallRules(current.Rules.Input, input.Rules.Input) {
+		if !strings.HasPrefix(line, " ") {
+			plan.changed = true
+		}
+
+		plan.lines = append(plan.lines, line)
+	}
+
+	return plan
+}
+
+// diffFirewallRules compares the ordered rules by their longest common
+// subsequence, lines start with "+" for added, "-" for removed and a space
+// for unchanged rules
+func diffFirewallRules(current []robot.FirewallRule, desired []robot.FirewallRule) []string {
+	lcs := make([][]int, len(current)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(desired)+1)
+	}
+
+	for i := len(current) - 1; i >= 0; i-- {
+		for j := len(desired) - 1; j >= 0; j-- {
+			switch {
+			case current[i] == desired[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+
+	for i < len(current) || j < len(desired) {
+		switch {
+		case i < len(current) && j < len(desired) && current[i] == desired[j]:
+			lines = append(lines, "  "+formatFirewallRule(current[i]))
+			i++
+			j++
+		case j < len(desired) && (i == len(current) || lcs[i][j+1] >= lcs[i+1][j]):
+			lines = append(lines, "+ "+formatFirewallRule(desired[j]))
+			j++
+		default:
+			lines = append(lines, "- "+formatFirewallRule(current[i]))
+			i++
+		}
+	}
+
+	return lines
+}
+
+func formatFirewallRule(rule robot.FirewallRule) string {
+	parts := []string{rule.Action}
+
+	fields := []struct {
+		label string
+		value string
+	}{
+		{"", rule.IPVersion},
+		{"", rule.Protocol},
+		{"src ", rule.SrcIP},
+		{"src_port ", rule.SrcPort},
+		{"dst ", rule.DstIP},
+		{"dst_port ", rule.DstPort},
+		{"tcp_flags ", rule.TCPFlags},
+	}
+
+	for _, field := range fields {
+		if field.value != "" {
+			parts = append(parts, field.label+field.value)
+		}
+	}
+
+	name := rule.Name
+	if name == "" {
+		name = "(unnamed)"
+	}
+
+	return fmt.Sprintf("%s: %s", name, strings.Join(parts, " "))
+}
+
+// renderFirewallPlan prints the changes of the plan, the plan is shown for
+// the confirmation and printed to the output of prompts
+func renderFirewallPlan(w io.Writer, plan *firewallPlan) {
+	color.New(color.FgCyan).Fprintf(w, "Firewall plan for %s (%s):\n", plan.server.ServerName, plan.server.ServerIP)
+
+	if !plan.changed {
+		fmt.Fprintln(w, "  no changes")
+		return
+	}
+
+	for _, line := range plan.lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			color.New(color.FgGreen).Fprintln(w, line)
+		case strings.HasPrefix(line, "-"):
+			color.New(color.FgRed).Fprintln(w, line)
+		case strings.HasPrefix(line, "  ") && strings.Contains(line, " → "):
+			color.New(color.FgYellow).Fprintln(w, line)
+		default:
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+func renderFirewallRules(caption string, rules []robot.FirewallRule) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"#", "name", "ip version", "protocol", "src ip", "src port", "dst ip", "dst port", "tcp flags", "action"})
+
+	for i, rule := range rules {
+		t.AppendRow(table.Row{
+			i + 1,
+			rule.Name,
+			rule.IPVersion,
+			rule.Protocol,
+			rule.SrcIP,
+			rule.SrcPort,
+			rule.DstIP,
+			rule.DstPort,
+			rule.TCPFlags,
+			rule.Action,
+		})
+	}
+
+	t.SetCaption(caption)
+	t.Render()
+}
+
+// loadFirewallFile reads and validates a rule file
+func loadFirewallFile(path string) (*firewallFile, error) {
+	if path == "" {
+		return nil, errors.New("rule file must be given with --file")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file firewallFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid rule file %s: %s", path, err)
+	}
+
+	if len(file.Rules.Input) > firewallMaxRules {
+		return nil, fmt.Errorf("invalid rule file %s: at most %d input rules are allowed, got %d", path, firewallMaxRules, len(file.Rules.Input))
+	}
+
+	for i, rule := range file.Rules.Input {
+		if err := validateFirewallRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid rule file %s: input rule %d: %s", path, i+1, err)
+		}
+	}
+
+	return &file, nil
+}
+
+func validateFirewallRule(rule robot.FirewallRule) error {
+	switch rule.Action {
+	case robot.FirewallActionAccept, robot.FirewallActionDiscard:
+	default:
+		return fmt.Errorf("unknown action %q, use one of: %s, %s", rule.Action, robot.FirewallActionAccept, robot.FirewallActionDiscard)
+	}
+
+	switch rule.IPVersion {
+	case "", "ipv4", "ipv6":
+	default:
+		return fmt.Errorf("unknown ip_version %q, use one of: ipv4, ipv6", rule.IPVersion)
+	}
+
+	switch rule.Protocol {
+	case "", "tcp", "udp", "gre", "icmp", "ipip", "ah", "esp":
+	default:
+		return fmt.Errorf("unknown protocol %q", rule.Protocol)
+	}
+
+	if rule.TCPFlags != "" && rule.Protocol != "tcp" {
+		return errors.New("tcp_flags require protocol tcp")
+	}
+
+	return nil
+}
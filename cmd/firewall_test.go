@@ -0,0 +1,171 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+var testFirewall = &robot.Firewall{
+	ServerIP:     "123.123.123.123",
+	ServerNumber: 321,
+	Status:       robot.FirewallStatusActive,
+	WhitelistHOS: true,
+	Port:         "main",
+	Rules: robot.FirewallRules{
+		Input: []robot.FirewallRule{
+			{
+				Name:      "ssh",
+				IPVersion: "ipv4",
+				Protocol:  "tcp",
+				DstPort:   "22",
+				Action:    robot.FirewallActionAccept,
+			},
+		},
+	},
+}
+
+func writeRuleFile(c *C, content string) string {
+	path := filepath.Join(c.MkDir(), "rules.yaml")
+	c.Assert(ioutil.WriteFile(path, []byte(content), 0600), IsNil)
+
+	return path
+}
+
+func (s *AppSuite) TestFirewallGetCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().FirewallGet("123.123.123.123").Times(1).Return(testFirewall, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "firewall:get", "app-prod-84")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestFirewallApplyCommandNoChanges(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	file := writeRuleFile(c, `
+whitelist_hos: true
+rules:
+  input:
+    - name: ssh
+      ip_version: ipv4
+      protocol: tcp
+      dst_port: "22"
+      action: accept
+`)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().FirewallGet("123.123.123.123").Times(1).Return(testFirewall, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "firewall:apply", "--servers", "app-prod-84", "-f", file)
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestFirewallApplyCommandInvalidFile(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	file := writeRuleFile(c, `
+rules:
+  input:
+    - name: ssh
+      action: allow
+`)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "firewall:apply", "--servers", "app-prod-84", "-f", file)
+	c.Assert(err, ErrorMatches, `invalid rule file .*: input rule 1: unknown action "allow".*`)
+}
+
+func (s *AppSuite) TestFirewallTemplateListCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []robot.FirewallTemplate{
+		{
+			ID:        1,
+			Name:      "My template",
+			IsDefault: true,
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().FirewallTemplateGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "firewall:template:list")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestDiffFirewallRules(c *C) {
+	ssh := robot.FirewallRule{Name: "ssh", DstPort: "22", Protocol: "tcp", Action: robot.FirewallActionAccept}
+	web := robot.FirewallRule{Name: "web", DstPort: "443", Protocol: "tcp", Action: robot.FirewallActionAccept}
+	drop := robot.FirewallRule{Action: robot.FirewallActionDiscard}
+
+	lines := cmd.DiffFirewallRules([]robot.FirewallRule{ssh, drop}, []robot.FirewallRule{ssh, web, drop})
+	c.Assert(lines, DeepEquals, []string{
+		"  ssh: accept tcp dst_port 22",
+		"+ web: accept tcp dst_port 443",
+		"  (unnamed): discard",
+	})
+
+	lines = cmd.DiffFirewallRules([]robot.FirewallRule{web, ssh}, []robot.FirewallRule{ssh})
+	c.Assert(lines, DeepEquals, []string{
+		"- web: accept tcp dst_port 443",
+		"  ssh: accept tcp dst_port 22",
+	})
+}
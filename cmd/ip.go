@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/multiselect"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
 )
 
 func (app *RobotApp) NewIPGetListCmd() *cobra.Command {
-	return &cobra.Command{
+	var wide bool
+
+	cmd := &cobra.Command{
 		Use:   "ip:list",
 		Short: "Print list of IP's",
 		Long:  "Print list of IP's in the hetzner account",
@@ -22,15 +32,23 @@ func (app *RobotApp) NewIPGetListCmd() *cobra.Command {
 			t := table.NewWriter()
 			t.SetOutputMirror(os.Stdout)
 
-			t.AppendHeader(table.Row{"ip", "server_ip", "server_number", "locked"})
+			header := table.Row{"ip", "server_ip", "server_number", "locked"}
+			if wide {
+				header = append(header, "mac")
+			}
+			t.AppendHeader(header)
 
 			for _, ip := range ips {
-				t.AppendRow(table.Row{
+				row := table.Row{
 					ip.IP,
 					ip.ServerIP,
 					ip.ServerNumber,
 					ip.Locked,
-				})
+				}
+				if wide {
+					row = append(row, ip.SeparateMac)
+				}
+				t.AppendRow(row)
 			}
 
 			t.SortBy([]table.SortBy{
@@ -43,4 +61,238 @@ func (app *RobotApp) NewIPGetListCmd() *cobra.Command {
 			t.Render()
 		},
 	}
+
+	cmd.Flags().BoolVar(&wide, "wide", false, "print additional columns like the separate MAC address")
+
+	return cmd
+}
+
+func (app *RobotApp) NewIPGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ip:get [ip]",
+		Short: "Print single IP",
+		Long: `Print details of single IP in hetzner account including network and traffic warning settings,
+IP can be given as argument or chosen interactively`,
+		Annotations: argCompletion(completeIPs),
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := app.ipArg(args)
+			if err != nil {
+				return err
+			}
+
+			// additional get as getting a single IP returns more data
+			ip, err := app.client.IPGet(addr)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"ip", ip.IP.IP})
+			t.AppendRow(table.Row{"server ip", ip.ServerIP})
+			t.AppendRow(table.Row{"server number", ip.ServerNumber})
+			t.AppendRow(table.Row{"locked", ip.Locked})
+			t.AppendRow(table.Row{"separate mac", ip.SeparateMac})
+			t.AppendRow(table.Row{"gateway", ip.Gateway})
+			t.AppendRow(table.Row{"mask", ip.Mask})
+			t.AppendRow(table.Row{"broadcast", ip.Broadcast})
+			t.AppendRow(table.Row{"traffic warnings", ip.TrafficWarnings})
+			t.AppendRow(table.Row{"traffic hourly (MB)", ip.TrafficHourly})
+			t.AppendRow(table.Row{"traffic daily (MB)", ip.TrafficDaily})
+			t.AppendRow(table.Row{"traffic monthly (GB)", ip.TrafficMonthly})
+
+			t.Render()
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewIPTrafficWarningsCmd() *cobra.Command {
+	var sel ipSelector
+	var opts bulkOptions
+	var input robot.IPUpdateInput
+	var disable bool
+
+	cmd := &cobra.Command{
+		Use:   "ip:traffic-warnings",
+		Short: "Configure traffic warnings for selected IP's",
+		Long: `Enable or disable traffic warnings and set their thresholds for selected IP's in the hetzner
+account. IP's can be chosen interactively, given by flags or taken from the servers selected by flags.
+Thresholds which are not given keep their current value.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			if input.TrafficHourly < 0 || input.TrafficDaily < 0 || input.TrafficMonthly < 0 {
+				return errors.New("traffic thresholds must not be negative")
+			}
+
+			input.TrafficWarnings = !disable
+
+			ipList, err := app.client.IPGetList()
+			if err != nil {
+				return err
+			}
+
+			chosenIPs, err := app.selectIPs(&sel, ipList)
+			if err != nil {
+				return err
+			}
+
+			action := "enable traffic warnings for"
+			if disable {
+				action = "disable traffic warnings for"
+			}
+
+			if err := app.confirmIPs(chosenIPs, action); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			var tasks []bulkTask
+			for _, ip := range chosenIPs {
+				ip := ip
+
+				tasks = append(tasks, bulkTask{
+					Item:   ip.IP,
+					Action: describeTrafficWarnings(&input),
+					Run: func() error {
+						_, err := app.client.IPUpdate(ip.IP, &input)
+						return err
+					},
+				})
+			}
+
+			return app.executeBulk(tasks, &opts)
+		},
+	}
+
+	addIPSelectorFlags(cmd, &sel)
+	cmd.Flags().BoolVar(&disable, "disable", false, "disable traffic warnings")
+	cmd.Flags().IntVar(&input.TrafficHourly, "hourly", 0, "hourly traffic threshold in MB")
+	cmd.Flags().IntVar(&input.TrafficDaily, "daily", 0, "daily traffic threshold in MB")
+	cmd.Flags().IntVar(&input.TrafficMonthly, "monthly", 0, "monthly traffic threshold in GB")
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+func describeTrafficWarnings(input *robot.IPUpdateInput) string {
+	if !input.TrafficWarnings {
+		return "disable traffic warnings"
+	}
+
+	action := "enable traffic warnings"
+
+	var thresholds []string
+	if input.TrafficHourly > 0 {
+		thresholds = append(thresholds, fmt.Sprintf("hourly %d MB", input.TrafficHourly))
+	}
+	if input.TrafficDaily > 0 {
+		thresholds = append(thresholds, fmt.Sprintf("daily %d MB", input.TrafficDaily))
+	}
+	if input.TrafficMonthly > 0 {
+		thresholds = append(thresholds, fmt.Sprintf("monthly %d GB", input.TrafficMonthly))
+	}
+
+	if len(thresholds) > 0 {
+		action += " (" + strings.Join(thresholds, ", ") + ")"
+	}
+
+	return action
+}
+
+// ipArg returns the IP given as argument, the IP is chosen interactively if
+// there is no argument
+func (app *RobotApp) ipArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	ip, err := app.selectIP()
+	if err != nil {
+		return "", err
+	}
+
+	return ip.IP, nil
+}
+
+// selectIP lets the user choose an IP, errors of the prompt are returned as
+// prompt failed unlike errors fetching the IP's
+func (app *RobotApp) selectIP() (*models.IP, error) {
+	ips, err := app.client.IPGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := promptui.Select{
+		Label:             "Select IP",
+		Items:             ips,
+		Searcher:          getIPSearcher(ips),
+		Size:              10,
+		Templates:         getIPSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, fmt.Errorf("prompt failed: %s", err)
+	}
+
+	return &ips[chosenIdx], nil
+}
+
+func (app *RobotApp) selectMultipleIPs(ips []models.IP) ([]models.IP, error) {
+	prompt := multiselect.MultiSelect{
+		Label:             "Choose IP's",
+		Items:             ips,
+		Searcher:          getIPSearcher(ips),
+		Size:              10,
+		Templates:         getIPSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdxs, err := prompt.Run()
+	if err != nil {
+		app.logger.Errorln("Prompt failed: ", err)
+		return []models.IP{}, err
+	}
+
+	var chosenIPs []models.IP
+	for _, chosenIdx := range chosenIdxs {
+		chosenIPs = append(chosenIPs, ips[chosenIdx])
+	}
+
+	return chosenIPs, nil
+}
+
+func getIPSearcher(ips []models.IP) func(string, int) bool {
+	return func(input string, index int) bool {
+		ip := ips[index]
+		addr := strings.Replace(strings.ToLower(ip.IP), " ", "", -1)
+		serverIP := strings.Replace(strings.ToLower(ip.ServerIP), " ", "", -1)
+		input = strings.Replace(strings.ToLower(input), " ", "", -1)
+
+		return strings.Contains(addr, input) || strings.Contains(serverIP, input)
+	}
+}
+
+func getIPSelectTemplates() *promptui.SelectTemplates {
+	return &promptui.SelectTemplates{
+		Label:    "{{ . }} ?",
+		Active:   "→ {{ .IP | green }} ({{ .ServerIP | yellow }} - {{ .ServerNumber | yellow }})",
+		Inactive: "  {{ .IP | cyan }} ({{ .ServerIP | red }} - {{ .ServerNumber | blue }})",
+		Selected: "→ {{ .IP | cyan }}",
+		Details: `
+	--------- Selected IP ----------
+	{{ "IP:" | faint }}	          {{ .IP }}
+	{{ "Server IP:" | faint }}	  {{ .ServerIP }}
+	{{ "Server number:" | faint }}	  {{ .ServerNumber }}`,
+	}
 }
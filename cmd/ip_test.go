@@ -1,11 +1,14 @@
 package cmd_test
 
 import (
+	"errors"
+
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	. "gopkg.in/check.v1"
 
 	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
 	"github.com/nl2go/hrobot-cli/test/mock"
 	"github.com/nl2go/hrobot-go/models"
 )
@@ -56,3 +59,114 @@ func (s *AppSuite) TestIPListCommandSuccess(c *C) {
 	_, err := executeCommand(rootCmd, "ip:list")
 	c.Assert(err, IsNil)
 }
+
+func (s *AppSuite) TestIPGetCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := &robot.IP{
+		IP: models.IP{
+			IP:           "123.123.123.123",
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+		},
+		Gateway:   "123.123.123.97",
+		Mask:      27,
+		Broadcast: "123.123.123.127",
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPGet("123.123.123.123").Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:get", "123.123.123.123")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestIPGetCommandListError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:get")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestIPTrafficWarningsCommandUnknownIP(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.IP{
+		{
+			IP:           "123.123.123.123",
+			ServerNumber: 321,
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:traffic-warnings", "--ips", "124.124.124.124", "--daily", "2000")
+	c.Assert(err, ErrorMatches, `no IP found for "124.124.124.124"`)
+}
+
+func (s *AppSuite) TestIPTrafficWarningsCommandNegativeThreshold(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:traffic-warnings", "--ips", "123.123.123.123", "--monthly", "-1")
+	c.Assert(err, ErrorMatches, "traffic thresholds must not be negative")
+}
+
+func (s *AppSuite) TestIPListCommandWide(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.IP{
+		{
+			IP:           "123.123.123.123",
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			SeparateMac:  "00:50:56:00:9c:5e",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:list", "--wide")
+	c.Assert(err, IsNil)
+}
@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	ipv6ModeAddresses = "addresses"
+	ipv6ModeSubnets   = "subnets"
+
+	outputNetplan = "netplan"
+)
+
+type ipv6PlanOptions struct {
+	mode      string
+	count     int
+	start     int
+	prefix    int
+	name      string
+	rdns      string
+	iface     string
+	setRdns   bool
+	format    string
+	bulk      bulkOptions
+	selection serverSelector
+}
+
+type ipv6Plan struct {
+	Servers []ipv6ServerPlan `yaml:"servers"`
+}
+
+type ipv6ServerPlan struct {
+	ServerNumber int              `yaml:"server_number"`
+	ServerName   string           `yaml:"server_name"`
+	ServerIP     string           `yaml:"server_ip"`
+	Subnet       string           `yaml:"subnet"`
+	Gateway      string           `yaml:"gateway"`
+	Allocations  []ipv6Allocation `yaml:"allocations"`
+
+	// interfaceAddresses are the addresses to configure on the server
+	interfaceAddresses []string
+}
+
+type ipv6Allocation struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	Rdns    string `yaml:"rdns,omitempty"`
+
+	// rdnsIP is the address receiving the reverse DNS entry
+	rdnsIP string
+}
+
+type netplanConfig struct {
+	Network netplanNetwork `yaml:"network"`
+}
+
+type netplanNetwork struct {
+	Version   int                         `yaml:"version"`
+	Ethernets map[string]netplanInterface `yaml:"ethernets"`
+}
+
+type netplanInterface struct {
+	Addresses []string `yaml:"addresses"`
+	Gateway6  string   `yaml:"gateway6,omitempty"`
+}
+
+func (app *RobotApp) NewIPv6PlanCmd() *cobra.Command {
+	var opts ipv6PlanOptions
+
+	cmd := &cobra.Command{
+		Use:   "ipv6:plan",
+		Short: "Plan IPv6 address allocation for selected servers",
+		Long: `Plan the allocation of addresses from the IPv6 subnets of selected servers, either as single
+addresses or as smaller subnets per virtual machine. The plan is printed as YAML or as netplan snippets,
+reverse DNS entries for the allocated addresses can be set optionally. Servers can be chosen
+interactively or by flags.
+
+Names and reverse DNS entries support the placeholders {server} (server name), {number} (server
+number) and {n} (number of the allocation starting with 1).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateIPv6PlanOptions(&opts); err != nil {
+				return err
+			}
+
+			chosenServers, err := app.selectServers(&opts.selection)
+			if err != nil {
+				return err
+			}
+
+			subnets, err := app.client.SubnetGetList()
+			if err != nil {
+				return err
+			}
+
+			var plan ipv6Plan
+			for _, server := range chosenServers {
+				subnet, ok := findIPv6Subnet(subnets, server.ServerNumber)
+				if !ok {
+					app.logger.Warnf("Server %s (%d) has no IPv6 subnet, skipping", server.ServerName, server.ServerNumber)
+					continue
+				}
+
+				serverPlan, err := newIPv6ServerPlan(server, subnet, &opts)
+				if err != nil {
+					return err
+				}
+
+				plan.Servers = append(plan.Servers, *serverPlan)
+			}
+
+			if len(plan.Servers) == 0 {
+				return errors.New("none of the selected servers has an IPv6 subnet")
+			}
+
+			// with --set-rdns the plan is shown for the confirmation, stdout
+			// only carries the report of the changes then
+			planOut := app.out
+			if opts.setRdns {
+				planOut = app.errOut
+			}
+
+			if err := printIPv6Plan(planOut, &plan, &opts); err != nil {
+				return err
+			}
+
+			if !opts.setRdns {
+				return nil
+			}
+
+			var tasks []bulkTask
+			for _, serverPlan := range plan.Servers {
+				for _, allocation := range serverPlan.Allocations {
+					allocation := allocation
+
+					tasks = append(tasks, bulkTask{
+						Item:   allocation.rdnsIP,
+						Action: "set reverse DNS to " + allocation.Rdns,
+						Run: func() error {
+							_, err := app.client.RDnsSet(allocation.rdnsIP, allocation.Rdns)
+							return err
+						},
+					})
+				}
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Really set %d reverse DNS entries as planned above", len(tasks)),
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			return app.executeBulk(tasks, &opts.bulk)
+		},
+	}
+
+	addServerSelectorFlags(cmd, &opts.selection)
+	cmd.Flags().StringVar(&opts.mode, "mode", ipv6ModeAddresses, "allocate single addresses or subnets (addresses, subnets)")
+	cmd.Flags().IntVar(&opts.count, "count", 1, "number of allocations per server")
+	cmd.Flags().IntVar(&opts.start, "start", 0, "offset of the first allocation, defaults to 2 for addresses and 1 for subnets")
+	cmd.Flags().IntVar(&opts.prefix, "prefix", 80, "prefix length of allocated subnets")
+	cmd.Flags().StringVar(&opts.name, "name", "{server}-{n}", "name of the allocations")
+	cmd.Flags().StringVar(&opts.rdns, "rdns", "", "reverse DNS entry of the allocations, e.g. {server}-vm{n}.example.com")
+	cmd.Flags().BoolVar(&opts.setRdns, "set-rdns", false, "set the planned reverse DNS entries")
+	cmd.Flags().StringVar(&opts.iface, "interface", "eth0", "interface of the netplan snippets")
+	cmd.Flags().StringVar(&opts.format, "format", outputYAML, "format of the plan (yaml, netplan)")
+	addBulkFlags(cmd, &opts.bulk)
+
+	return cmd
+}
+
+func validateIPv6PlanOptions(opts *ipv6PlanOptions) error {
+	if err := validateOutput(opts.format, outputYAML, outputNetplan); err != nil {
+		return err
+	}
+
+	if err := validateBulkOptions(&opts.bulk); err != nil {
+		return err
+	}
+
+	switch opts.mode {
+	case ipv6ModeAddresses:
+		if opts.start == 0 {
+			// ::1 is usually configured on the server itself
+			opts.start = 2
+		}
+	case ipv6ModeSubnets:
+		if opts.start == 0 {
+			// the first subnet contains the address of the server itself
+			opts.start = 1
+		}
+	default:
+		return fmt.Errorf("unknown mode %q, use one of: %s, %s", opts.mode, ipv6ModeAddresses, ipv6ModeSubnets)
+	}
+
+	if opts.count < 1 {
+		return fmt.Errorf("count must be positive, got %d", opts.count)
+	}
+
+	if opts.start < 0 {
+		return fmt.Errorf("start must not be negative, got %d", opts.start)
+	}
+
+	if opts.setRdns && opts.rdns == "" {
+		return errors.New("--set-rdns requires --rdns")
+	}
+
+	return nil
+}
+
+// findIPv6Subnet returns the first IPv6 subnet routed to the server
+func findIPv6Subnet(subnets []robot.Subnet, serverNumber int) (robot.Subnet, bool) {
+	for _, subnet := range subnets {
+		ip := net.ParseIP(subnet.IP)
+		if subnet.ServerNumber == serverNumber && ip != nil && ip.To4() == nil {
+			return subnet, true
+		}
+	}
+
+	return robot.Subnet{}, false
+}
+
+func newIPv6ServerPlan(server models.Server, subnet robot.Subnet, opts *ipv6PlanOptions) (*ipv6ServerPlan, error) {
+	_, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", subnet.IP, subnet.Mask))
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet %s/%d", subnet.IP, subnet.Mask)
+	}
+
+	base := new(big.Int).SetBytes(network.IP)
+
+	// size of a single allocation and the number of allocations fitting into the subnet
+	blockBits := 0
+	if opts.mode == ipv6ModeSubnets {
+		if opts.prefix <= subnet.Mask || opts.prefix > 128 {
+			return nil, fmt.Errorf("prefix /%d does not fit into subnet %s/%d", opts.prefix, subnet.IP, subnet.Mask)
+		}
+
+		blockBits = 128 - opts.prefix
+	}
+
+	available := new(big.Int).Lsh(big.NewInt(1), uint(128-subnet.Mask-blockBits))
+	needed := big.NewInt(int64(opts.start + opts.count))
+	if needed.Cmp(available) > 0 {
+		return nil, fmt.Errorf("subnet %s/%d has no room for %d allocations starting at %d", subnet.IP, subnet.Mask, opts.count, opts.start)
+	}
+
+	plan := &ipv6ServerPlan{
+		ServerNumber: server.ServerNumber,
+		ServerName:   server.ServerName,
+		ServerIP:     server.ServerIP,
+		Subnet:       fmt.Sprintf("%s/%d", network.IP, subnet.Mask),
+		Gateway:      subnet.Gateway,
+	}
+
+	for i := 0; i < opts.count; i++ {
+		offset := new(big.Int).Lsh(big.NewInt(int64(opts.start+i)), uint(blockBits))
+		first := new(big.Int).Add(base, offset)
+
+		allocation := ipv6Allocation{
+			Name: expandIPv6Pattern(opts.name, server, i+1),
+		}
+
+		if opts.mode == ipv6ModeSubnets {
+			// the first address of the allocated subnet is the gateway of the virtual machine
+			gateway := bigToIP(new(big.Int).Add(first, big.NewInt(1)), 128)
+
+			allocation.Address = fmt.Sprintf("%s/%d", bigToIP(first, 128), opts.prefix)
+			allocation.rdnsIP = gateway
+			plan.interfaceAddresses = append(plan.interfaceAddresses, fmt.Sprintf("%s/%d", gateway, opts.prefix))
+		} else {
+			allocation.Address = bigToIP(first, 128)
+			allocation.rdnsIP = allocation.Address
+			plan.interfaceAddresses = append(plan.interfaceAddresses, fmt.Sprintf("%s/%d", allocation.Address, subnet.Mask))
+		}
+
+		if opts.rdns != "" {
+			allocation.Rdns = expandIPv6Pattern(opts.rdns, server, i+1)
+		}
+
+		plan.Allocations = append(plan.Allocations, allocation)
+	}
+
+	return plan, nil
+}
+
+func expandIPv6Pattern(pattern string, server models.Server, n int) string {
+	name := server.ServerName
+	if name == "" {
+		name = strconv.Itoa(server.ServerNumber)
+	}
+
+	return strings.NewReplacer(
+		"{server}", name,
+		"{number}", strconv.Itoa(server.ServerNumber),
+		"{n}", strconv.Itoa(n),
+	).Replace(pattern)
+}
+
+func printIPv6Plan(w io.Writer, plan *ipv6Plan, opts *ipv6PlanOptions) error {
+	if opts.format == outputYAML {
+		return writeYAML(w, plan)
+	}
+
+	for i, serverPlan := range plan.Servers {
+		config := netplanConfig{
+			Network: netplanNetwork{
+				Version: 2,
+				Ethernets: map[string]netplanInterface{
+					opts.iface: {
+						Addresses: serverPlan.interfaceAddresses,
+						Gateway6:  serverPlan.Gateway,
+					},
+				},
+			},
+		}
+
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprintf(w, "# %s (%d) %s\n", serverPlan.ServerName, serverPlan.ServerNumber, serverPlan.Subnet)
+		fmt.Fprint(w, string(out))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func newIPv6MockClient(ctrl *gomock.Controller) *mock.MockRobotClient {
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-42",
+		},
+	}
+
+	subnets := []robot.Subnet{
+		{
+			IP:           "123.123.123.96",
+			Mask:         29,
+			ServerNumber: 321,
+		},
+		{
+			IP:           "2a01:4f8:111:4221::",
+			Mask:         64,
+			Gateway:      "fe80::1",
+			ServerNumber: 321,
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(subnets, nil)
+
+	return mockRobotClient
+}
+
+func (s *AppSuite) TestIPv6PlanCommandAddresses(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newIPv6MockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ipv6:plan", "--servers", "app-prod-42", "--count", "3", "--rdns", "{server}-vm{n}.example.com")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestIPv6PlanCommandServerAlias(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newIPv6MockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	// --server is the singular alias of --servers
+	_, err := executeCommand(rootCmd, "ipv6:plan", "--server", "app-prod-42", "--count", "1")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestIPv6PlanCommandSubnetsNetplan(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newIPv6MockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ipv6:plan", "--servers", "321", "--mode", "subnets", "--count", "2", "--format", "netplan", "--interface", "br0")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestIPv6PlanCommandPrefixTooShort(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newIPv6MockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ipv6:plan", "--servers", "321", "--mode", "subnets", "--prefix", "64")
+	c.Assert(err, ErrorMatches, `prefix /64 does not fit into subnet 2a01:4f8:111:4221::/64`)
+}
+
+func (s *AppSuite) TestIPv6PlanCommandInvalidOptions(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ipv6:plan", "--servers", "321", "--mode", "ranges")
+	c.Assert(err, ErrorMatches, `unknown mode "ranges".*`)
+
+	_, err = executeCommand(rootCmd, "ipv6:plan", "--servers", "321", "--mode", "addresses", "--set-rdns")
+	c.Assert(err, ErrorMatches, "--set-rdns requires --rdns")
+}
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"os"
+	"strings"
 
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/multiselect"
+	"github.com/nl2go/hrobot-go/models"
 )
 
 func (app *RobotApp) NewKeyGetListCmd() *cobra.Command {
@@ -40,6 +44,41 @@ func (app *RobotApp) NewKeyGetListCmd() *cobra.Command {
 	}
 }
 
+func (app *RobotApp) selectMultipleKeys(keys []models.Key) ([]models.Key, error) {
+	prompt := multiselect.MultiSelect{
+		Label:             "Choose keys",
+		Items:             keys,
+		Searcher:          getKeySearcher(keys),
+		Size:              10,
+		Templates:         getKeySelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdxs, err := prompt.Run()
+	if err != nil {
+		return []models.Key{}, err
+	}
+
+	var chosenKeys []models.Key
+	for _, chosenIdx := range chosenIdxs {
+		chosenKeys = append(chosenKeys, keys[chosenIdx])
+	}
+
+	return chosenKeys, nil
+}
+
+func getKeySearcher(keys []models.Key) func(string, int) bool {
+	return func(input string, index int) bool {
+		key := keys[index]
+		name := strings.Replace(strings.ToLower(key.Name), " ", "", -1)
+		fingerprint := strings.ToLower(key.Fingerprint)
+		input = strings.Replace(strings.ToLower(input), " ", "", -1)
+
+		return strings.Contains(name, input) || strings.Contains(fingerprint, input)
+	}
+}
+
 func getKeySelectTemplates() *promptui.SelectTemplates {
 	return &promptui.SelectTemplates{
 		Label:    "{{ . }} ?",
@@ -0,0 +1,449 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	lintServerName        = "server-name"
+	lintDuplicateNames    = "duplicate-names"
+	lintRDns              = "rdns"
+	lintRDnsResolve       = "rdns-resolve"
+	lintFailoverCancelled = "failover-cancelled"
+	lintRescueKeys        = "rescue-keys"
+
+	outputJUnit = "junit"
+)
+
+var lintChecks = []string{
+	lintServerName,
+	lintDuplicateNames,
+	lintRDns,
+	lintRDnsResolve,
+	lintFailoverCancelled,
+	lintRescueKeys,
+}
+
+// lookupHost resolves the PTR names of the rdns-resolve check, replaced in
+// tests
+var lookupHost = net.LookupHost
+
+// lintResult is the outcome of a single check for a single item
+type lintResult struct {
+	Check   string `json:"check"`
+	Item    string `json:"item"`
+	Failed  bool   `json:"failed"`
+	Message string `json:"message,omitempty"`
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+type lintOptions struct {
+	checks    []string
+	skip      []string
+	nameRegex string
+	output    string
+}
+
+func (app *RobotApp) NewLintCmd() *cobra.Command {
+	var opts lintOptions
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check the account for violations of conventions",
+		Long: `Run checks against the hetzner account and exit with a non-zero exit code on violations.
+
+Checks:
+  server-name         server names match --name-regex, by default the names generated by
+                      server:set-name (<prefix>-<product>-hetzner-<dc>-<number>)
+  duplicate-names     server names are unique
+  rdns                IP's have a reverse DNS entry
+  rdns-resolve        reverse DNS entries resolve to their IP
+  failover-cancelled  failover IP's are not routed to cancelled servers
+  rescue-keys         active rescue systems were activated with an authorized key, cancelled
+                      servers and servers without rescue options are skipped
+
+The result is printed as table or as JUnit XML report for CI systems. Requests which fail abort the
+run with an error instead of being reported as violations.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(opts.output, outputTable, outputJUnit); err != nil {
+				return err
+			}
+
+			checks, err := enabledLintChecks(opts.checks, opts.skip)
+			if err != nil {
+				return err
+			}
+
+			var nameRegex *regexp.Regexp
+			if opts.nameRegex != "" {
+				nameRegex, err = regexp.Compile(opts.nameRegex)
+				if err != nil {
+					return fmt.Errorf("invalid name regex: %s", err)
+				}
+			}
+
+			results, err := app.runLint(checks, nameRegex)
+			if err != nil {
+				return err
+			}
+
+			if opts.output == outputJUnit {
+				if err := printJUnit(checks, results); err != nil {
+					return err
+				}
+			} else {
+				renderLintResults(results)
+			}
+
+			violations := 0
+			for _, result := range results {
+				if result.Failed {
+					violations++
+				}
+			}
+
+			if violations > 0 {
+				return fmt.Errorf("%d lint violations found", violations)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.checks, "checks", lintChecks, "comma separated checks to run")
+	cmd.Flags().StringSliceVar(&opts.skip, "skip", nil, "comma separated checks to skip")
+	cmd.Flags().StringVar(&opts.nameRegex, "name-regex", "", "regular expression server names must match")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", outputTable, "output format (table, junit)")
+
+	return cmd
+}
+
+func enabledLintChecks(checks []string, skip []string) ([]string, error) {
+	known := make(map[string]bool)
+	for _, check := range lintChecks {
+		known[check] = true
+	}
+
+	skipped := make(map[string]bool)
+	for _, check := range append(append([]string{}, checks...), skip...) {
+		if !known[check] {
+			return nil, fmt.Errorf("unknown check %q, use one of: %s", check, strings.Join(lintChecks, ", "))
+		}
+	}
+
+	for _, check := range skip {
+		skipped[check] = true
+	}
+
+	var enabled []string
+	for _, check := range checks {
+		if !skipped[check] {
+			enabled = append(enabled, check)
+			skipped[check] = true
+		}
+	}
+
+	return enabled, nil
+}
+
+// runLint fetches only the lists required by the enabled checks
+func (app *RobotApp) runLint(checks []string, nameRegex *regexp.Regexp) ([]lintResult, error) {
+	enabled := make(map[string]bool)
+	for _, check := range checks {
+		enabled[check] = true
+	}
+
+	var servers []models.Server
+	var err error
+	if enabled[lintServerName] || enabled[lintDuplicateNames] || enabled[lintFailoverCancelled] || enabled[lintRescueKeys] {
+		servers, err = app.client.ServerGetList()
+		if err = robot.ListError(err); err != nil {
+			return nil, err
+		}
+	}
+
+	var rdns []models.Rdns
+	if enabled[lintRDns] || enabled[lintRDnsResolve] {
+		rdns, err = app.client.RDnsGetList()
+		if err = robot.ListError(err); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []lintResult
+
+	if enabled[lintServerName] {
+		results = append(results, lintServerNames(servers, nameRegex)...)
+	}
+
+	if enabled[lintDuplicateNames] {
+		results = append(results, lintDuplicateServerNames(servers)...)
+	}
+
+	if enabled[lintRDns] {
+		ips, err := app.client.IPGetList()
+		if err = robot.ListError(err); err != nil {
+			return nil, err
+		}
+
+		results = append(results, lintMissingRDns(ips, rdns)...)
+	}
+
+	if enabled[lintRDnsResolve] {
+		results = append(results, lintResolveRDns(rdns)...)
+	}
+
+	if enabled[lintFailoverCancelled] {
+		failovers, err := app.client.FailoverGetList()
+		if err = robot.ListError(err); err != nil {
+			return nil, err
+		}
+
+		results = append(results, lintFailovers(failovers, servers)...)
+	}
+
+	if enabled[lintRescueKeys] {
+		// cancelled servers and servers without rescue options have no
+		// rescue system to check, other errors abort the run
+		for _, server := range servers {
+			if server.Cancelled {
+				continue
+			}
+
+			rescue, err := app.client.BootRescueGet(server.ServerIP)
+			if robot.ErrorCode(err) == robot.CodeNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, lintRescueKey(server, rescue))
+		}
+	}
+
+	return results, nil
+}
+
+func lintServerNames(servers []models.Server, nameRegex *regexp.Regexp) []lintResult {
+	var results []lintResult
+	for _, server := range servers {
+		result := lintResult{Check: lintServerName, Item: serverItem(server)}
+
+		if nameRegex != nil {
+			if !nameRegex.MatchString(server.ServerName) {
+				result.Failed = true
+				result.Message = fmt.Sprintf("name %q does not match %s", server.ServerName, nameRegex)
+			}
+		} else {
+			// generated names end with the suffix generated for an empty prefix
+			suffix := generateServerName(server, "")
+			if len(server.ServerName) <= len(suffix) || !strings.HasSuffix(server.ServerName, suffix) {
+				result.Failed = true
+				result.Message = fmt.Sprintf("name %q does not match <prefix>%s", server.ServerName, suffix)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func lintDuplicateServerNames(servers []models.Server) []lintResult {
+	counts := make(map[string]int)
+	for _, server := range servers {
+		counts[server.ServerName]++
+	}
+
+	var results []lintResult
+	for _, server := range servers {
+		result := lintResult{Check: lintDuplicateNames, Item: serverItem(server)}
+		if counts[server.ServerName] > 1 {
+			result.Failed = true
+			result.Message = fmt.Sprintf("name %q is used by %d servers", server.ServerName, counts[server.ServerName])
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func lintMissingRDns(ips []models.IP, rdns []models.Rdns) []lintResult {
+	ptrs := make(map[string]string)
+	for _, entry := range rdns {
+		ptrs[entry.IP] = entry.Ptr
+	}
+
+	var results []lintResult
+	for _, ip := range ips {
+		result := lintResult{Check: lintRDns, Item: ip.IP}
+		if ptrs[ip.IP] == "" {
+			result.Failed = true
+			result.Message = "no reverse DNS entry"
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func lintResolveRDns(rdns []models.Rdns) []lintResult {
+	var results []lintResult
+	for _, entry := range rdns {
+		if entry.Ptr == "" {
+			continue
+		}
+
+		result := lintResult{Check: lintRDnsResolve, Item: entry.IP}
+
+		addrs, err := lookupHost(entry.Ptr)
+		switch {
+		case err != nil:
+			result.Failed = true
+			result.Message = fmt.Sprintf("%s does not resolve: %s", entry.Ptr, err)
+		case !containsIP(addrs, entry.IP):
+			result.Failed = true
+			result.Message = fmt.Sprintf("%s resolves to %s", entry.Ptr, strings.Join(addrs, ", "))
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func lintFailovers(failovers []models.Failover, servers []models.Server) []lintResult {
+	serversByIP := make(map[string]models.Server)
+	for _, server := range servers {
+		serversByIP[server.ServerIP] = server
+	}
+
+	var results []lintResult
+	for _, failover := range failovers {
+		result := lintResult{Check: lintFailoverCancelled, Item: failover.IP}
+		if server, ok := serversByIP[failover.ActiveServerIP]; ok && server.Cancelled {
+			result.Failed = true
+			result.Message = fmt.Sprintf("routed to cancelled server %s", serverItem(server))
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func lintRescueKey(server models.Server, rescue *models.Rescue) lintResult {
+	result := lintResult{Check: lintRescueKeys, Item: serverItem(server)}
+	if rescue.Active && len(rescue.AuthorizedKey) == 0 {
+		result.Failed = true
+		result.Message = "rescue system active without authorized key"
+	}
+
+	return result
+}
+
+// containsIP compares the addresses in their canonical form, IPv6 addresses
+// may be written in different ways
+func containsIP(addrs []string, ip string) bool {
+	parsed := net.ParseIP(ip)
+	for _, addr := range addrs {
+		if addr == ip || (parsed != nil && parsed.Equal(net.ParseIP(addr))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func serverItem(server models.Server) string {
+	return fmt.Sprintf("%s (%s)", server.ServerName, server.ServerIP)
+}
+
+func renderLintResults(results []lintResult) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"check", "item", "message"})
+
+	violations := 0
+	for _, result := range results {
+		if !result.Failed {
+			continue
+		}
+
+		t.AppendRow(table.Row{result.Check, result.Item, result.Message})
+		violations++
+	}
+
+	t.AppendFooter(table.Row{"", fmt.Sprintf("%d checked", len(results)), fmt.Sprintf("%d violations", violations)})
+	t.Render()
+}
+
+func printJUnit(checks []string, results []lintResult) error {
+	suites := make(map[string]*junitTestSuite)
+	report := junitTestSuites{}
+
+	for _, check := range checks {
+		suites[check] = &junitTestSuite{Name: check}
+	}
+
+	for _, result := range results {
+		suite := suites[result.Check]
+		suite.Tests++
+
+		testCase := junitTestCase{Name: result.Item, Classname: "hrobot-cli.lint." + result.Check}
+		if result.Failed {
+			suite.Failures++
+			testCase.Failure = &junitFailure{Message: result.Message}
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	for _, check := range checks {
+		report.Suites = append(report.Suites, *suites[check])
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(os.Stdout, "%s%s\n", xml.Header, out)
+	return err
+}
@@ -0,0 +1,232 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/retry"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func getTestLintServers() []models.Server {
+	return []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "mongodb-ex44-hetzner-fsn1-dc14-321",
+			Product:      "EX44",
+			Dc:           "FSN1-DC14",
+		},
+		{
+			ServerIP:     "124.124.124.124",
+			ServerNumber: 322,
+			ServerName:   "mongodb-ex44-hetzner-fsn1-dc14-322",
+			Product:      "EX44",
+			Dc:           "FSN1-DC14",
+		},
+	}
+}
+
+func (s *AppSuite) TestLintCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	restore := cmd.SetLookupHost(func(host string) ([]string, error) {
+		return []string{"123.123.123.123"}, nil
+	})
+	defer restore()
+
+	rdns := []models.Rdns{
+		{IP: "123.123.123.123", Ptr: "mongodb-1.example.com"},
+	}
+
+	ips := []models.IP{
+		{IP: "123.123.123.123"},
+	}
+
+	failovers := []models.Failover{
+		{IP: "125.125.125.125", ServerIP: "123.123.123.123", ActiveServerIP: "124.124.124.124"},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(getTestLintServers(), nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return(rdns, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(ips, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return(failovers, nil)
+	mockRobotClient.EXPECT().BootRescueGet(gomock.Any()).Times(2).Return(&models.Rescue{}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "lint", "-o", "junit")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestLintCommandViolations(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	restore := cmd.SetLookupHost(func(host string) ([]string, error) {
+		return nil, errors.New("no such host")
+	})
+	defer restore()
+
+	servers := getTestLintServers()
+	servers[1].ServerName = servers[0].ServerName
+	servers[1].Cancelled = true
+
+	rdns := []models.Rdns{
+		{IP: "123.123.123.123", Ptr: "mongodb-1.example.com"},
+	}
+
+	failovers := []models.Failover{
+		{IP: "125.125.125.125", ServerIP: "123.123.123.123", ActiveServerIP: "124.124.124.124"},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return(rdns, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return(failovers, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	// server-name: 322 has the name of 321, duplicate-names: both servers,
+	// rdns-resolve: PTR does not resolve, failover-cancelled: routed to 322
+	_, err := executeCommand(rootCmd, "lint", "--skip", "rdns,rescue-keys")
+	c.Assert(err, ErrorMatches, "5 lint violations found")
+}
+
+func (s *AppSuite) TestLintCommandRescueNotAvailable(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	notFound := errors.New(`{"error":{"status":404,"code":"NOT_FOUND","message":"Not found"}}`)
+
+	servers := getTestLintServers()
+	servers = append(servers, models.Server{ServerIP: "125.125.125.125", ServerName: "mongodb-old", Cancelled: true})
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().BootRescueGet("124.124.124.124").Times(1).Return(&models.Rescue{}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	// servers without rescue options and cancelled servers are skipped
+	_, err := executeCommand(rootCmd, "lint", "--checks", "rescue-keys", "-o", "junit")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestLintCommandRescueError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(getTestLintServers(), nil)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(nil, errors.New("proxyconnect tcp: connection refused"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "lint", "--checks", "rescue-keys")
+	c.Assert(err, ErrorMatches, "proxyconnect tcp: connection refused")
+}
+
+func (s *AppSuite) TestLintCommandRescueRetriesExhausted(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	exhausted := &retry.ExhaustedError{
+		Call:     "BootRescueGet",
+		Attempts: 4,
+		Err:      errors.New(`{"error":{"status":403,"code":"RATE_LIMIT_EXCEEDED","message":"Rate limit exceeded"}}`),
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(getTestLintServers(), nil)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(nil, exhausted)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "lint", "--checks", "rescue-keys")
+	c.Assert(err, Equals, exhausted)
+}
+
+func (s *AppSuite) TestLintCommandNotFoundLists(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	notFound := errors.New(`{"error":{"status":404,"code":"NOT_FOUND","message":"Not found"}}`)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(getTestLintServers(), nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return(nil, notFound)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "lint", "--skip", "rescue-keys")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestLintCommandListError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(getTestLintServers(), nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "lint", "--checks", "failover-cancelled")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestLintCommandUnknownCheck(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "lint", "--checks", "spelling")
+	c.Assert(err, ErrorMatches, `unknown check "spelling", .*`)
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (app *RobotApp) NewIPMacGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ip:mac:get [ip]",
+		Short: "Print separate MAC address of single IP",
+		Long: `Print the separate MAC address of single additional IP in hetzner account,
+IP can be given as argument or chosen interactively`,
+		Annotations: argCompletion(completeIPs),
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := app.ipArg(args)
+			if err != nil {
+				return err
+			}
+
+			mac, err := app.client.IPMacGet(addr)
+			if err != nil {
+				return err
+			}
+
+			renderMac(mac)
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewIPMacGenerateCmd() *cobra.Command {
+	return app.newIPMacBulkCmd(
+		"ip:mac:generate",
+		"Generate separate MAC addresses for selected IP's",
+		"generate a separate MAC address for",
+		func(ip string) error {
+			mac, err := app.client.IPMacGenerate(ip)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Generated MAC address %s for %s", mac.Mac, ip)
+			return nil
+		},
+	)
+}
+
+func (app *RobotApp) NewIPMacDeleteCmd() *cobra.Command {
+	return app.newIPMacBulkCmd(
+		"ip:mac:delete",
+		"Delete separate MAC addresses of selected IP's",
+		"delete the separate MAC address of",
+		func(ip string) error {
+			_, err := app.client.IPMacDelete(ip)
+			return err
+		},
+	)
+}
+
+// newIPMacBulkCmd builds the commands changing the MAC addresses of multiple IP's
+func (app *RobotApp) newIPMacBulkCmd(use string, short string, action string, run func(ip string) error) *cobra.Command {
+	var sel ipSelector
+	var opts bulkOptions
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: fmt.Sprintf(`%s in the hetzner account. IP's can be chosen interactively, given by flags
+or taken from the servers selected by flags.`, short),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			ipList, err := app.client.IPGetList()
+			if err != nil {
+				return err
+			}
+
+			chosenIPs, err := app.selectIPs(&sel, ipList)
+			if err != nil {
+				return err
+			}
+
+			if err := app.confirmIPs(chosenIPs, action); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			var tasks []bulkTask
+			for _, ip := range chosenIPs {
+				ip := ip
+
+				tasks = append(tasks, bulkTask{
+					Item:   ip.IP,
+					Action: action,
+					Run: func() error {
+						return run(ip.IP)
+					},
+				})
+			}
+
+			return app.executeBulk(tasks, &opts)
+		},
+	}
+
+	addIPSelectorFlags(cmd, &sel)
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+func (app *RobotApp) NewSubnetMacGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "subnet:mac:get [subnet]",
+		Short: "Print MAC address of single IPv6 subnet",
+		Long: `Print the MAC address an IPv6 subnet in hetzner account is routed to and the possible MAC
+addresses, subnet can be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := app.subnetArg(args)
+			if err != nil {
+				return err
+			}
+
+			mac, err := app.client.SubnetMacGet(addr)
+			if err != nil {
+				return err
+			}
+
+			renderMac(mac)
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewSubnetMacSetCmd() *cobra.Command {
+	var macAddr string
+
+	cmd := &cobra.Command{
+		Use:   "subnet:mac:set [subnet]",
+		Short: "Set MAC address of single IPv6 subnet",
+		Long: `Route an IPv6 subnet in hetzner account to one of its possible MAC addresses, the MAC addresses
+of the server the subnet belongs to. No new MAC address is generated, the first possible MAC address
+is used if none is given. Subnet can be given as argument or chosen interactively.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := app.subnetArg(args)
+			if err != nil {
+				return err
+			}
+
+			if macAddr == "" {
+				current, err := app.client.SubnetMacGet(addr)
+				if err != nil {
+					return err
+				}
+
+				possible := possibleMacs(current)
+				if len(possible) == 0 {
+					return fmt.Errorf("no possible MAC address for subnet %s", addr)
+				}
+
+				macAddr = possible[0]
+			}
+
+			mac, err := app.client.SubnetMacSet(addr, macAddr)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Set MAC address %s for subnet %s", mac.Mac, addr)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&macAddr, "mac", "", "MAC address to route the subnet to")
+
+	return cmd
+}
+
+func (app *RobotApp) NewSubnetMacDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "subnet:mac:delete [subnet]",
+		Short: "Delete MAC address of single IPv6 subnet",
+		Long: `Reset the MAC address of an IPv6 subnet in hetzner account to the default, subnet can be
+given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := app.subnetArg(args)
+			if err != nil {
+				return err
+			}
+
+			if _, err := app.client.SubnetMacDelete(addr); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Deleted MAC address of subnet %s", addr)
+			return nil
+		},
+	}
+}
+
+func renderMac(mac *robot.Mac) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"field", "value"})
+	t.AppendRow(table.Row{"ip", mac.IP})
+	if mac.Mask > 0 {
+		t.AppendRow(table.Row{"mask", mac.Mask})
+	}
+	t.AppendRow(table.Row{"mac", mac.Mac})
+	for _, possible := range possibleMacs(mac) {
+		t.AppendRow(table.Row{"possible mac", fmt.Sprintf("%s (%s)", possible, mac.PossibleMac[possible])})
+	}
+
+	t.Render()
+}
+
+// possibleMacs returns the possible MAC addresses of a subnet in a stable order
+func possibleMacs(mac *robot.Mac) []string {
+	var macs []string
+	for possible := range mac.PossibleMac {
+		macs = append(macs, possible)
+	}
+	sort.Strings(macs)
+
+	return macs
+}
+
+// subnetIP strips the prefix length from a subnet as the webservice expects
+// the network address only
+func subnetIP(subnet string) string {
+	return strings.Split(strings.TrimSpace(subnet), "/")[0]
+}
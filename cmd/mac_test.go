@@ -0,0 +1,113 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+)
+
+func (s *AppSuite) TestIPMacGetCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := &robot.Mac{
+		IP:  "123.123.123.123",
+		Mac: "00:50:56:00:9c:5e",
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPMacGet("123.123.123.123").Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:mac:get", "123.123.123.123")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestIPMacGetCommandListError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "ip:mac:get")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestSubnetMacGetCommandListError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "subnet:mac:get")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestSubnetMacSetCommandFirstPossibleMac(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	current := &robot.Mac{
+		IP:   "2a01:4f8:111:4221::",
+		Mask: 64,
+		Mac:  "00:21:85:62:3e:9b",
+		PossibleMac: map[string]string{
+			"00:21:85:62:3e:9b": "123.123.123.123",
+			"00:21:85:62:3d:8c": "123.123.123.124",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().SubnetMacGet("2a01:4f8:111:4221::").Times(1).Return(current, nil)
+	mockRobotClient.EXPECT().SubnetMacSet("2a01:4f8:111:4221::", "00:21:85:62:3d:8c").Times(1).Return(current, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "subnet:mac:set", "2a01:4f8:111:4221::/64")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestSubnetMacDeleteCommandTooManyArgs(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "subnet:mac:delete", "2a01:4f8:111:4221::", "2a01:4f8:111:4222::")
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,585 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+const (
+	marketSortPrice       = "price"
+	marketSortPricePerRAM = "price-per-ram"
+	marketSortPricePerTB  = "price-per-tb"
+	marketSortRAM         = "ram"
+	marketSortDisk        = "disk"
+	marketSortBenchmark   = "benchmark"
+	marketSortNextReduce  = "next-reduce"
+)
+
+var marketSortKeys = []string{
+	marketSortPrice,
+	marketSortPricePerRAM,
+	marketSortPricePerTB,
+	marketSortRAM,
+	marketSortDisk,
+	marketSortBenchmark,
+	marketSortNextReduce,
+}
+
+// marketFilter holds the filter flags shared by the server auction commands,
+// zero values do not filter
+type marketFilter struct {
+	minRAM       int
+	minDisk      int
+	minDisks     int
+	minBenchmark int
+	cpu          string
+	datacenter   string
+	maxPrice     float64
+	ecc          bool
+	sortBy       string
+	limit        int
+}
+
+type marketRow struct {
+	ID           int     `json:"id"`
+	CPU          string  `json:"cpu"`
+	CPUBenchmark int     `json:"cpu_benchmark"`
+	MemorySize   int     `json:"memory_size"`
+	Disks        string  `json:"disks"`
+	DiskSize     int     `json:"disk_size"`
+	ECC          bool    `json:"ecc"`
+	Datacenter   string  `json:"datacenter"`
+	Price        float64 `json:"price"`
+	PricePerRAM  float64 `json:"price_per_gb_ram"`
+	PricePerTB   float64 `json:"price_per_tb"`
+	NextReduce   string  `json:"next_reduce"`
+}
+
+func addMarketFilterFlags(cmd *cobra.Command, filter *marketFilter) {
+	cmd.Flags().IntVar(&filter.minRAM, "min-ram", 0, "minimum memory in GB")
+	cmd.Flags().IntVar(&filter.minDisk, "min-disk", 0, "minimum total disk size in GB")
+	cmd.Flags().IntVar(&filter.minDisks, "min-disks", 0, "minimum number of disks")
+	cmd.Flags().IntVar(&filter.minBenchmark, "min-benchmark", 0, "minimum CPU benchmark score")
+	cmd.Flags().StringVar(&filter.cpu, "cpu", "", "part of the CPU name, e.g. Ryzen")
+	cmd.Flags().StringVar(&filter.datacenter, "datacenter", "", "datacenter or location prefix, e.g. FSN1")
+	cmd.Flags().Float64Var(&filter.maxPrice, "max-price", 0, "maximum monthly price (net, EUR)")
+	cmd.Flags().BoolVar(&filter.ecc, "ecc", false, "only servers with ECC memory")
+	cmd.Flags().StringVar(&filter.sortBy, "sort", marketSortPrice, "sort by "+strings.Join(marketSortKeys, ", "))
+	cmd.Flags().IntVar(&filter.limit, "limit", 0, "maximum number of servers to print, 0 prints all")
+}
+
+func (filter *marketFilter) validate() error {
+	if filter.limit < 0 {
+		return fmt.Errorf("limit must not be negative, got %d", filter.limit)
+	}
+
+	for _, key := range marketSortKeys {
+		if filter.sortBy == key {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown sort key %q, use one of: %s", filter.sortBy, strings.Join(marketSortKeys, ", "))
+}
+
+func (filter *marketFilter) matches(product robot.MarketProduct) bool {
+	if product.MemorySize < filter.minRAM {
+		return false
+	}
+
+	if marketDiskSize(product) < filter.minDisk || product.HddCount < filter.minDisks {
+		return false
+	}
+
+	if product.CPUBenchmark < filter.minBenchmark {
+		return false
+	}
+
+	if filter.cpu != "" && !strings.Contains(strings.ToLower(product.CPU), strings.ToLower(filter.cpu)) {
+		return false
+	}
+
+	if filter.datacenter != "" && !strings.HasPrefix(strings.ToUpper(product.Datacenter), strings.ToUpper(filter.datacenter)) {
+		return false
+	}
+
+	if filter.maxPrice > 0 && marketPrice(product) > filter.maxPrice {
+		return false
+	}
+
+	if filter.ecc && !marketECC(product) {
+		return false
+	}
+
+	return true
+}
+
+// apply filters and sorts the products, the limit is applied last
+func (filter *marketFilter) apply(products []robot.MarketProduct) []robot.MarketProduct {
+	var matching []robot.MarketProduct
+	for _, product := range products {
+		if filter.matches(product) {
+			matching = append(matching, product)
+		}
+	}
+
+	sortMarketProducts(matching, filter.sortBy)
+
+	if filter.limit > 0 && len(matching) > filter.limit {
+		matching = matching[:filter.limit]
+	}
+
+	return matching
+}
+
+func (app *RobotApp) NewMarketListCmd() *cobra.Command {
+	var filter marketFilter
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "market:list",
+		Short: "Print servers of the server auction",
+		Long: `Print the servers currently offered in the server auction with CPU, memory, disks, datacenter
+and monthly price (net, EUR). Servers can be filtered by flags and sorted by price, price per GB of
+memory, price per TB of disk space, memory, disk size, CPU benchmark or time until the next price
+reduction.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputTable, outputJSON, outputCSV); err != nil {
+				return err
+			}
+
+			if err := filter.validate(); err != nil {
+				return err
+			}
+
+			products, err := app.client.MarketProductGetList()
+			if err != nil {
+				return err
+			}
+
+			return printMarketProducts(filter.apply(products), output)
+		},
+	}
+
+	addMarketFilterFlags(cmd, &filter)
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format (table, json, csv)")
+
+	return cmd
+}
+
+func (app *RobotApp) NewMarketWatchCmd() *cobra.Command {
+	var filter marketFilter
+	var interval time.Duration
+	var untilMatch bool
+
+	cmd := &cobra.Command{
+		Use:   "market:watch",
+		Short: "Watch server auction for matching servers",
+		Long: `Poll the server auction and print servers matching the filter flags as soon as they are offered
+or their price drops into the filter. Servers are reported once per run. With --until-match the
+command exits after the first match, which makes it usable in scripts.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := filter.validate(); err != nil {
+				return err
+			}
+
+			if interval <= 0 {
+				return fmt.Errorf("interval must be positive, got %s", interval)
+			}
+
+			seen := make(map[int]bool)
+
+			for {
+				products, err := app.client.MarketProductGetList()
+				if err != nil {
+					return err
+				}
+
+				var matches []robot.MarketProduct
+				for _, product := range filter.apply(products) {
+					if !seen[product.ID] {
+						seen[product.ID] = true
+						matches = append(matches, product)
+					}
+				}
+
+				if len(matches) > 0 {
+					color.Green(fmt.Sprintf("%d new matching servers in the server auction:", len(matches)))
+
+					if err := printMarketProducts(matches, outputTable); err != nil {
+						return err
+					}
+
+					if untilMatch {
+						return nil
+					}
+				}
+
+				app.logger.Infof("Checking server auction again in %s ...", interval)
+				time.Sleep(interval)
+			}
+		},
+	}
+
+	addMarketFilterFlags(cmd, &filter)
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "interval between polls")
+	cmd.Flags().BoolVar(&untilMatch, "until-match", false, "exit after the first matching servers were found")
+
+	return cmd
+}
+
+func (app *RobotApp) NewMarketOrderCmd() *cobra.Command {
+	var dist, lang, comment string
+	var keyRefs []string
+	var arch int
+	var test bool
+
+	cmd := &cobra.Command{
+		Use:   "market:order [product-id]",
+		Short: "Order server from the server auction",
+		Long: `Order a server from the server auction, the server can be given by its id as argument or chosen
+interactively. Distribution and ssh keys can be given by flags or chosen interactively, the order is
+confirmed before it is sent. With --test the order is only validated by the webservice and no
+server is ordered.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			products, err := app.client.MarketProductGetList()
+			if err != nil {
+				return err
+			}
+
+			sortMarketProducts(products, marketSortPrice)
+
+			product, err := app.chooseMarketProduct(products, args)
+			if err != nil {
+				return err
+			}
+
+			chosenDist, err := app.chooseOption("distribution", product.Dist, dist)
+			if err != nil {
+				return err
+			}
+
+			if lang != "" && !containsFold(product.Lang, lang) {
+				return fmt.Errorf("language %q not available for server %d, use one of: %s", lang, product.ID, strings.Join(product.Lang, ", "))
+			}
+
+			if arch != 0 && !containsArch(product.Arch, arch) {
+				return fmt.Errorf("architecture %d not available for server %d", arch, product.ID)
+			}
+
+			keys, err := app.chooseKeys(keyRefs)
+			if err != nil {
+				return err
+			}
+
+			input := &robot.MarketOrderInput{
+				ProductID: product.ID,
+				Dist:      chosenDist,
+				Arch:      arch,
+				Lang:      lang,
+				Comment:   comment,
+				Test:      test,
+			}
+
+			var keyNames []string
+			for _, key := range keys {
+				input.Keys = append(input.Keys, key.Fingerprint)
+				keyNames = append(keyNames, key.Name)
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"server", fmt.Sprintf("%s (%d)", product.Name, product.ID)})
+			t.AppendRow(table.Row{"cpu", product.CPU})
+			t.AppendRow(table.Row{"memory", fmt.Sprintf("%d GB", product.MemorySize)})
+			t.AppendRow(table.Row{"disks", product.HddText})
+			t.AppendRow(table.Row{"datacenter", product.Datacenter})
+			t.AppendRow(table.Row{"price / month", product.Price})
+			t.AppendRow(table.Row{"setup", product.PriceSetup})
+			t.AppendRow(table.Row{"distribution", chosenDist})
+			t.AppendRow(table.Row{"ssh keys", strings.Join(keyNames, "\n")})
+			t.AppendRow(table.Row{"comment", comment})
+			t.AppendRow(table.Row{"test order", test})
+
+			t.Render()
+
+			label := fmt.Sprintf("Really order server %d in %s for %s EUR per month", product.ID, product.Datacenter, product.Price)
+			if test {
+				label = fmt.Sprintf("Send test order for server %d in %s", product.ID, product.Datacenter)
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     label,
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			transaction, err := app.client.MarketTransactionCreate(input)
+			if err != nil {
+				return err
+			}
+
+			if test {
+				app.logger.Infof("Test order for server %d was accepted, no server was ordered", product.ID)
+				return nil
+			}
+
+			app.logger.Infof("Ordered server %d, transaction %s is %s", product.ID, transaction.ID, transaction.Status)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dist, "dist", "", "distribution installed on the server")
+	cmd.Flags().StringVar(&lang, "lang", "", "language of the distribution")
+	cmd.Flags().IntVar(&arch, "arch", 0, "architecture of the distribution")
+	cmd.Flags().StringSliceVar(&keyRefs, "keys", nil, "comma separated names or fingerprints of ssh keys")
+	markFlagCompletion(cmd, "keys", completeKeys)
+	cmd.Flags().StringVar(&comment, "comment", "", "comment of the order")
+	cmd.Flags().BoolVar(&test, "test", false, "only validate the order, no server is ordered")
+
+	return cmd
+}
+
+// chooseMarketProduct returns the server given by id as argument, the server
+// is chosen interactively if no argument is given
+func (app *RobotApp) chooseMarketProduct(products []robot.MarketProduct, args []string) (*robot.MarketProduct, error) {
+	if len(args) > 0 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid server id %q", args[0])
+		}
+
+		for i := range products {
+			if products[i].ID == id {
+				return &products[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("server %d is not offered in the server auction", id)
+	}
+
+	if len(products) == 0 {
+		return nil, errors.New("no servers offered in the server auction")
+	}
+
+	prompt := promptui.Select{
+		Label: "Select server",
+		Items: products,
+		Searcher: func(input string, index int) bool {
+			product := products[index]
+			content := strings.ToLower(strconv.Itoa(product.ID) + product.CPU + product.HddText + product.Datacenter)
+
+			return strings.Contains(content, strings.ToLower(input))
+		},
+		Size: 10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "→ {{ .CPU | green }}, {{ .MemorySize | green }} GB ({{ .ID | yellow }} - {{ .Price | yellow }} EUR)",
+			Inactive: "  {{ .CPU | cyan }}, {{ .MemorySize | cyan }} GB ({{ .ID | red }} - {{ .Price | blue }} EUR)",
+			Selected: "→ {{ .ID | cyan }}",
+			Details: `
+	--------- Selected server ----------
+	{{ range .Description }}{{ . }}
+	{{ end }}`,
+		},
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &products[chosenIdx], nil
+}
+
+func printMarketProducts(products []robot.MarketProduct, output string) error {
+	var rows []marketRow
+	for _, product := range products {
+		rows = append(rows, marketRow{
+			ID:           product.ID,
+			CPU:          product.CPU,
+			CPUBenchmark: product.CPUBenchmark,
+			MemorySize:   product.MemorySize,
+			Disks:        product.HddText,
+			DiskSize:     marketDiskSize(product),
+			ECC:          marketECC(product),
+			Datacenter:   product.Datacenter,
+			Price:        finite(marketPrice(product)),
+			PricePerRAM:  finite(marketPricePerRAM(product)),
+			PricePerTB:   finite(marketPricePerTB(product)),
+			NextReduce:   formatNextReduce(product),
+		})
+	}
+
+	switch output {
+	case outputJSON:
+		return printJSON(rows)
+	case outputCSV:
+		var csvRows [][]string
+		for _, row := range rows {
+			csvRows = append(csvRows, []string{
+				strconv.Itoa(row.ID),
+				row.CPU,
+				strconv.Itoa(row.CPUBenchmark),
+				strconv.Itoa(row.MemorySize),
+				row.Disks,
+				strconv.Itoa(row.DiskSize),
+				strconv.FormatBool(row.ECC),
+				row.Datacenter,
+				fmt.Sprintf("%.2f", row.Price),
+				fmt.Sprintf("%.2f", row.PricePerRAM),
+				fmt.Sprintf("%.2f", row.PricePerTB),
+				row.NextReduce,
+			})
+		}
+
+		return printCSV([]string{"id", "cpu", "cpu_benchmark", "memory_size", "disks", "disk_size", "ecc", "datacenter", "price", "price_per_gb_ram", "price_per_tb", "next_reduce"}, csvRows)
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"id", "cpu", "benchmark", "ram", "disks", "ecc", "datacenter", "price", "per GB ram", "per TB", "next reduce"})
+
+	for _, row := range rows {
+		t.AppendRow(table.Row{
+			row.ID,
+			row.CPU,
+			row.CPUBenchmark,
+			fmt.Sprintf("%d GB", row.MemorySize),
+			row.Disks,
+			row.ECC,
+			row.Datacenter,
+			fmt.Sprintf("%.2f", row.Price),
+			fmt.Sprintf("%.2f", row.PricePerRAM),
+			fmt.Sprintf("%.2f", row.PricePerTB),
+			row.NextReduce,
+		})
+	}
+
+	t.AppendFooter(table.Row{"", "", "", "", "", "", "", "", "", "Total", len(rows)})
+	t.SetCaption("Prices per month in EUR (net)")
+	t.Render()
+
+	return nil
+}
+
+// sortMarketProducts sorts cheapest and biggest servers first, servers with
+// equal values keep their order
+func sortMarketProducts(products []robot.MarketProduct, sortBy string) {
+	var less func(a, b robot.MarketProduct) bool
+
+	switch sortBy {
+	case marketSortPricePerRAM:
+		less = func(a, b robot.MarketProduct) bool { return marketPricePerRAM(a) < marketPricePerRAM(b) }
+	case marketSortPricePerTB:
+		less = func(a, b robot.MarketProduct) bool { return marketPricePerTB(a) < marketPricePerTB(b) }
+	case marketSortRAM:
+		less = func(a, b robot.MarketProduct) bool { return a.MemorySize > b.MemorySize }
+	case marketSortDisk:
+		less = func(a, b robot.MarketProduct) bool { return marketDiskSize(a) > marketDiskSize(b) }
+	case marketSortBenchmark:
+		less = func(a, b robot.MarketProduct) bool { return a.CPUBenchmark > b.CPUBenchmark }
+	case marketSortNextReduce:
+		less = func(a, b robot.MarketProduct) bool { return nextReduce(a) < nextReduce(b) }
+	default:
+		less = func(a, b robot.MarketProduct) bool { return marketPrice(a) < marketPrice(b) }
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		return less(products[i], products[j])
+	})
+}
+
+func marketPrice(product robot.MarketProduct) float64 {
+	price, err := strconv.ParseFloat(product.Price, 64)
+	if err != nil {
+		return math.Inf(1)
+	}
+
+	return price
+}
+
+func marketDiskSize(product robot.MarketProduct) int {
+	return product.HddSize * product.HddCount
+}
+
+func marketPricePerRAM(product robot.MarketProduct) float64 {
+	if product.MemorySize == 0 {
+		return math.Inf(1)
+	}
+
+	return marketPrice(product) / float64(product.MemorySize)
+}
+
+func marketPricePerTB(product robot.MarketProduct) float64 {
+	if marketDiskSize(product) == 0 {
+		return math.Inf(1)
+	}
+
+	return marketPrice(product) / (float64(marketDiskSize(product)) / 1000)
+}
+
+// finite replaces the infinite values used for sorting servers without price,
+// memory or disks, they are not representable in JSON
+func finite(value float64) float64 {
+	if math.IsInf(value, 0) {
+		return 0
+	}
+
+	return value
+}
+
+// marketECC reports ECC memory, the webservice only mentions it in the
+// description of the server
+func marketECC(product robot.MarketProduct) bool {
+	for _, line := range product.Description {
+		upper := strings.ToUpper(line)
+		if strings.Contains(upper, "ECC") && !strings.Contains(upper, "NON-ECC") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextReduce returns the seconds until the next price reduction, servers with
+// fixed price sort last
+func nextReduce(product robot.MarketProduct) int {
+	if product.FixedPrice || product.NextReduce <= 0 {
+		return math.MaxInt32
+	}
+
+	return product.NextReduce
+}
+
+func formatNextReduce(product robot.MarketProduct) string {
+	if product.FixedPrice || product.NextReduce <= 0 {
+		return "fixed"
+	}
+
+	return (time.Duration(product.NextReduce) * time.Second).String()
+}
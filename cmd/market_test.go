@@ -0,0 +1,141 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+)
+
+func getTestMarketProducts() []robot.MarketProduct {
+	return []robot.MarketProduct{
+		{
+			ID:          1,
+			CPU:         "Intel Xeon E3-1275v6",
+			Description: []string{"4x RAM 16384 MB DDR4 ECC"},
+			MemorySize:  64,
+			HddSize:     4000,
+			HddCount:    2,
+			Datacenter:  "FSN1-DC14",
+			Price:       "39.0000",
+		},
+		{
+			ID:          2,
+			CPU:         "AMD Ryzen 7 3700X",
+			Description: []string{"4x RAM 32768 MB DDR4"},
+			MemorySize:  128,
+			HddSize:     1000,
+			HddCount:    2,
+			Datacenter:  "HEL1-DC2",
+			Price:       "48.0000",
+		},
+		{
+			ID:          3,
+			CPU:         "Intel Core i7-6700",
+			Description: []string{"2x RAM 16384 MB DDR4"},
+			MemorySize:  32,
+			HddSize:     512,
+			HddCount:    2,
+			Datacenter:  "FSN1-DC1",
+			Price:       "29.0000",
+		},
+	}
+}
+
+func marketProductIDs(products []robot.MarketProduct) []int {
+	var ids []int
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	return ids
+}
+
+func (s *AppSuite) TestSortMarketProducts(c *C) {
+	products := getTestMarketProducts()
+
+	cmd.SortMarketProducts(products, "price")
+	c.Assert(marketProductIDs(products), DeepEquals, []int{3, 1, 2})
+
+	cmd.SortMarketProducts(products, "price-per-ram")
+	c.Assert(marketProductIDs(products), DeepEquals, []int{2, 1, 3})
+
+	cmd.SortMarketProducts(products, "price-per-tb")
+	c.Assert(marketProductIDs(products), DeepEquals, []int{1, 2, 3})
+
+	cmd.SortMarketProducts(products, "ram")
+	c.Assert(marketProductIDs(products), DeepEquals, []int{2, 1, 3})
+}
+
+func (s *AppSuite) TestMarketListCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().MarketProductGetList().Times(1).Return(getTestMarketProducts(), nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "market:list", "--min-ram", "64", "--datacenter", "fsn1", "--ecc", "--max-price", "40", "-o", "csv")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestMarketListCommandInvalidSort(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "market:list", "--sort", "cores")
+	c.Assert(err, ErrorMatches, `unknown sort key "cores", .*`)
+}
+
+func (s *AppSuite) TestMarketWatchCommandUntilMatch(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().MarketProductGetList().Times(1).Return(getTestMarketProducts()[:1], nil),
+		mockRobotClient.EXPECT().MarketProductGetList().Times(1).Return(getTestMarketProducts(), nil),
+	)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "market:watch", "--cpu", "ryzen", "--interval", "1ms", "--until-match")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestMarketOrderCommandNotOffered(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().MarketProductGetList().Times(1).Return(getTestMarketProducts(), nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "market:order", "4", "--test")
+	c.Assert(err, ErrorMatches, "server 4 is not offered in the server auction")
+}
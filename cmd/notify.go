@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-cli/notify"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// notifyAction sends the outcome of a mutating action to the webhook of the
+// profile, failures are only logged as the action itself already ran
+func (app *RobotApp) notifyAction(command string, action string, targets []notify.Target) {
+	if app.notifier == nil {
+		return
+	}
+
+	event := notify.Event{
+		Time:    time.Now(),
+		Actor:   history.CurrentUser(),
+		Command: command,
+		Action:  action,
+		Targets: targets,
+	}
+
+	if err := app.notifier.Send(event); err != nil {
+		app.logger.Warnf("Could not send notification: %s", err)
+	}
+}
+
+// bulkTargets pairs the servers with the results of the tasks created for
+// them in the same order
+func bulkTargets(servers []models.Server, results []bulkResult) []notify.Target {
+	targets := make([]notify.Target, len(servers))
+	for i, server := range servers {
+		targets[i] = notify.Target{
+			ServerNumber: server.ServerNumber,
+			ServerName:   server.ServerName,
+			ServerIP:     server.ServerIP,
+			Status:       notify.StatusSkipped,
+		}
+
+		if i < len(results) {
+			targets[i].Status = results[i].Status
+			targets[i].Error = results[i].Error
+		}
+	}
+
+	return targets
+}
+
+func serverTarget(server models.Server, err error) notify.Target {
+	target := notify.Target{
+		ServerNumber: server.ServerNumber,
+		ServerName:   server.ServerName,
+		ServerIP:     server.ServerIP,
+		Status:       notify.StatusOK,
+	}
+
+	if err != nil {
+		target.Status = notify.StatusFailed
+		target.Error = err.Error()
+	}
+
+	return target
+}
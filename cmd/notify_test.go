@@ -0,0 +1,90 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/notify"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestRollingActionNotifies(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	var events []notify.Event
+	standIn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.Event
+		c.Check(json.NewDecoder(r.Body).Decode(&event), IsNil)
+		events = append(events, event)
+	}))
+	defer standIn.Close()
+
+	notifier, err := notify.New(standIn.URL, notify.FormatWebhook, "prod", time.Second)
+	c.Assert(err, IsNil)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetNotifier(notifier)
+
+	servers := []models.Server{
+		{ServerIP: "123.123.123.123", ServerNumber: 42, ServerName: "app-prod-42"},
+		{ServerIP: "124.124.124.124", ServerNumber: 84, ServerName: "app-prod-84"},
+	}
+
+	err = app.ExecuteRolling("server:reset", servers, "hardware reset", func(server models.Server) error {
+		if server.ServerNumber == 84 {
+			return errors.New("reset failed")
+		}
+
+		return nil
+	})
+	c.Assert(err, ErrorMatches, "1 of 2 items failed")
+
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Profile, Equals, "prod")
+	c.Assert(events[0].Command, Equals, "server:reset")
+	c.Assert(events[0].Action, Equals, "hardware reset")
+	c.Assert(events[0].Actor, Not(Equals), "")
+	c.Assert(events[0].Targets, DeepEquals, []notify.Target{
+		{ServerNumber: 42, ServerName: "app-prod-42", ServerIP: "123.123.123.123", Status: notify.StatusOK},
+		{ServerNumber: 84, ServerName: "app-prod-84", ServerIP: "124.124.124.124", Status: notify.StatusFailed, Error: "reset failed"},
+	})
+}
+
+func (s *AppSuite) TestServerResetCommandNotConfirmedDoesNotNotify(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	standIn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Error("unexpected notification")
+	}))
+	defer standIn.Close()
+
+	notifier, err := notify.New(standIn.URL, notify.FormatSlack, "prod", time.Second)
+	c.Assert(err, IsNil)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return([]models.Server{{ServerIP: "123.123.123.123", ServerName: "app-prod-42"}}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetNotifier(notifier)
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err = executeCommand(rootCmd, "server:reset", "--filter", "app-prod")
+	c.Assert(err, IsNil)
+}
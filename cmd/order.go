@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (app *RobotApp) NewOrderProductsCmd() *cobra.Command {
+	var location string
+
+	cmd := &cobra.Command{
+		Use:   "order:products [product-id]",
+		Short: "Print servers of the standard product catalog",
+		Long: `Print the dedicated servers of the standard product catalog with monthly and setup prices (net,
+in EUR) per location. With a product id as argument the details of the product including the
+available distributions, architectures and languages are printed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			products, err := app.client.OrderServerProductGetList()
+			if err != nil {
+				return err
+			}
+
+			if len(args) > 0 {
+				product, err := findServerProduct(products, args[0])
+				if err != nil {
+					return err
+				}
+
+				renderServerProduct(product)
+				return nil
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"id", "name", "traffic", "location", "price / month", "setup"})
+
+			count := 0
+			for _, product := range products {
+				listed := false
+
+				for _, price := range product.Prices {
+					if location != "" && !strings.EqualFold(price.Location, location) {
+						continue
+					}
+
+					t.AppendRow(table.Row{
+						product.ID,
+						product.Name,
+						product.Traffic,
+						price.Location,
+						price.Price.Net,
+						price.PriceSetup.Net,
+					})
+
+					listed = true
+				}
+
+				if listed {
+					count++
+				}
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "", "Total", count})
+			t.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&location, "location", "", "only print prices of this location, e.g. FSN1")
+
+	return cmd
+}
+
+func (app *RobotApp) NewOrderServerCmd() *cobra.Command {
+	var productID, location, dist, lang, comment string
+	var keyRefs []string
+	var arch int
+	var test bool
+
+	cmd := &cobra.Command{
+		Use:   "order:server",
+		Short: "Order server from the standard product catalog",
+		Long: `Order a dedicated server from the standard product catalog. Product, location, distribution and
+ssh keys can be given by flags or chosen interactively, the order is confirmed before it is sent.
+With --test the order is only validated by the webservice and no server is ordered.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			products, err := app.client.OrderServerProductGetList()
+			if err != nil {
+				return err
+			}
+
+			product, err := app.chooseServerProduct(products, productID)
+			if err != nil {
+				return err
+			}
+
+			chosenLocation, err := app.chooseOption("location", product.Location, location)
+			if err != nil {
+				return err
+			}
+
+			chosenDist, err := app.chooseOption("distribution", product.Dist, dist)
+			if err != nil {
+				return err
+			}
+
+			if lang != "" && !containsFold(product.Lang, lang) {
+				return fmt.Errorf("language %q not available for product %s, use one of: %s", lang, product.ID, strings.Join(product.Lang, ", "))
+			}
+
+			if arch != 0 && !containsArch(product.Arch, arch) {
+				return fmt.Errorf("architecture %d not available for product %s", arch, product.ID)
+			}
+
+			keys, err := app.chooseKeys(keyRefs)
+			if err != nil {
+				return err
+			}
+
+			input := &robot.ServerOrderInput{
+				ProductID: product.ID,
+				Location:  chosenLocation,
+				Dist:      chosenDist,
+				Arch:      arch,
+				Lang:      lang,
+				Comment:   comment,
+				Test:      test,
+			}
+
+			var keyNames []string
+			for _, key := range keys {
+				input.Keys = append(input.Keys, key.Fingerprint)
+				keyNames = append(keyNames, key.Name)
+			}
+
+			price := productPrice(product, chosenLocation)
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"product", fmt.Sprintf("%s (%s)", product.Name, product.ID)})
+			t.AppendRow(table.Row{"location", chosenLocation})
+			t.AppendRow(table.Row{"price / month", price.Price.Net})
+			t.AppendRow(table.Row{"setup", price.PriceSetup.Net})
+			t.AppendRow(table.Row{"distribution", chosenDist})
+			t.AppendRow(table.Row{"ssh keys", strings.Join(keyNames, "\n")})
+			t.AppendRow(table.Row{"comment", comment})
+			t.AppendRow(table.Row{"test order", test})
+
+			t.Render()
+
+			label := fmt.Sprintf("Really order %s in %s for %s EUR per month", product.Name, chosenLocation, price.Price.Net)
+			if test {
+				label = fmt.Sprintf("Send test order for %s in %s", product.Name, chosenLocation)
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     label,
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			transaction, err := app.client.OrderServerTransactionCreate(input)
+			if err != nil {
+				return err
+			}
+
+			if test {
+				app.logger.Infof("Test order for %s was accepted, no server was ordered", product.Name)
+				return nil
+			}
+
+			app.logger.Infof("Ordered %s, transaction %s is %s", product.Name, transaction.ID, transaction.Status)
+			app.logger.Infof("Track the order with: hrobot-cli order:transactions %s --wait", transaction.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&productID, "product", "", "id of the product, e.g. EX44")
+	cmd.Flags().StringVar(&location, "location", "", "location of the server, e.g. FSN1")
+	cmd.Flags().StringVar(&dist, "dist", "", "distribution installed on the server")
+	cmd.Flags().StringVar(&lang, "lang", "", "language of the distribution")
+	cmd.Flags().IntVar(&arch, "arch", 0, "architecture of the distribution")
+	cmd.Flags().StringSliceVar(&keyRefs, "keys", nil, "comma separated names or fingerprints of ssh keys")
+	markFlagCompletion(cmd, "keys", completeKeys)
+	cmd.Flags().StringVar(&comment, "comment", "", "comment of the order")
+	cmd.Flags().BoolVar(&test, "test", false, "only validate the order, no server is ordered")
+
+	return cmd
+}
+
+func (app *RobotApp) NewOrderTransactionsCmd() *cobra.Command {
+	var wait bool
+	var interval, timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "order:transactions [transaction-id]",
+		Short: "Print server orders",
+		Long: `Print the server orders of the last 30 days, with a transaction id as argument the details of a
+single order are printed. With --wait the order is polled until it is ready and the server appears
+in the server list.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				if wait {
+					return errors.New("--wait requires a transaction id")
+				}
+
+				transactions, err := app.client.OrderServerTransactionGetList()
+				if err != nil {
+					return err
+				}
+
+				t := table.NewWriter()
+				t.SetOutputMirror(os.Stdout)
+
+				t.AppendHeader(table.Row{"id", "date", "status", "product", "location", "server number", "server ip", "comment"})
+
+				for _, transaction := range transactions {
+					t.AppendRow(table.Row{
+						transaction.ID,
+						transaction.Date,
+						transaction.Status,
+						transaction.Product.Name,
+						transaction.Product.Location,
+						formatServerNumber(transaction.ServerNumber),
+						transaction.ServerIP,
+						transaction.Comment,
+					})
+				}
+
+				t.AppendFooter(table.Row{"", "", "", "", "", "", "Total", len(transactions)})
+				t.Render()
+
+				return nil
+			}
+
+			if wait {
+				if interval <= 0 {
+					return fmt.Errorf("interval must be positive, got %s", interval)
+				}
+
+				return app.waitForTransaction(args[0], interval, timeout)
+			}
+
+			transaction, err := app.client.OrderServerTransactionGet(args[0])
+			if err != nil {
+				return err
+			}
+
+			renderServerTransaction(transaction)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the server is ready and appears in the server list")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "interval between status checks while waiting")
+	cmd.Flags().DurationVar(&timeout, "wait-timeout", 24*time.Hour, "maximum time to wait for the server")
+
+	return cmd
+}
+
+// waitForTransaction polls the order until the server is ready and part of
+// the server list, cached server lists are bypassed while waiting
+func (app *RobotApp) waitForTransaction(id string, interval time.Duration, timeout time.Duration) error {
+	if cachedClient, ok := app.client.(cacheControl); ok {
+		cachedClient.SetRefresh(true)
+	}
+
+	start := time.Now()
+
+	for {
+		transaction, err := app.client.OrderServerTransactionGet(id)
+		if err != nil {
+			return err
+		}
+
+		if transaction.Status == robot.TransactionStatusCancelled {
+			return fmt.Errorf("order %s was cancelled", id)
+		}
+
+		if transaction.Status == robot.TransactionStatusReady && transaction.ServerNumber > 0 {
+			servers, err := app.client.ServerGetList()
+			if err != nil {
+				return err
+			}
+
+			for _, server := range servers {
+				if server.ServerNumber == transaction.ServerNumber {
+					renderServerTransaction(transaction)
+					color.Green(fmt.Sprintf("Server %d (%s) is ready", server.ServerNumber, server.ServerIP))
+					return nil
+				}
+			}
+		}
+
+		if time.Since(start)+interval > timeout {
+			return fmt.Errorf("order %s not completed after %s, status is %s", id, timeout, transaction.Status)
+		}
+
+		app.logger.Infof("Order %s is %s, checking again in %s ...", id, transaction.Status, interval)
+		time.Sleep(interval)
+	}
+}
+
+// chooseServerProduct returns the product with the given id, the product is
+// chosen interactively if no id is given
+func (app *RobotApp) chooseServerProduct(products []robot.ServerProduct, id string) (*robot.ServerProduct, error) {
+	if id != "" {
+		return findServerProduct(products, id)
+	}
+
+	if len(products) == 0 {
+		return nil, errors.New("no products available")
+	}
+
+	prompt := promptui.Select{
+		Label: "Select product",
+		Items: products,
+		Searcher: func(input string, index int) bool {
+			product := products[index]
+			content := strings.ToLower(product.ID + product.Name)
+
+			return strings.Contains(content, strings.ToLower(input))
+		},
+		Size: 10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "→ {{ .Name | green }} ({{ .ID | yellow }} - {{ .Traffic | yellow }})",
+			Inactive: "  {{ .Name | cyan }} ({{ .ID | red }} - {{ .Traffic | blue }})",
+			Selected: "→ {{ .Name | cyan }}",
+			Details: `
+	--------- Selected product ----------
+	{{ range .Description }}{{ . }}
+	{{ end }}`,
+		},
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &products[chosenIdx], nil
+}
+
+// chooseKeys resolves the keys given by name or fingerprint, the keys are
+// chosen interactively if no key is given
+func (app *RobotApp) chooseKeys(refs []string) ([]models.Key, error) {
+	keys, err := app.client.KeyGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no ssh keys in account, add a key in the robot first")
+	}
+
+	if len(refs) > 0 {
+		var chosenKeys []models.Key
+		for _, ref := range refs {
+			found := false
+
+			for _, key := range keys {
+				if key.Name == ref || key.Fingerprint == ref {
+					chosenKeys = append(chosenKeys, key)
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return nil, fmt.Errorf("no ssh key found for %q", ref)
+			}
+		}
+
+		return chosenKeys, nil
+	}
+
+	chosenKeys, err := app.selectMultipleKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chosenKeys) == 0 {
+		return nil, errors.New("no ssh keys selected")
+	}
+
+	return chosenKeys, nil
+}
+
+// chooseOption validates the given value against the options, the option is
+// chosen interactively if no value is given and there is more than one option
+func (app *RobotApp) chooseOption(label string, options []string, value string) (string, error) {
+	if value != "" {
+		for _, option := range options {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+
+		return "", fmt.Errorf("%s %q not available, use one of: %s", label, value, strings.Join(options, ", "))
+	}
+
+	switch len(options) {
+	case 0:
+		return "", nil
+	case 1:
+		return options[0], nil
+	}
+
+	prompt := promptui.Select{
+		Label: "Select " + label,
+		Items: options,
+		Size:  10,
+		Searcher: func(input string, index int) bool {
+			return strings.Contains(strings.ToLower(options[index]), strings.ToLower(input))
+		},
+		Stdout: app.promptOutput(),
+	}
+
+	_, chosen, err := prompt.Run()
+	return chosen, err
+}
+
+func findServerProduct(products []robot.ServerProduct, id string) (*robot.ServerProduct, error) {
+	for i := range products {
+		if strings.EqualFold(products[i].ID, id) {
+			return &products[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no product found for %q", id)
+}
+
+func productPrice(product *robot.ServerProduct, location string) robot.LocationPrice {
+	for _, price := range product.Prices {
+		if price.Location == location {
+			return price
+		}
+	}
+
+	return robot.LocationPrice{Location: location}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsArch(archs []int, arch int) bool {
+	for _, a := range archs {
+		if a == arch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatServerNumber(number int) string {
+	if number == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(number)
+}
+
+func renderServerProduct(product *robot.ServerProduct) {
+	var archs []string
+	for _, arch := range product.Arch {
+		archs = append(archs, strconv.Itoa(arch))
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"field", "value"})
+	t.AppendRow(table.Row{"id", product.ID})
+	t.AppendRow(table.Row{"name", product.Name})
+	t.AppendRow(table.Row{"description", strings.Join(product.Description, "\n")})
+	t.AppendRow(table.Row{"traffic", product.Traffic})
+	t.AppendRow(table.Row{"distributions", strings.Join(product.Dist, "\n")})
+	t.AppendRow(table.Row{"architectures", strings.Join(archs, ", ")})
+	t.AppendRow(table.Row{"languages", strings.Join(product.Lang, ", ")})
+
+	t.Render()
+
+	tPrices := table.NewWriter()
+	tPrices.SetOutputMirror(os.Stdout)
+
+	tPrices.AppendHeader(table.Row{"location", "price / month (net)", "price / month (gross)", "setup (net)", "setup (gross)"})
+
+	for _, price := range product.Prices {
+		tPrices.AppendRow(table.Row{
+			price.Location,
+			price.Price.Net,
+			price.Price.Gross,
+			price.PriceSetup.Net,
+			price.PriceSetup.Gross,
+		})
+	}
+
+	tPrices.SetCaption("Prices in EUR")
+	tPrices.Render()
+}
+
+func renderServerTransaction(transaction *robot.ServerTransaction) {
+	var keys []string
+	for _, key := range transaction.AuthorizedKey {
+		keys = append(keys, fmt.Sprintf("%s (%s)", key.Key.Name, key.Key.Fingerprint))
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"field", "value"})
+	t.AppendRow(table.Row{"id", transaction.ID})
+	t.AppendRow(table.Row{"date", transaction.Date})
+	t.AppendRow(table.Row{"status", transaction.Status})
+	t.AppendRow(table.Row{"product", fmt.Sprintf("%s (%s)", transaction.Product.Name, transaction.Product.ID)})
+	t.AppendRow(table.Row{"location", transaction.Product.Location})
+	t.AppendRow(table.Row{"distribution", transaction.Product.Dist})
+	t.AppendRow(table.Row{"server number", formatServerNumber(transaction.ServerNumber)})
+	t.AppendRow(table.Row{"server ip", transaction.ServerIP})
+	t.AppendRow(table.Row{"ssh keys", strings.Join(keys, "\n")})
+	t.AppendRow(table.Row{"comment", transaction.Comment})
+
+	t.Render()
+}
@@ -0,0 +1,187 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func getTestServerProducts() []robot.ServerProduct {
+	return []robot.ServerProduct{
+		{
+			ID:       "EX44",
+			Name:     "Dedicated Server EX44",
+			Traffic:  "unlimited",
+			Dist:     []string{"Rescue system", "Debian 12 base"},
+			Arch:     []int{64},
+			Lang:     []string{"en"},
+			Location: []string{"FSN1", "HEL1"},
+			Prices: []robot.LocationPrice{
+				{Location: "FSN1", Price: robot.Price{Net: "44.0000"}, PriceSetup: robot.Price{Net: "39.0000"}},
+				{Location: "HEL1", Price: robot.Price{Net: "44.0000"}, PriceSetup: robot.Price{Net: "39.0000"}},
+			},
+		},
+	}
+}
+
+func (s *AppSuite) TestOrderProductsCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerProductGetList().Times(1).Return(getTestServerProducts(), nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:products", "--location", "fsn1")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestOrderProductsCommandUnknownProduct(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerProductGetList().Times(1).Return(getTestServerProducts(), nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:products", "AX41")
+	c.Assert(err, ErrorMatches, `no product found for "AX41"`)
+}
+
+func (s *AppSuite) TestOrderServerCommandInvalidLocation(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerProductGetList().Times(1).Return(getTestServerProducts(), nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:server", "--product", "ex44", "--location", "NBG1", "--test")
+	c.Assert(err, ErrorMatches, `location "NBG1" not available, use one of: FSN1, HEL1`)
+}
+
+func (s *AppSuite) TestOrderServerCommandUnknownKey(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	keys := []models.Key{
+		{Name: "key1", Fingerprint: "15:28:b0:03:95:f0:77:b3:10:56:15:6b:77:22:a5:bb"},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerProductGetList().Times(1).Return(getTestServerProducts(), nil)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return(keys, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:server", "--product", "EX44", "--location", "FSN1", "--dist", "Rescue system", "--keys", "key2", "--test")
+	c.Assert(err, ErrorMatches, `no ssh key found for "key2"`)
+}
+
+func (s *AppSuite) TestOrderTransactionsCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	transactions := []robot.ServerTransaction{
+		{
+			ID:     "B20150121-344958-251479",
+			Date:   "2015-01-21T12:30:43+01:00",
+			Status: robot.TransactionStatusInProcess,
+			Product: robot.ServerTransactionProduct{
+				ID:       "EX44",
+				Name:     "Dedicated Server EX44",
+				Location: "FSN1",
+			},
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerTransactionGetList().Times(1).Return(transactions, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:transactions")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestOrderTransactionsCommandWaitReady(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	transaction := &robot.ServerTransaction{
+		ID:           "B20150121-344958-251479",
+		Status:       robot.TransactionStatusReady,
+		ServerNumber: 321,
+		ServerIP:     "123.123.123.123",
+	}
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerTransactionGet("B20150121-344958-251479").Times(1).Return(transaction, nil)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:transactions", "B20150121-344958-251479", "--wait")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestOrderTransactionsCommandWaitCancelled(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	transaction := &robot.ServerTransaction{
+		ID:     "B20150121-344958-251479",
+		Status: robot.TransactionStatusCancelled,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().OrderServerTransactionGet("B20150121-344958-251479").Times(1).Return(transaction, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "order:transactions", "B20150121-344958-251479", "--wait")
+	c.Assert(err, ErrorMatches, "order B20150121-344958-251479 was cancelled")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+	outputYAML  = "yaml"
+)
+
+func validateOutput(output string, formats ...string) error {
+	for _, format := range formats {
+		if output == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown output format %q, use one of: %s", output, strings.Join(formats, ", "))
+}
+
+func printJSON(v interface{}) error {
+	return writeJSON(os.Stdout, v)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func printCSV(header []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	return w.WriteAll(rows)
+}
+
+func printYAML(v interface{}) error {
+	return writeYAML(os.Stdout, v)
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+// promptOutput returns the output of interactive prompts, prompts never write
+// to stdout to keep it free for results
+func (app *RobotApp) promptOutput() io.WriteCloser {
+	if w, ok := app.errOut.(io.WriteCloser); ok {
+		return w
+	}
+
+	return nopWriteCloser{app.errOut}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// printMessage prints a highlighted message about the progress to errOut
+func (app *RobotApp) printMessage(format string, a ...interface{}) {
+	color.New(color.FgCyan).Fprintln(app.errOut, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const paidUntilLayout = "2006-01-02"
+
+type overviewServer struct {
+	ServerNumber int    `json:"server_number"`
+	ServerName   string `json:"server_name"`
+	ServerIP     string `json:"server_ip"`
+}
+
+type overviewCancellation struct {
+	overviewServer
+	CancellationDate string `json:"cancellation_date"`
+}
+
+type overviewPaidUntil struct {
+	overviewServer
+	PaidUntil string `json:"paid_until"`
+	Days      int    `json:"days"`
+}
+
+type overviewFailover struct {
+	IP               string `json:"ip"`
+	ServerName       string `json:"server_name"`
+	ActiveServerIP   string `json:"active_server_ip"`
+	ActiveServerName string `json:"active_server_name"`
+	Routed           bool   `json:"routed"`
+}
+
+type overview struct {
+	Servers        int                    `json:"servers"`
+	ByDc           map[string]int         `json:"by_dc"`
+	ByProduct      map[string]int         `json:"by_product"`
+	Cancelled      []overviewCancellation `json:"cancelled"`
+	Rescue         []overviewServer       `json:"rescue"`
+	Failovers      []overviewFailover     `json:"failovers"`
+	IPsWithoutRDns []string               `json:"ips_without_rdns"`
+	UnusedKeys     []models.Key           `json:"unused_keys"`
+	PaidUntil      []overviewPaidUntil    `json:"paid_until"`
+	Errors         []string               `json:"errors,omitempty"`
+}
+
+func (app *RobotApp) NewOverviewCmd() *cobra.Command {
+	var paidUntilDays, concurrency int
+	var skipRescue bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "overview",
+		Short: "Print summary of the account",
+		Long: `Print a summary of the hetzner account: servers per datacenter and product, cancelled servers
+with cancellation date, servers in rescue mode, failover IP routing, IP's without reverse DNS entry,
+unused ssh keys and servers paid until the next --paid-until-days days. The data is fetched
+concurrently, sections whose data could not be fetched are reported as errors and the command exits
+with a non-zero exit code. Lists the webservice reports as not found are empty sections.
+
+Keys are unused if they are neither authorized on an active rescue system nor part of a server order
+of the last 30 days, the webservice does not report keys installed on servers. The rescue status is
+fetched per server, --skip-rescue skips it for large accounts.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputTable, outputJSON); err != nil {
+				return err
+			}
+
+			if concurrency < 1 {
+				return fmt.Errorf("concurrency must be at least 1, got %d", concurrency)
+			}
+
+			ov, err := app.buildOverview(concurrency, skipRescue, paidUntilDays, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if output == outputJSON {
+				if err := printJSON(ov); err != nil {
+					return err
+				}
+			} else {
+				renderOverview(ov, paidUntilDays)
+			}
+
+			if len(ov.Errors) > 0 {
+				return fmt.Errorf("%d sections of the overview could not be fetched", len(ov.Errors))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&paidUntilDays, "paid-until-days", 30, "report servers paid until the next number of days")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 5, "number of requests sent in parallel")
+	cmd.Flags().BoolVar(&skipRescue, "skip-rescue", false, "do not fetch the rescue status of every server")
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format (table, json)")
+
+	return cmd
+}
+
+// buildOverview fetches the lists of the account concurrently, followed by
+// the per server details. Failed requests are recorded in the errors, except
+// for the server list which is required by every section.
+func (app *RobotApp) buildOverview(concurrency int, skipRescue bool, paidUntilDays int, now time.Time) (*overview, error) {
+	var servers []models.Server
+	var serversErr error
+	var ips []models.IP
+	var rdns []models.Rdns
+	var failovers []models.Failover
+	var keys []models.Key
+	var transactions []robot.ServerTransaction
+
+	tasks := []bulkTask{
+		{Item: "servers", Run: func() error { servers, serversErr = app.client.ServerGetList(); return robot.ListError(serversErr) }},
+		{Item: "ips", Run: func() (err error) { ips, err = app.client.IPGetList(); return robot.ListError(err) }},
+		{Item: "rdns", Run: func() (err error) { rdns, err = app.client.RDnsGetList(); return robot.ListError(err) }},
+		{Item: "failovers", Run: func() (err error) { failovers, err = app.client.FailoverGetList(); return robot.ListError(err) }},
+		{Item: "keys", Run: func() (err error) { keys, err = app.client.KeyGetList(); return robot.ListError(err) }},
+		{Item: "server orders", Run: func() (err error) {
+			transactions, err = app.client.OrderServerTransactionGetList()
+			return robot.ListError(err)
+		}},
+	}
+
+	ov := &overview{
+		ByDc:      make(map[string]int),
+		ByProduct: make(map[string]int),
+	}
+
+	results := runBulk(tasks, concurrency)
+	if err := robot.ListError(serversErr); err != nil {
+		return nil, err
+	}
+
+	ov.addErrors(results)
+
+	serversByIP := make(map[string]models.Server)
+	for _, server := range servers {
+		serversByIP[server.ServerIP] = server
+	}
+
+	// details of single servers, every task writes its own slot only
+	var detailTasks []bulkTask
+	cancellations := make([]*models.Cancellation, len(servers))
+	rescues := make([]*models.Rescue, len(servers))
+
+	for i, server := range servers {
+		i, server := i, server
+
+		if server.Cancelled {
+			detailTasks = append(detailTasks, bulkTask{
+				Item: "cancellation of " + server.ServerIP,
+				Run: func() (err error) {
+					cancellations[i], err = app.client.ServerCancellationGet(server.ServerIP)
+					return err
+				},
+			})
+		}
+
+		if !skipRescue {
+			detailTasks = append(detailTasks, bulkTask{
+				Item: "rescue of " + server.ServerIP,
+				Run: func() (err error) {
+					// servers without rescue options are not in rescue mode
+					rescues[i], err = app.client.BootRescueGet(server.ServerIP)
+					if robot.ErrorCode(err) == robot.CodeNotFound {
+						return nil
+					}
+
+					return err
+				},
+			})
+		}
+	}
+
+	ov.addErrors(runBulk(detailTasks, concurrency))
+
+	usedKeys := make(map[string]bool)
+	for _, transaction := range transactions {
+		for _, key := range transaction.AuthorizedKey {
+			usedKeys[key.Key.Fingerprint] = true
+		}
+	}
+
+	ov.Servers = len(servers)
+	for i, server := range servers {
+		ov.ByDc[server.Dc]++
+		ov.ByProduct[server.Product]++
+
+		ovServer := overviewServer{
+			ServerNumber: server.ServerNumber,
+			ServerName:   server.ServerName,
+			ServerIP:     server.ServerIP,
+		}
+
+		if server.Cancelled {
+			cancellation := overviewCancellation{overviewServer: ovServer}
+			if cancellations[i] != nil {
+				cancellation.CancellationDate = cancellations[i].CancellationDate
+			}
+
+			ov.Cancelled = append(ov.Cancelled, cancellation)
+		}
+
+		if rescues[i] != nil && rescues[i].Active {
+			ov.Rescue = append(ov.Rescue, ovServer)
+
+			for _, key := range rescues[i].AuthorizedKey {
+				usedKeys[key.Key.Fingerprint] = true
+			}
+		}
+
+		if paidUntil, err := time.ParseInLocation(paidUntilLayout, server.PaidUntil, now.Location()); err == nil {
+			days := int(paidUntil.Sub(now).Hours() / 24)
+			if days <= paidUntilDays {
+				ov.PaidUntil = append(ov.PaidUntil, overviewPaidUntil{overviewServer: ovServer, PaidUntil: server.PaidUntil, Days: days})
+			}
+		}
+	}
+
+	sort.SliceStable(ov.PaidUntil, func(i, j int) bool {
+		return ov.PaidUntil[i].Days < ov.PaidUntil[j].Days
+	})
+
+	for _, failover := range failovers {
+		ov.Failovers = append(ov.Failovers, overviewFailover{
+			IP:               failover.IP,
+			ServerName:       serversByIP[failover.ServerIP].ServerName,
+			ActiveServerIP:   failover.ActiveServerIP,
+			ActiveServerName: serversByIP[failover.ActiveServerIP].ServerName,
+			Routed:           failover.ActiveServerIP != "" && failover.ActiveServerIP != failover.ServerIP,
+		})
+	}
+
+	withRDns := make(map[string]bool)
+	for _, entry := range rdns {
+		if entry.Ptr != "" {
+			withRDns[entry.IP] = true
+		}
+	}
+
+	for _, ip := range ips {
+		if !withRDns[ip.IP] {
+			ov.IPsWithoutRDns = append(ov.IPsWithoutRDns, ip.IP)
+		}
+	}
+
+	for _, key := range keys {
+		if !usedKeys[key.Fingerprint] {
+			ov.UnusedKeys = append(ov.UnusedKeys, key)
+		}
+	}
+
+	return ov, nil
+}
+
+func (ov *overview) addErrors(results []bulkResult) {
+	for _, result := range results {
+		if result.Status != bulkStatusOK {
+			ov.Errors = append(ov.Errors, fmt.Sprintf("%s: %s", result.Item, result.Error))
+		}
+	}
+}
+
+func renderOverview(ov *overview, paidUntilDays int) {
+	renderOverviewCounts("Servers per datacenter", "datacenter", ov.ByDc, ov.Servers)
+	renderOverviewCounts("Servers per product", "product", ov.ByProduct, ov.Servers)
+
+	color.Cyan("Cancelled servers:")
+	t := newOverviewTable(table.Row{"number", "name", "ip", "cancellation date"})
+	for _, cancellation := range ov.Cancelled {
+		t.AppendRow(table.Row{cancellation.ServerNumber, cancellation.ServerName, cancellation.ServerIP, cancellation.CancellationDate})
+	}
+	renderOverviewTable(t, len(ov.Cancelled))
+
+	color.Cyan("Servers in rescue mode:")
+	t = newOverviewTable(table.Row{"number", "name", "ip"})
+	for _, server := range ov.Rescue {
+		t.AppendRow(table.Row{server.ServerNumber, server.ServerName, server.ServerIP})
+	}
+	renderOverviewTable(t, len(ov.Rescue))
+
+	color.Cyan("Failover IP's:")
+	t = newOverviewTable(table.Row{"ip", "server", "active server ip", "active server", "routed"})
+	for _, failover := range ov.Failovers {
+		t.AppendRow(table.Row{failover.IP, failover.ServerName, failover.ActiveServerIP, failover.ActiveServerName, failover.Routed})
+	}
+	renderOverviewTable(t, len(ov.Failovers))
+
+	color.Cyan("IP's without reverse DNS entry:")
+	t = newOverviewTable(table.Row{"ip"})
+	for _, ip := range ov.IPsWithoutRDns {
+		t.AppendRow(table.Row{ip})
+	}
+	renderOverviewTable(t, len(ov.IPsWithoutRDns))
+
+	color.Cyan("Unused ssh keys:")
+	t = newOverviewTable(table.Row{"name", "fingerprint"})
+	for _, key := range ov.UnusedKeys {
+		t.AppendRow(table.Row{key.Name, key.Fingerprint})
+	}
+	renderOverviewTable(t, len(ov.UnusedKeys))
+
+	color.Cyan(fmt.Sprintf("Servers paid until the next %d days:", paidUntilDays))
+	t = newOverviewTable(table.Row{"number", "name", "ip", "paid until", "days"})
+	for _, paidUntil := range ov.PaidUntil {
+		t.AppendRow(table.Row{paidUntil.ServerNumber, paidUntil.ServerName, paidUntil.ServerIP, paidUntil.PaidUntil, paidUntil.Days})
+	}
+	renderOverviewTable(t, len(ov.PaidUntil))
+
+	for _, err := range ov.Errors {
+		color.Red(fmt.Sprintf("Could not fetch %s", err))
+	}
+}
+
+func renderOverviewCounts(title string, column string, counts map[string]int, total int) {
+	var names []string
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	color.Cyan(title + ":")
+	t := newOverviewTable(table.Row{column, "servers"})
+	for _, name := range names {
+		t.AppendRow(table.Row{name, counts[name]})
+	}
+	t.AppendFooter(table.Row{"Total", total})
+	t.Render()
+}
+
+func newOverviewTable(header table.Row) table.Writer {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(header)
+
+	return t
+}
+
+// renderOverviewTable renders the section, empty sections are printed as a
+// single line to keep the report compact
+func renderOverviewTable(t table.Writer, rows int) {
+	if rows == 0 {
+		fmt.Println("  none")
+		return
+	}
+
+	t.Render()
+}
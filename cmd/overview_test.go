@@ -0,0 +1,156 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestOverviewCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+			Product:      "EX44",
+			Dc:           "FSN1-DC14",
+			PaidUntil:    time.Now().AddDate(0, 0, 10).Format("2006-01-02"),
+		},
+		{
+			ServerIP:     "124.124.124.124",
+			ServerNumber: 322,
+			ServerName:   "app-prod-85",
+			Product:      "EX44",
+			Dc:           "FSN1-DC14",
+			Cancelled:    true,
+			PaidUntil:    "2099-01-01",
+		},
+	}
+
+	ips := []models.IP{
+		{IP: "123.123.123.123", ServerNumber: 321},
+		{IP: "124.124.124.124", ServerNumber: 322},
+	}
+
+	rdns := []models.Rdns{
+		{IP: "123.123.123.123", Ptr: "app-prod-84.example.com"},
+	}
+
+	failovers := []models.Failover{
+		{IP: "125.125.125.125", ServerIP: "123.123.123.123", ActiveServerIP: "124.124.124.124"},
+	}
+
+	keys := []models.Key{
+		{Name: "deploy", Fingerprint: "aa:bb"},
+		{Name: "old", Fingerprint: "cc:dd"},
+	}
+
+	rescue := &models.Rescue{
+		Active:        true,
+		AuthorizedKey: []models.AuthorizedKey{{Key: models.Key{Fingerprint: "aa:bb"}}},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(ips, nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return(rdns, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return(failovers, nil)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return(keys, nil)
+	mockRobotClient.EXPECT().OrderServerTransactionGetList().Times(1).Return([]robot.ServerTransaction{}, nil)
+	mockRobotClient.EXPECT().ServerCancellationGet("124.124.124.124").Times(1).Return(&models.Cancellation{CancellationDate: "2030-01-31"}, nil)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(rescue, nil)
+	mockRobotClient.EXPECT().BootRescueGet("124.124.124.124").Times(1).Return(&models.Rescue{}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "overview")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestOverviewCommandPartialFailure(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return([]models.Server{}, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, errors.New("ips unavailable"))
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return([]models.Rdns{}, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return([]models.Failover{}, nil)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return([]models.Key{}, nil)
+	mockRobotClient.EXPECT().OrderServerTransactionGetList().Times(1).Return([]robot.ServerTransaction{}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "overview", "--skip-rescue", "-o", "json")
+	c.Assert(err, ErrorMatches, "1 sections of the overview could not be fetched")
+}
+
+func (s *AppSuite) TestOverviewCommandServersError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(nil, errors.New("unauthorized"))
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return([]models.IP{}, nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return([]models.Rdns{}, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return([]models.Failover{}, nil)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return([]models.Key{}, nil)
+	mockRobotClient.EXPECT().OrderServerTransactionGetList().Times(1).Return([]robot.ServerTransaction{}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "overview")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestOverviewCommandNotFoundLists(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	notFound := errors.New(`{"error":{"status":404,"code":"NOT_FOUND","message":"Not found"}}`)
+
+	servers := []models.Server{
+		{ServerIP: "123.123.123.123", ServerNumber: 321, ServerName: "app-prod-84"},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return([]models.IP{}, nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().OrderServerTransactionGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(nil, notFound)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "overview", "-o", "json")
+	c.Assert(err, IsNil)
+}
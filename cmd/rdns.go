@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"strings"
 
@@ -9,6 +8,7 @@ import (
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
+	"github.com/nl2go/hrobot-cli/multiselect"
 	"github.com/nl2go/hrobot-go/models"
 )
 
@@ -45,9 +45,9 @@ func (app *RobotApp) NewRdnsGetListCmd() *cobra.Command {
 func (app *RobotApp) NewRdnsGetCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "rdns:get",
-		Short: "Print single reverse DNS entry",
-		Long: `Print details of single reverse DNS entry in hetzner account
-		reverse DNS entry can be chosen interactively`,
+		Short: "Print selected reverse DNS entries",
+		Long: `Print details of reverse DNS entries in hetzner account,
+		reverse DNS entries can be chosen interactively`,
 		Run: func(cmd *cobra.Command, args []string) {
 			rDnsList, err := app.client.RDnsGetList()
 			if err != nil {
@@ -55,37 +55,55 @@ func (app *RobotApp) NewRdnsGetCmd() *cobra.Command {
 				return
 			}
 
-			prompt := promptui.Select{
-				Label:             "Select reverse DNS entry",
-				Items:             rDnsList,
-				Searcher:          getRDnsSearcher(rDnsList),
-				Size:              10,
-				Templates:         getRDnsSelectTemplates(),
-				StartInSearchMode: true,
-			}
-
-			choosenIdx, _, err := prompt.Run()
+			choosenRdns, err := app.selectMultipleRdns(rDnsList)
 			if err != nil {
 				app.logger.Errorln("Prompt failed: ", err)
 				return
 			}
 
-			choosenRdns := rDnsList[choosenIdx]
-			fmt.Println("Chosen reverse DNS entry: ", choosenRdns.IP)
-
 			// directly print info without additional get as that does not deliver more data
 			t := table.NewWriter()
 			t.SetOutputMirror(os.Stdout)
 
-			t.AppendHeader(table.Row{"field", "value"})
-			t.AppendRow(table.Row{"ip", choosenRdns.IP})
-			t.AppendRow(table.Row{"ptr", choosenRdns.Ptr})
+			t.AppendHeader(table.Row{"ip", "ptr"})
+
+			for _, rdns := range choosenRdns {
+				t.AppendRow(table.Row{
+					rdns.IP,
+					rdns.Ptr,
+				})
+			}
 
+			t.AppendFooter(table.Row{"Total", len(choosenRdns)})
 			t.Render()
 		},
 	}
 }
 
+func (app *RobotApp) selectMultipleRdns(rDnsList []models.Rdns) ([]models.Rdns, error) {
+	prompt := multiselect.MultiSelect{
+		Label:             "Choose reverse DNS entries",
+		Items:             rDnsList,
+		Searcher:          getRDnsSearcher(rDnsList),
+		Size:              10,
+		Templates:         getRDnsSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdxs, err := prompt.Run()
+	if err != nil {
+		return []models.Rdns{}, err
+	}
+
+	var chosenRdns []models.Rdns
+	for _, chosenIdx := range chosenIdxs {
+		chosenRdns = append(chosenRdns, rDnsList[chosenIdx])
+	}
+
+	return chosenRdns, nil
+}
+
 func getRDnsSearcher(rDnsList []models.Rdns) func(string, int) bool {
 	return func(input string, index int) bool {
 		rDns := rDnsList[index]
@@ -104,7 +122,7 @@ func getRDnsSelectTemplates() *promptui.SelectTemplates {
 		Inactive: "  {{ .IP | cyan }} ({{ .Ptr | red }})",
 		Selected: "→ {{ .IP | cyan }}",
 		Details: `
-	--------- Selected server ----------
+	--------- Selected reverse DNS entry ----------
 	{{ "IP:" | faint }}	          {{ .IP }}
 	{{ "PTR record:" | faint }}	  {{ .Ptr }}`,
 	}
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	costByGroup   = "group"
+	costByDc      = "dc"
+	costByProduct = "product"
+	costByServer  = "server"
+)
+
+// priceFile holds monthly net prices in EUR, prices of servers are looked up
+// by server number first and by product second
+type priceFile struct {
+	Products map[string]float64 `yaml:"products"`
+	Servers  map[int]float64    `yaml:"servers"`
+	IP       *float64           `yaml:"ip"`
+	Subnets  map[int]float64    `yaml:"subnets"`
+}
+
+type costRow struct {
+	Key        string  `json:"key"`
+	Servers    int     `json:"servers"`
+	IPs        int     `json:"ips"`
+	Subnets    int     `json:"subnets"`
+	ServerCost float64 `json:"server_cost"`
+	IPCost     float64 `json:"ip_cost"`
+	SubnetCost float64 `json:"subnet_cost"`
+	Total      float64 `json:"total"`
+	Unpriced   int     `json:"unpriced"`
+}
+
+type costReport struct {
+	By       string    `json:"by"`
+	Rows     []costRow `json:"rows"`
+	Total    costRow   `json:"total"`
+	Unpriced []string  `json:"unpriced,omitempty"`
+}
+
+// costPricer looks up prices in the price file and falls back to the
+// ordering API, addon prices are fetched once per location
+type costPricer struct {
+	app      *RobotApp
+	file     *priceFile
+	useAPI   bool
+	products []robot.ServerProduct
+	addons   map[string][]robot.AddonProduct
+}
+
+func (app *RobotApp) NewReportCostCmd() *cobra.Command {
+	var by, pricesPath, output string
+	var noAPI bool
+
+	cmd := &cobra.Command{
+		Use:   "report:cost",
+		Short: "Print monthly cost of servers, IP's and subnets",
+		Long: `Print the monthly cost (net, EUR) of servers, additional IP's and subnets grouped by host group
+(first token of the server name like server:ansible-inv), datacenter, product or server. Prices are
+taken from the price file given by --prices and the ordering API, servers from the server auction
+are only priced by the price file. IPv6 subnets are included with the servers and not charged.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputTable, outputJSON, outputCSV); err != nil {
+				return err
+			}
+
+			switch by {
+			case costByGroup, costByDc, costByProduct, costByServer:
+			default:
+				return fmt.Errorf("unknown grouping %q, use one of: %s", by, strings.Join([]string{costByGroup, costByDc, costByProduct, costByServer}, ", "))
+			}
+
+			pricer := &costPricer{
+				app:    app,
+				file:   &priceFile{},
+				useAPI: !noAPI,
+				addons: make(map[string][]robot.AddonProduct),
+			}
+
+			if pricesPath != "" {
+				file, err := loadPriceFile(pricesPath)
+				if err != nil {
+					return err
+				}
+
+				pricer.file = file
+			}
+
+			servers, err := app.client.ServerGetList()
+			if err != nil {
+				return err
+			}
+
+			ips, err := app.client.IPGetList()
+			if err = robot.ListError(err); err != nil {
+				return err
+			}
+
+			subnets, err := app.client.SubnetGetList()
+			if err = robot.ListError(err); err != nil {
+				return err
+			}
+
+			if pricer.useAPI {
+				products, err := app.client.OrderServerProductGetList()
+				if err != nil {
+					app.logger.Warnf("Could not fetch product prices, only the price file is used: %s", err)
+				}
+
+				pricer.products = products
+			}
+
+			report := buildCostReport(servers, ips, subnets, pricer, by)
+
+			for _, item := range report.Unpriced {
+				app.logger.Warnf("No price found for %s", item)
+			}
+
+			return printCostReport(report, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", costByGroup, "group cost by group, dc, product or server")
+	cmd.Flags().StringVar(&pricesPath, "prices", "", "YAML file with monthly prices of products, servers, IP's and subnets")
+	cmd.Flags().BoolVar(&noAPI, "no-api-prices", false, "only use prices of the price file")
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format (table, json, csv)")
+
+	return cmd
+}
+
+func buildCostReport(servers []models.Server, ips []models.IP, subnets []robot.Subnet, pricer *costPricer, by string) *costReport {
+	report := &costReport{By: by}
+	rows := make(map[string]*costRow)
+
+	serversByNumber := make(map[int]models.Server)
+	for _, server := range servers {
+		serversByNumber[server.ServerNumber] = server
+	}
+
+	rowOf := func(server models.Server) *costRow {
+		key := costKey(server, by)
+		if rows[key] == nil {
+			rows[key] = &costRow{Key: key}
+		}
+
+		return rows[key]
+	}
+
+	for _, server := range servers {
+		row := rowOf(server)
+		row.Servers++
+
+		if price, ok := pricer.serverPrice(server); ok {
+			row.ServerCost += price
+		} else {
+			row.Unpriced++
+			report.Unpriced = append(report.Unpriced, fmt.Sprintf("server %s (%s)", server.ServerName, server.Product))
+		}
+	}
+
+	for _, ip := range ips {
+		server, ok := serversByNumber[ip.ServerNumber]
+		if !ok || ip.IP == server.ServerIP {
+			continue
+		}
+
+		row := rowOf(server)
+		row.IPs++
+
+		if price, ok := pricer.ipPrice(server); ok {
+			row.IPCost += price
+		} else {
+			row.Unpriced++
+			report.Unpriced = append(report.Unpriced, fmt.Sprintf("IP %s of %s", ip.IP, server.ServerName))
+		}
+	}
+
+	for _, subnet := range subnets {
+		server, ok := serversByNumber[subnet.ServerNumber]
+		if !ok {
+			continue
+		}
+
+		row := rowOf(server)
+		row.Subnets++
+
+		if strings.Contains(subnet.IP, ":") {
+			continue
+		}
+
+		if price, ok := pricer.subnetPrice(server, subnet.Mask); ok {
+			row.SubnetCost += price
+		} else {
+			row.Unpriced++
+			report.Unpriced = append(report.Unpriced, fmt.Sprintf("subnet %s/%d of %s", subnet.IP, subnet.Mask, server.ServerName))
+		}
+	}
+
+	report.Total.Key = "Total"
+	for _, row := range rows {
+		row.Total = row.ServerCost + row.IPCost + row.SubnetCost
+		report.Rows = append(report.Rows, *row)
+
+		report.Total.Servers += row.Servers
+		report.Total.IPs += row.IPs
+		report.Total.Subnets += row.Subnets
+		report.Total.ServerCost += row.ServerCost
+		report.Total.IPCost += row.IPCost
+		report.Total.SubnetCost += row.SubnetCost
+		report.Total.Total += row.Total
+		report.Total.Unpriced += row.Unpriced
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+
+	return report
+}
+
+func costKey(server models.Server, by string) string {
+	switch by {
+	case costByDc:
+		return server.Dc
+	case costByProduct:
+		return server.Product
+	case costByServer:
+		return server.ServerName
+	}
+
+	return hostGroup(server.ServerName)
+}
+
+func (p *costPricer) serverPrice(server models.Server) (float64, bool) {
+	if price, ok := p.file.Servers[server.ServerNumber]; ok {
+		return price, true
+	}
+
+	if price, ok := p.file.Products[server.Product]; ok {
+		return price, true
+	}
+
+	for _, product := range p.products {
+		if !strings.EqualFold(product.ID, server.Product) && !strings.EqualFold(product.Name, server.Product) {
+			continue
+		}
+
+		for _, price := range product.Prices {
+			if strings.EqualFold(price.Location, dcLocation(server.Dc)) {
+				return parsePrice(price.Price.Net)
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func (p *costPricer) ipPrice(server models.Server) (float64, bool) {
+	if p.file.IP != nil {
+		return *p.file.IP, true
+	}
+
+	for _, addon := range p.addonProducts(server) {
+		if addon.Type == "ip_ipv4" {
+			return parsePrice(addon.Price.Price.Net)
+		}
+	}
+
+	return 0, false
+}
+
+func (p *costPricer) subnetPrice(server models.Server, mask int) (float64, bool) {
+	if price, ok := p.file.Subnets[mask]; ok {
+		return price, true
+	}
+
+	suffix := "_" + strconv.Itoa(mask)
+	for _, addon := range p.addonProducts(server) {
+		if addon.Type == "subnet_ipv4" && strings.HasSuffix(addon.ID, suffix) {
+			return parsePrice(addon.Price.Price.Net)
+		}
+	}
+
+	return 0, false
+}
+
+// addonProducts returns the addons orderable in the location of the server,
+// failed lookups are not repeated for the location
+func (p *costPricer) addonProducts(server models.Server) []robot.AddonProduct {
+	if !p.useAPI {
+		return nil
+	}
+
+	location := dcLocation(server.Dc)
+	if addons, ok := p.addons[location]; ok {
+		return addons
+	}
+
+	addons, err := p.app.client.AddonProductGetList(server.ServerNumber)
+	if err != nil {
+		p.app.logger.Warnf("Could not fetch addon prices for %s: %s", location, err)
+	}
+
+	p.addons[location] = addons
+	return addons
+}
+
+func parsePrice(price string) (float64, bool) {
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+func loadPriceFile(path string) (*priceFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file priceFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid price file %s: %s", path, err)
+	}
+
+	return &file, nil
+}
+
+func printCostReport(report *costReport, output string) error {
+	switch output {
+	case outputJSON:
+		return printJSON(report)
+	case outputCSV:
+		var rows [][]string
+		for _, row := range append(report.Rows, report.Total) {
+			rows = append(rows, []string{
+				row.Key,
+				strconv.Itoa(row.Servers),
+				strconv.Itoa(row.IPs),
+				strconv.Itoa(row.Subnets),
+				fmt.Sprintf("%.2f", row.ServerCost),
+				fmt.Sprintf("%.2f", row.IPCost),
+				fmt.Sprintf("%.2f", row.SubnetCost),
+				fmt.Sprintf("%.2f", row.Total),
+				strconv.Itoa(row.Unpriced),
+			})
+		}
+
+		return printCSV([]string{report.By, "servers", "ips", "subnets", "server_cost", "ip_cost", "subnet_cost", "total", "unpriced"}, rows)
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{report.By, "servers", "ips", "subnets", "server cost", "ip cost", "subnet cost", "total", "unpriced"})
+
+	for _, row := range report.Rows {
+		t.AppendRow(table.Row{
+			row.Key,
+			row.Servers,
+			row.IPs,
+			row.Subnets,
+			fmt.Sprintf("%.2f", row.ServerCost),
+			fmt.Sprintf("%.2f", row.IPCost),
+			fmt.Sprintf("%.2f", row.SubnetCost),
+			fmt.Sprintf("%.2f", row.Total),
+			row.Unpriced,
+		})
+	}
+
+	total := report.Total
+	t.AppendFooter(table.Row{
+		total.Key,
+		total.Servers,
+		total.IPs,
+		total.Subnets,
+		fmt.Sprintf("%.2f", total.ServerCost),
+		fmt.Sprintf("%.2f", total.IPCost),
+		fmt.Sprintf("%.2f", total.SubnetCost),
+		fmt.Sprintf("%.2f", total.Total),
+		total.Unpriced,
+	})
+	t.SetCaption("Monthly cost in EUR (net)")
+	t.Render()
+
+	return nil
+}
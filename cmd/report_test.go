@@ -0,0 +1,191 @@
+package cmd_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const testPriceFile = `products:
+  EX44: 44.00
+servers:
+  322: 39.00
+ip: 1.70
+subnets:
+  29: 6.72
+`
+
+func getTestCostResources() ([]models.Server, []models.IP, []robot.Subnet) {
+	servers := []models.Server{
+		{ServerIP: "123.123.123.123", ServerNumber: 321, ServerName: "mongodb-prod-1", Product: "EX44", Dc: "FSN1-DC14"},
+		{ServerIP: "123.123.123.124", ServerNumber: 322, ServerName: "mongodb-prod-2", Product: "SB110", Dc: "FSN1-DC14"},
+		{ServerIP: "123.123.123.125", ServerNumber: 323, ServerName: "web-prod-1", Product: "AX41", Dc: "HEL1-DC2"},
+	}
+
+	ips := []models.IP{
+		{IP: "123.123.123.123", ServerNumber: 321},
+		{IP: "124.124.124.124", ServerNumber: 321},
+		{IP: "123.123.123.125", ServerNumber: 323},
+	}
+
+	subnets := []robot.Subnet{
+		{IP: "2a01:4f8:111:4221::", Mask: 64, ServerNumber: 321},
+		{IP: "10.0.0.0", Mask: 29, ServerNumber: 323},
+	}
+
+	return servers, ips, subnets
+}
+
+func writeTestPriceFile(c *C) string {
+	path := filepath.Join(c.MkDir(), "prices.yaml")
+	c.Assert(ioutil.WriteFile(path, []byte(testPriceFile), 0600), IsNil)
+
+	return path
+}
+
+func (s *AppSuite) TestBuildCostReportByGroup(c *C) {
+	servers, ips, subnets := getTestCostResources()
+
+	rows, total, err := cmd.BuildCostReport(servers, ips, subnets, writeTestPriceFile(c), "group")
+	c.Assert(err, IsNil)
+	c.Assert(rows, DeepEquals, []cmd.CostRow{
+		{Key: "mongodb", Servers: 2, IPs: 1, Subnets: 1, ServerCost: 83, IPCost: 1.7, Total: 84.7},
+		{Key: "web", Servers: 1, Subnets: 1, SubnetCost: 6.72, Total: 6.72, Unpriced: 1},
+	})
+	c.Assert(total.Servers, Equals, 3)
+	c.Assert(total.Unpriced, Equals, 1)
+	c.Assert(total.Total, Equals, 84.7+6.72)
+}
+
+func (s *AppSuite) TestBuildCostReportByDc(c *C) {
+	servers, ips, subnets := getTestCostResources()
+
+	rows, _, err := cmd.BuildCostReport(servers, ips, subnets, writeTestPriceFile(c), "dc")
+	c.Assert(err, IsNil)
+	c.Assert(len(rows), Equals, 2)
+	c.Assert(rows[0].Key, Equals, "FSN1-DC14")
+	c.Assert(rows[1].Key, Equals, "HEL1-DC2")
+}
+
+func (s *AppSuite) TestReportCostCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers, ips, subnets := getTestCostResources()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(ips, nil)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(subnets, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "report:cost", "--prices", writeTestPriceFile(c), "--no-api-prices", "-o", "csv")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestReportCostCommandEmptyLists(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers, _, _ := getTestCostResources()
+	notFound := errors.New(`{"error":{"status":404,"code":"NOT_FOUND","message":"Not found"}}`)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, notFound)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(nil, notFound)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "report:cost", "--prices", writeTestPriceFile(c), "--no-api-prices", "-o", "csv")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestReportCostCommandListError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers, _, _ := getTestCostResources()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "report:cost", "--prices", writeTestPriceFile(c), "--no-api-prices")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestReportCostCommandApiPrices(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers, ips, subnets := getTestCostResources()
+
+	products := []robot.ServerProduct{
+		{
+			ID:     "EX44",
+			Prices: []robot.LocationPrice{{Location: "FSN1", Price: robot.Price{Net: "44.0000"}}},
+		},
+	}
+
+	addons := []robot.AddonProduct{
+		{ID: "additional_ipv4", Type: "ip_ipv4", Price: robot.LocationPrice{Price: robot.Price{Net: "1.7000"}}},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(ips, nil)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(subnets, nil)
+	mockRobotClient.EXPECT().OrderServerProductGetList().Times(1).Return(products, nil)
+	mockRobotClient.EXPECT().AddonProductGetList(321).Times(1).Return(addons, nil)
+	mockRobotClient.EXPECT().AddonProductGetList(323).Times(1).Return(addons, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "report:cost", "--by", "product", "-o", "json")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestReportCostCommandInvalidGrouping(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "report:cost", "--by", "team")
+	c.Assert(err, ErrorMatches, `unknown grouping "team", .*`)
+}
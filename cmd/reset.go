@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// reset types of the webservice and their description
+var resetTypes = map[string]string{
+	"sw":                     "software reset",
+	models.ResetTypeHardware: "hardware reset",
+	models.ResetTypePower:    "power button",
+	"power_long":             "long power button press",
+	models.ResetTypeManual:   "manual reset by technician",
+}
+
+func (app *RobotApp) NewServerResetStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "server:reset:status [server]",
+		Short: "Print reset options and status of single server",
+		Long: `Print supported reset types and operating status of single server in hetzner account, server can
+be given by name, number or IP as argument or chosen interactively. The webservice does not report
+the progress of manual resets, the last resets recorded in the local reset history are shown instead.`,
+		Annotations: argCompletion(completeServers),
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			server, err := app.serverArg(args)
+			if err != nil {
+				return err
+			}
+
+			reset, err := app.client.ResetGet(server.ServerIP)
+			if err != nil {
+				return err
+			}
+
+			var supported []string
+			for _, resetType := range reset.Type {
+				if description, ok := resetTypes[resetType]; ok {
+					supported = append(supported, fmt.Sprintf("%s (%s)", resetType, description))
+				} else {
+					supported = append(supported, resetType)
+				}
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"server ip", reset.ServerIP})
+			t.AppendRow(table.Row{"server number", reset.ServerNumber})
+			t.AppendRow(table.Row{"server name", server.ServerName})
+			t.AppendRow(table.Row{"operating status", reset.OperatingStatus})
+			t.AppendRow(table.Row{"reset types", strings.Join(supported, "\n")})
+
+			if app.history != nil {
+				resets, err := app.history.Resets()
+				if err != nil {
+					return err
+				}
+
+				var last, lastManual *history.Reset
+				for i := range resets {
+					if resets[i].ServerIP != server.ServerIP {
+						continue
+					}
+
+					last = &resets[i]
+					if resets[i].Type == models.ResetTypeManual {
+						lastManual = &resets[i]
+					}
+				}
+
+				t.AppendRow(table.Row{"last reset", formatHistoryReset(last, time.Now())})
+				t.AppendRow(table.Row{"last manual reset", formatHistoryReset(lastManual, time.Now())})
+			}
+
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewServerResetHistoryCmd() *cobra.Command {
+	var sel serverSelector
+	var limit int
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "server:reset:history",
+		Short: "Print resets issued with hrobot-cli",
+		Long: `Print the resets issued with hrobot-cli from the local reset history of the current profile,
+newest first. Without flags the resets of all servers are printed, servers can be selected by flags.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputTable, outputJSON); err != nil {
+				return err
+			}
+
+			if limit < 0 {
+				return fmt.Errorf("limit must not be negative, got %d", limit)
+			}
+
+			if app.history == nil {
+				return errors.New("reset history is not enabled")
+			}
+
+			resets, err := app.history.Resets()
+			if err != nil {
+				return err
+			}
+
+			if sel.isSet() {
+				chosenServers, err := app.selectServers(&sel)
+				if err != nil {
+					return err
+				}
+
+				chosen := make(map[string]bool)
+				for _, server := range chosenServers {
+					chosen[server.ServerIP] = true
+				}
+
+				var filtered []history.Reset
+				for _, reset := range resets {
+					if chosen[reset.ServerIP] {
+						filtered = append(filtered, reset)
+					}
+				}
+
+				resets = filtered
+			}
+
+			// newest first, the history is stored oldest first
+			sort.SliceStable(resets, func(i, j int) bool {
+				return resets[i].Time.After(resets[j].Time)
+			})
+
+			if limit > 0 && len(resets) > limit {
+				resets = resets[:limit]
+			}
+
+			if output == outputJSON {
+				return printJSON(resets)
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"time", "server ip", "server number", "server name", "type", "user"})
+
+			for _, reset := range resets {
+				t.AppendRow(table.Row{
+					reset.Time.Local().Format("2006-01-02 15:04:05"),
+					reset.ServerIP,
+					reset.ServerNumber,
+					reset.ServerName,
+					reset.Type,
+					reset.User,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "", "Total", len(resets)})
+			t.Render()
+
+			return nil
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+	cmd.Flags().IntVar(&limit, "limit", 20, "maximum number of resets to print, 0 prints all")
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format (table, json)")
+
+	return cmd
+}
+
+// recordReset adds an issued reset to the local history, failures are only
+// logged as the reset itself succeeded
+func (app *RobotApp) recordReset(server models.Server, resetType string) {
+	if app.history == nil {
+		return
+	}
+
+	reset := history.Reset{
+		Time:         time.Now(),
+		ServerIP:     server.ServerIP,
+		ServerNumber: server.ServerNumber,
+		ServerName:   server.ServerName,
+		Type:         resetType,
+		User:         history.CurrentUser(),
+	}
+
+	if err := app.history.AddReset(reset); err != nil {
+		app.logger.Warnf("Could not record reset of %s in history: %s", server.ServerIP, err)
+	}
+}
+
+func formatHistoryReset(reset *history.Reset, now time.Time) string {
+	if reset == nil {
+		return "none recorded"
+	}
+
+	ago := now.Sub(reset.Time).Truncate(time.Minute)
+
+	return fmt.Sprintf("%s at %s (%s ago) by %s", reset.Type, reset.Time.Local().Format("2006-01-02 15:04"), ago, reset.User)
+}
+
+func resetTypeNames() []string {
+	var names []string
+	for name := range resetTypes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,113 @@
+package cmd_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestServerResetStatusCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	reset := &models.Reset{
+		ServerIP:        "123.123.123.123",
+		ServerNumber:    321,
+		OperatingStatus: "not supported",
+		Type:            []string{"sw", "hw", "man"},
+	}
+
+	store := history.NewStore(c.MkDir())
+	c.Assert(store.AddReset(history.Reset{Time: time.Now(), ServerIP: "123.123.123.123", Type: models.ResetTypeManual, User: "alice"}), IsNil)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().ResetGet("123.123.123.123").Times(1).Return(reset, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetHistory(store)
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset:status", "321")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestServerResetHistoryCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	store := history.NewStore(c.MkDir())
+	c.Assert(store.AddReset(history.Reset{Time: time.Now(), ServerIP: "123.123.123.123", Type: models.ResetTypeHardware, User: "alice"}), IsNil)
+	c.Assert(store.AddReset(history.Reset{Time: time.Now(), ServerIP: "124.124.124.124", Type: models.ResetTypeManual, User: "bob"}), IsNil)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetHistory(store)
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset:history", "--servers", "app-prod-84", "-o", "json")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestServerResetHistoryCommandNotEnabled(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset:history")
+	c.Assert(err, ErrorMatches, "reset history is not enabled")
+}
+
+func (s *AppSuite) TestServerResetCommandUnknownType(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset", "--servers", "app-prod-84", "--type", "reboot")
+	c.Assert(err, ErrorMatches, `unknown reset type "reboot", use one of: hw, man, power, power_long, sw`)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	waitPortInterval    = 5 * time.Second
+	waitPortDialTimeout = 5 * time.Second
+	// a server might come back faster than it is polled, so after this time a
+	// reachable port counts even if the server was never seen down
+	waitPortDownGrace = 2 * time.Minute
+)
+
+// rollingOptions control the execution of an action in batches of servers
+type rollingOptions struct {
+	batchSize   int
+	pause       time.Duration
+	waitPort    int
+	waitTimeout time.Duration
+}
+
+func addRollingFlags(cmd *cobra.Command, opts *rollingOptions) {
+	cmd.Flags().IntVar(&opts.batchSize, "batch-size", 0, "number of servers per batch, 0 processes all servers in a single batch")
+	cmd.Flags().DurationVar(&opts.pause, "pause", 0, "pause between batches, i.e. 30s or 5m")
+	cmd.Flags().IntVar(&opts.waitPort, "wait-port", 0, "wait until this TCP port is reachable again on every server of a batch")
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", 10*time.Minute, "maximum time to wait for the port of a server")
+}
+
+func validateRollingOptions(opts *rollingOptions) error {
+	if opts.batchSize < 0 {
+		return fmt.Errorf("batch size must not be negative, got %d", opts.batchSize)
+	}
+
+	if opts.waitPort < 0 || opts.waitPort > 65535 {
+		return fmt.Errorf("invalid port %d", opts.waitPort)
+	}
+
+	return nil
+}
+
+// executeRolling applies the action to the servers batch by batch. Following
+// batches are skipped once the action failed for a server of a batch. The
+// outcome is sent to the notification webhook of the profile.
+func (app *RobotApp) executeRolling(command string, servers []models.Server, action string, run func(server models.Server) error, rolling *rollingOptions, bulk *bulkOptions) error {
+	tasks := make([]bulkTask, len(servers))
+	for i, server := range servers {
+		server := server
+
+		tasks[i] = bulkTask{
+			Item:   server.ServerIP,
+			Action: action,
+			Run: func() error {
+				if err := run(server); err != nil {
+					return err
+				}
+
+				if rolling.waitPort > 0 {
+					app.logger.Infof("Waiting for port %d on %s ...", rolling.waitPort, server.ServerIP)
+					return waitForPort(server.ServerIP, rolling.waitPort, rolling.waitTimeout)
+				}
+
+				return nil
+			},
+		}
+	}
+
+	batchSize := rolling.batchSize
+	if batchSize == 0 {
+		batchSize = len(tasks)
+	}
+
+	batchCount := (len(tasks) + batchSize - 1) / batchSize
+
+	var results []bulkResult
+	for batch, start := 1, 0; start < len(tasks); batch, start = batch+1, start+batchSize {
+		end := start + batchSize
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+
+		if start > 0 {
+			if report := newBulkReport(results); report.Failed > 0 {
+				app.logger.Errorln("Stopping, action failed for servers of the previous batch.")
+
+				for _, task := range tasks[start:] {
+					results = append(results, bulkResult{
+						Item:   task.Item,
+						Action: task.Action,
+						Status: bulkStatusSkipped,
+						Error:  "previous batch failed",
+					})
+				}
+
+				break
+			}
+
+			if rolling.pause > 0 {
+				app.logger.Infof("Pausing for %s before next batch ...", rolling.pause)
+				time.Sleep(rolling.pause)
+			}
+		}
+
+		if batchCount > 1 {
+			app.logger.Infof("Processing batch %d of %d ...", batch, batchCount)
+		}
+
+		results = append(results, runBulk(tasks[start:end], bulk.concurrency)...)
+	}
+
+	app.notifyAction(command, action, bulkTargets(servers, results))
+
+	return app.reportBulk(results, bulk)
+}
+
+// waitForPort waits until the server went down and the port is reachable again
+func waitForPort(ip string, port int, timeout time.Duration) error {
+	address := net.JoinHostPort(ip, strconv.Itoa(port))
+	start := time.Now()
+	seenDown := false
+
+	for time.Since(start) < timeout {
+		conn, err := net.DialTimeout("tcp", address, waitPortDialTimeout)
+		if err != nil {
+			seenDown = true
+		} else {
+			conn.Close()
+
+			if seenDown || time.Since(start) > waitPortDownGrace {
+				return nil
+			}
+		}
+
+		time.Sleep(waitPortInterval)
+	}
+
+	return fmt.Errorf("port %d not reachable after %s", port, timeout)
+}
+
+// waitForPortReachable waits until the port is reachable, servers being
+// started are not expected to go down first
+func waitForPortReachable(ip string, port int, timeout time.Duration) error {
+	address := net.JoinHostPort(ip, strconv.Itoa(port))
+	start := time.Now()
+
+	for time.Since(start) < timeout {
+		conn, err := net.DialTimeout("tcp", address, waitPortDialTimeout)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+
+		time.Sleep(waitPortInterval)
+	}
+
+	return fmt.Errorf("port %d not reachable after %s", port, timeout)
+}
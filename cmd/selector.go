@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// serverSelector chooses servers from flags instead of the interactive selection
+type serverSelector struct {
+	servers []string
+	filter  string
+}
+
+// selectorFlagAliases are the singular names of the selector flags, e.g.
+// --server for a single server
+var selectorFlagAliases = map[string]string{
+	"server": "servers",
+	"ip":     "ips",
+}
+
+// normalizeSelectorFlags resolves the aliases of the selector flags, set as
+// global normalization function of the root command
+func normalizeSelectorFlags(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	if alias, ok := selectorFlagAliases[name]; ok {
+		return pflag.NormalizedName(alias)
+	}
+
+	return pflag.NormalizedName(name)
+}
+
+func addServerSelectorFlags(cmd *cobra.Command, sel *serverSelector) {
+	cmd.Flags().StringSliceVar(&sel.servers, "servers", nil, "comma separated server names, numbers or IP's")
+	cmd.Flags().StringVar(&sel.filter, "filter", "", "select all servers with name, IP, product or datacenter containing the filter")
+	markFlagCompletion(cmd, "servers", completeServers)
+}
+
+func (sel *serverSelector) isSet() bool {
+	return len(sel.servers) > 0 || sel.filter != ""
+}
+
+// selectServers resolves the servers given by flags, the servers are chosen
+// interactively if no flag is set
+func (app *RobotApp) selectServers(sel *serverSelector) ([]models.Server, error) {
+	if !sel.isSet() {
+		chosenServers, err := app.selectMultipleServers()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(chosenServers) == 0 {
+			return nil, errors.New("no servers selected")
+		}
+
+		return chosenServers, nil
+	}
+
+	servers, err := app.client.ServerGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	var chosenServers []models.Server
+	chosen := make(map[int]bool)
+
+	for _, ref := range sel.servers {
+		idx := findServer(servers, ref)
+		if idx < 0 {
+			return nil, fmt.Errorf("no server found for %q", ref)
+		}
+
+		if !chosen[idx] {
+			chosen[idx] = true
+			chosenServers = append(chosenServers, servers[idx])
+		}
+	}
+
+	if sel.filter != "" {
+		searcher := getServerSearcher(servers)
+		for idx := range servers {
+			if searcher(sel.filter, idx) && !chosen[idx] {
+				chosen[idx] = true
+				chosenServers = append(chosenServers, servers[idx])
+			}
+		}
+	}
+
+	if len(chosenServers) == 0 {
+		return nil, fmt.Errorf("no server matches filter %q", sel.filter)
+	}
+
+	return chosenServers, nil
+}
+
+// serverArg returns the server given by name, number or IP as argument, the
+// server is chosen interactively if there is no argument
+func (app *RobotApp) serverArg(args []string) (*models.Server, error) {
+	if len(args) == 0 {
+		return app.selectServer()
+	}
+
+	servers, err := app.client.ServerGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := findServer(servers, args[0])
+	if idx < 0 {
+		return nil, fmt.Errorf("no server found for %q", args[0])
+	}
+
+	return &servers[idx], nil
+}
+
+// findServer returns the index of the server with the given name, number or IP
+func findServer(servers []models.Server, ref string) int {
+	ref = strings.TrimSpace(ref)
+	number, numErr := strconv.Atoi(ref)
+
+	for idx, server := range servers {
+		if server.ServerName == ref || server.ServerIP == ref || (numErr == nil && server.ServerNumber == number) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// confirmServers lists the servers and asks for a single confirmation for all of them
+func (app *RobotApp) confirmServers(servers []models.Server, action string) error {
+	app.printMessage("Servers selected to %s:", action)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(app.errOut)
+
+	t.AppendHeader(table.Row{"id", "ip", "name", "datacenter"})
+
+	for _, server := range servers {
+		t.AppendRow(table.Row{
+			server.ServerNumber,
+			server.ServerIP,
+			server.ServerName,
+			server.Dc,
+		})
+	}
+
+	t.AppendFooter(table.Row{"", "", "Total", len(servers)})
+	t.Render()
+
+	confirmPrompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Really %s %d servers", action, len(servers)),
+		IsConfirm: true,
+		Stdout:    app.promptOutput(),
+	}
+
+	_, err := confirmPrompt.Run()
+	return err
+}
+
+// ipSelector chooses IP's from flags instead of the interactive selection,
+// IP's can be given directly or taken from the selected servers
+type ipSelector struct {
+	ips     []string
+	servers serverSelector
+}
+
+func addIPSelectorFlags(cmd *cobra.Command, sel *ipSelector) {
+	cmd.Flags().StringSliceVar(&sel.ips, "ips", nil, "comma separated IP's")
+	markFlagCompletion(cmd, "ips", completeIPs)
+	addServerSelectorFlags(cmd, &sel.servers)
+}
+
+func (sel *ipSelector) isSet() bool {
+	return len(sel.ips) > 0 || sel.servers.isSet()
+}
+
+// selectIPs resolves the IP's given by flags including all IP's of the servers
+// selected by flags, the IP's are chosen interactively if no flag is set
+func (app *RobotApp) selectIPs(sel *ipSelector, ipList []models.IP) ([]models.IP, error) {
+	if !sel.isSet() {
+		chosenIPs, err := app.selectMultipleIPs(ipList)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(chosenIPs) == 0 {
+			return nil, errors.New("no IP's selected")
+		}
+
+		return chosenIPs, nil
+	}
+
+	var chosenIPs []models.IP
+	chosen := make(map[int]bool)
+
+	for _, ref := range sel.ips {
+		ref = strings.TrimSpace(ref)
+
+		idx := -1
+		for i, ip := range ipList {
+			if ip.IP == ref {
+				idx = i
+				break
+			}
+		}
+
+		if idx < 0 {
+			return nil, fmt.Errorf("no IP found for %q", ref)
+		}
+
+		if !chosen[idx] {
+			chosen[idx] = true
+			chosenIPs = append(chosenIPs, ipList[idx])
+		}
+	}
+
+	if sel.servers.isSet() {
+		chosenServers, err := app.selectServers(&sel.servers)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, server := range chosenServers {
+			for idx, ip := range ipList {
+				if ip.ServerNumber == server.ServerNumber && !chosen[idx] {
+					chosen[idx] = true
+					chosenIPs = append(chosenIPs, ip)
+				}
+			}
+		}
+	}
+
+	if len(chosenIPs) == 0 {
+		return nil, errors.New("selected servers have no IP's")
+	}
+
+	return chosenIPs, nil
+}
+
+// confirmIPs lists the IP's and asks for a single confirmation for all of them
+func (app *RobotApp) confirmIPs(ips []models.IP, action string) error {
+	app.printMessage("IP's selected to %s:", action)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(app.errOut)
+
+	t.AppendHeader(table.Row{"ip", "server ip", "server number"})
+
+	for _, ip := range ips {
+		t.AppendRow(table.Row{
+			ip.IP,
+			ip.ServerIP,
+			ip.ServerNumber,
+		})
+	}
+
+	t.AppendFooter(table.Row{"", "Total", len(ips)})
+	t.Render()
+
+	confirmPrompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Really %s %d IP's", action, len(ips)),
+		IsConfirm: true,
+		Stdout:    app.promptOutput(),
+	}
+
+	_, err := confirmPrompt.Run()
+	return err
+}
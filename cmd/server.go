@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
+	"github.com/nl2go/hrobot-cli/multiselect"
+	"github.com/nl2go/hrobot-cli/notify"
+	"github.com/nl2go/hrobot-cli/robot"
 	"github.com/nl2go/hrobot-go/models"
 )
 
@@ -75,7 +80,13 @@ func (app *RobotApp) NewServerGetCmd() *cobra.Command {
 			t.AppendRow(table.Row{"data center", server.Dc})
 			t.AppendRow(table.Row{"product", server.Product})
 			t.AppendRow(table.Row{"status", server.Status})
-			t.AppendRow(table.Row{"subnet", "IP: " + server.Subnet[0].IP + " Mask: " + server.Subnet[0].Mask})
+			t.AppendRow(table.Row{"ips", strings.Join(server.IP, "\n")})
+
+			var subnets []string
+			for _, subnet := range server.Subnet {
+				subnets = append(subnets, "IP: "+subnet.IP+" Mask: "+subnet.Mask)
+			}
+			t.AppendRow(table.Row{"subnets", strings.Join(subnets, "\n")})
 			t.AppendRow(table.Row{"traffic", server.Traffic})
 			t.AppendRow(table.Row{"paid until", server.PaidUntil})
 
@@ -99,6 +110,7 @@ func (app *RobotApp) NewServerReversalCmd() *cobra.Command {
 			confirmPrompt := promptui.Prompt{
 				Label:     fmt.Sprintf("Really reverse server %s (%s) ", chosenServer.ServerName, chosenServer.ServerIP),
 				IsConfirm: true,
+				Stdout:    app.promptOutput(),
 			}
 
 			_, confirmErr := confirmPrompt.Run()
@@ -108,6 +120,7 @@ func (app *RobotApp) NewServerReversalCmd() *cobra.Command {
 			}
 
 			_, reverseErr := app.client.ServerReverse(chosenServer.ServerIP)
+			app.notifyAction(cmd.Name(), "revert order", []notify.Target{serverTarget(*chosenServer, reverseErr)})
 			if reverseErr != nil {
 				app.logger.Errorln("Error while reversing server:", reverseErr)
 				return
@@ -119,21 +132,27 @@ func (app *RobotApp) NewServerReversalCmd() *cobra.Command {
 }
 
 func (app *RobotApp) NewServerSetNameCmd() *cobra.Command {
-	return &cobra.Command{
+	var sel serverSelector
+	var opts bulkOptions
+
+	cmd := &cobra.Command{
 		Use:   "server:set-name",
 		Short: "Sets name for selected servers",
-		Long:  "Sets name for selected servers in the hetzner account, servers can be chosen interactively",
-		Run: func(cmd *cobra.Command, args []string) {
-			chosenServers, err := app.selectMultipleServers()
+		Long:  "Sets name for selected servers in the hetzner account, servers can be chosen interactively or by flags",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			chosenServers, err := app.selectServers(&sel)
 			if err != nil {
-				app.logger.Errorln(err)
-				return
+				return err
 			}
 
-			color.Cyan("Servers selected for renaming:")
+			app.printMessage("Servers selected for renaming:")
 
 			t := table.NewWriter()
-			t.SetOutputMirror(os.Stdout)
+			t.SetOutputMirror(app.errOut)
 
 			t.AppendHeader(table.Row{"name", "ip"})
 
@@ -148,20 +167,21 @@ func (app *RobotApp) NewServerSetNameCmd() *cobra.Command {
 			t.Render()
 
 			prompt := promptui.Prompt{
-				Label: "Add server name prefix",
+				Label:  "Add server name prefix",
+				Stdout: app.promptOutput(),
 			}
 
 			prefix, err := prompt.Run()
 
 			if err != nil {
 				app.logger.Errorln("Prompt failed: ", err)
-				return
+				return nil
 			}
 
-			color.Cyan(fmt.Sprint("Chosen server prefix: ", prefix))
+			app.printMessage("Chosen server prefix: %s", prefix)
 
 			tNames := table.NewWriter()
-			tNames.SetOutputMirror(os.Stdout)
+			tNames.SetOutputMirror(app.errOut)
 
 			tNames.AppendHeader(table.Row{"id", "ip", "current name", "new name"})
 
@@ -181,112 +201,152 @@ func (app *RobotApp) NewServerSetNameCmd() *cobra.Command {
 			confirmPrompt := promptui.Prompt{
 				Label:     fmt.Sprintf("Really set names as shown above for %d servers", len(chosenServers)),
 				IsConfirm: true,
+				Stdout:    app.promptOutput(),
 			}
 
 			_, confirmErr := confirmPrompt.Run()
 			if confirmErr != nil {
 				app.logger.Errorln("Prompt failed: ", confirmErr)
-				return
+				return nil
 			}
 
+			var tasks []bulkTask
 			for _, server := range chosenServers {
-				color.Cyan(fmt.Sprint("Set server name for ", server.ServerIP, " to ", generateServerName(server, prefix), " ..."))
-
-				input := &models.ServerSetNameInput{
-					Name: generateServerName(server, prefix),
-				}
-
-				_, err := app.client.ServerSetName(server.ServerIP, input)
-				if err != nil {
-					app.logger.Errorln(err)
-					continue
-				}
+				server := server
+				name := generateServerName(server, prefix)
+
+				tasks = append(tasks, bulkTask{
+					Item:   server.ServerIP,
+					Action: "rename to " + name,
+					Run: func() error {
+						app.logger.Infof("Set server name for %s to %s ...", server.ServerIP, name)
+
+						input := &models.ServerSetNameInput{
+							Name: name,
+						}
+
+						_, err := app.client.ServerSetName(server.ServerIP, input)
+						return err
+					},
+				})
 			}
+
+			return app.executeBulk(tasks, &opts)
 		},
 	}
+
+	addServerSelectorFlags(cmd, &sel)
+	addBulkFlags(cmd, &opts)
+
+	return cmd
 }
 
 func (app *RobotApp) NewServerActivateRescueCmd() *cobra.Command {
-	return &cobra.Command{
+	var sel serverSelector
+	var rolling rollingOptions
+	var opts bulkOptions
+	var keyRef string
+
+	cmd := &cobra.Command{
 		Use:   "server:rescue",
-		Short: "Activate rescue mode for single server",
-		Long:  `Activate rescue mode for single server in hetzner account, server can be chosen interactively`,
-		Run: func(cmd *cobra.Command, args []string) {
-			chosenServer, err := app.selectServer()
-			if err != nil {
-				app.logger.Errorln(err)
-				return
+		Short: "Activate rescue mode for selected servers",
+		Long: `Activate rescue mode and reboot selected servers in hetzner account, servers can be chosen
+interactively or by flags. The rescue systems offered for any of the servers can be chosen, servers
+without the chosen rescue system or with an already active one are skipped. The ssh key authorized
+on the rescue system can be given by name or fingerprint with --key instead of being chosen
+interactively. Servers can be processed in batches waiting for a port to become reachable again
+between the batches.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
 			}
 
-			rescueOptions, rescueOptErr := app.client.BootRescueGet(chosenServer.ServerIP)
-			if rescueOptErr != nil {
-				app.logger.Errorln("Error while fetching rescue options:", rescueOptErr)
-				return
+			if err := validateRollingOptions(&rolling); err != nil {
+				return err
 			}
 
-			selectOsItems := rescueOptions.Os.([]interface{})
+			chosenServers, err := app.selectServers(&sel)
+			if err != nil {
+				return err
+			}
 
-			promptRescueOS := promptui.Select{
-				Label: "Select rescue operating system",
-				Items: selectOsItems,
-				Size:  10,
+			options, err := app.fetchRescueOptions(chosenServers)
+			if err != nil {
+				return err
+			}
+
+			// the options of all servers are offered, servers without the
+			// chosen ones are skipped
+			selectOs, selectArch := mergeRescueOptions(chosenServers, options)
+			if len(selectOs) == 0 {
+				return errors.New("rescue system is already active on all selected servers")
 			}
 
-			// convert interface to string
-			selectOs := make([]string, len(selectOsItems))
-			for i, v := range selectOsItems {
-				selectOs[i] = v.(string)
+			promptRescueOS := promptui.Select{
+				Label:  "Select rescue operating system",
+				Items:  selectOs,
+				Size:   10,
+				Stdout: app.promptOutput(),
 			}
 
 			chosenOSIdx, _, err := promptRescueOS.Run()
 			if err != nil {
 				app.logger.Errorln("Prompt failed: ", err)
-				return
+				return nil
 			}
 
 			chosenOS := selectOs[chosenOSIdx]
-			color.Cyan(fmt.Sprint("Chosen OS: ", chosenOS))
-
-			selectArchItems := rescueOptions.Arch.([]interface{})
+			app.printMessage("Chosen OS: %s", chosenOS)
 
 			promptRescueArch := promptui.Select{
-				Label: "Select rescue operating system architecture",
-				Items: selectArchItems,
-			}
-
-			// convert interface to int
-			selectArch := make([]int, len(selectArchItems))
-			for i, v := range selectArchItems {
-				selectArch[i] = int(v.(float64))
+				Label:  "Select rescue operating system architecture",
+				Items:  selectArch,
+				Stdout: app.promptOutput(),
 			}
 
 			chosenArchIdx, _, err := promptRescueArch.Run()
 			if err != nil {
 				app.logger.Errorln("Prompt failed: ", err)
-				return
+				return nil
 			}
 
 			chosenArch := selectArch[chosenArchIdx]
-			color.Cyan(fmt.Sprint("Chosen arch: ", chosenArch))
+			app.printMessage("Chosen arch: %d", chosenArch)
 
-			confirmPromptKey := promptui.Prompt{
-				Label:     fmt.Sprintf("Use SSH key for rescue system "),
-				IsConfirm: true,
-				Default:   "y",
+			for _, server := range chosenServers {
+				if err := checkRescueOptions(options, server, chosenOS, chosenArch); err != nil {
+					app.logger.Warnf("Skipping %s: %s", server.ServerIP, err)
+				}
 			}
 
 			useSSHKey := true
-			_, confirmKeyErr := confirmPromptKey.Run()
-			if confirmKeyErr != nil {
-				useSSHKey = false
+			if keyRef == "" {
+				confirmPromptKey := promptui.Prompt{
+					Label:     fmt.Sprintf("Use SSH key for rescue system "),
+					IsConfirm: true,
+					Default:   "y",
+					Stdout:    app.promptOutput(),
+				}
+
+				_, confirmKeyErr := confirmPromptKey.Run()
+				if confirmKeyErr != nil {
+					useSSHKey = false
+				}
 			}
 
 			var chosenKey models.Key
-			if useSSHKey {
+			if keyRef != "" {
+				keys, err := app.chooseKeys([]string{keyRef})
+				if err != nil {
+					return err
+				}
+
+				chosenKey = keys[0]
+				app.printMessage("Chosen key: %s %s", chosenKey.Name, chosenKey.Fingerprint)
+			} else if useSSHKey {
 				keys, err := app.client.KeyGetList()
 				if err != nil {
-					app.logger.Errorln(err)
-					return
+					return err
 				}
 
 				promptKey := promptui.Select{
@@ -294,99 +354,328 @@ func (app *RobotApp) NewServerActivateRescueCmd() *cobra.Command {
 					Items:     keys,
 					Size:      10,
 					Templates: getKeySelectTemplates(),
+					Stdout:    app.promptOutput(),
 				}
 
 				chosenKeyIdx, _, err := promptKey.Run()
 				if err != nil {
 					app.logger.Errorln("Prompt failed: ", err)
-					return
+					return nil
 				}
 
 				chosenKey = keys[chosenKeyIdx]
-				color.Cyan(fmt.Sprint("Chosen key: ", chosenKey.Name, chosenKey.Fingerprint))
+				app.printMessage("Chosen key: %s %s", chosenKey.Name, chosenKey.Fingerprint)
 			} else {
-				color.Cyan("Chosen to use password instead of key.")
-			}
-
-			confirmPrompt := promptui.Prompt{
-				Label:     fmt.Sprintf("Really activate rescue system and reboot server %s (%s) ", chosenServer.ServerName, chosenServer.ServerIP),
-				IsConfirm: true,
+				app.printMessage("Chosen to use password instead of key.")
 			}
 
-			_, confirmErr := confirmPrompt.Run()
-			if confirmErr != nil {
-				app.logger.Errorln("Prompt failed: ", confirmErr)
-				return
+			if err := app.confirmServers(chosenServers, "activate rescue system and reboot"); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
 			}
 
-			input := &models.RescueSetInput{}
-			input = &models.RescueSetInput{
+			input := &models.RescueSetInput{
 				OS:            chosenOS,
 				Arch:          chosenArch,
 				AuthorizedKey: chosenKey.Fingerprint,
 			}
 
-			rescue, err := app.client.BootRescueSet(chosenServer.ServerIP, input)
-			if err != nil {
-				app.logger.Errorln("Error while activating rescue system:", err)
-				return
-			}
+			activateRescue := func(server models.Server) error {
+				if err := checkRescueOptions(options, server, chosenOS, chosenArch); err != nil {
+					return err
+				}
 
-			resetInput := &models.ResetSetInput{
-				Type: models.ResetTypeHardware,
+				return app.activateRescue(server, input, !useSSHKey)
 			}
 
-			_, resetErr := app.client.ResetSet(chosenServer.ServerIP, resetInput)
-			if resetErr != nil {
-				app.logger.Errorln("Error while rebooting server:", resetErr)
-				return
+			return app.executeRolling(cmd.Name(), chosenServers, "activate rescue and reboot", activateRescue, &rolling, &opts)
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+	cmd.Flags().StringVar(&keyRef, "key", "", "name or fingerprint of the ssh key authorized on the rescue system")
+	markFlagCompletion(cmd, "key", completeKeys)
+	addRollingFlags(cmd, &rolling)
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+// rescueOptions are the operating systems and architectures of the rescue
+// system offered for a server
+type rescueOptions struct {
+	os   []string
+	arch []int
+}
+
+// fetchRescueOptions returns the rescue options of the servers by server IP,
+// servers with an active rescue system have no options
+func (app *RobotApp) fetchRescueOptions(servers []models.Server) (map[string]*rescueOptions, error) {
+	options := make(map[string]*rescueOptions)
+
+	for _, server := range servers {
+		rescue, err := app.client.BootRescueGet(server.ServerIP)
+		if err != nil {
+			return nil, fmt.Errorf("error while fetching rescue options of %s: %s", server.ServerIP, err)
+		}
+
+		if rescue.Active {
+			continue
+		}
+
+		serverOptions, err := newRescueOptions(rescue)
+		if err != nil {
+			return nil, fmt.Errorf("error while fetching rescue options of %s: %s", server.ServerIP, err)
+		}
+
+		options[server.ServerIP] = serverOptions
+	}
+
+	return options, nil
+}
+
+// newRescueOptions reads the options of an inactive rescue system, active
+// ones return the chosen values instead of lists
+func newRescueOptions(rescue *models.Rescue) (*rescueOptions, error) {
+	osItems, ok := rescue.Os.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected operating systems %v", rescue.Os)
+	}
+
+	archItems, ok := rescue.Arch.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected architectures %v", rescue.Arch)
+	}
+
+	options := &rescueOptions{}
+
+	for _, item := range osItems {
+		name, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected operating system %v", item)
+		}
+
+		options.os = append(options.os, name)
+	}
+
+	for _, item := range archItems {
+		arch, ok := item.(float64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected architecture %v", item)
+		}
+
+		options.arch = append(options.arch, int(arch))
+	}
+
+	return options, nil
+}
+
+// mergeRescueOptions returns the operating systems and architectures offered
+// for any of the servers in the order of the servers
+func mergeRescueOptions(servers []models.Server, options map[string]*rescueOptions) ([]string, []int) {
+	var osNames []string
+	var archs []int
+	seenOs := make(map[string]bool)
+	seenArch := make(map[int]bool)
+
+	for _, server := range servers {
+		serverOptions, ok := options[server.ServerIP]
+		if !ok {
+			continue
+		}
+
+		for _, name := range serverOptions.os {
+			if !seenOs[name] {
+				seenOs[name] = true
+				osNames = append(osNames, name)
 			}
+		}
 
-			if !useSSHKey {
-				color.Cyan(fmt.Sprintf("Password for accessing rescue mode: %s", rescue.Password))
+		for _, arch := range serverOptions.arch {
+			if !seenArch[arch] {
+				seenArch[arch] = true
+				archs = append(archs, arch)
 			}
+		}
+	}
 
-			color.Cyan("Rescue mode successfully activated and server rebooted.")
-		},
+	return osNames, archs
+}
+
+// checkRescueOptions returns a skip error if the rescue system is already
+// active on the server or the chosen options are not offered for it
+func checkRescueOptions(options map[string]*rescueOptions, server models.Server, osName string, arch int) error {
+	serverOptions, ok := options[server.ServerIP]
+	if !ok {
+		return bulkSkip("rescue system is already active")
+	}
+
+	if !containsFold(serverOptions.os, osName) {
+		return bulkSkip("rescue system %s is not available", osName)
 	}
+
+	if !containsArch(serverOptions.arch, arch) {
+		return bulkSkip("rescue system architecture %d is not available", arch)
+	}
+
+	return nil
+}
+
+// activateRescue activates the rescue system of the server and reboots it
+// with a hardware reset, the reset is recorded in the history
+func (app *RobotApp) activateRescue(server models.Server, input *models.RescueSetInput, printPassword bool) error {
+	rescue, err := app.client.BootRescueSet(server.ServerIP, input)
+	if err != nil {
+		return fmt.Errorf("error while activating rescue system: %s", err)
+	}
+
+	resetInput := &models.ResetSetInput{
+		Type: models.ResetTypeHardware,
+	}
+
+	_, resetErr := app.client.ResetSet(server.ServerIP, resetInput)
+	if resetErr != nil {
+		return fmt.Errorf("error while rebooting server: %s", resetErr)
+	}
+
+	app.recordReset(server, models.ResetTypeHardware)
+
+	if printPassword {
+		app.printMessage("Password for accessing rescue mode of %s: %s", server.ServerIP, rescue.Password)
+	}
+
+	return nil
 }
 
 func (app *RobotApp) NewServerResetCmd() *cobra.Command {
-	return &cobra.Command{
+	var sel serverSelector
+	var rolling rollingOptions
+	var opts bulkOptions
+	var resetType string
+
+	cmd := &cobra.Command{
 		Use:   "server:reset",
-		Short: "Reset single server (hardware reset)",
-		Long:  `Reset single server in hetzner account using hardware reset, server can be chosen interactively`,
-		Run: func(cmd *cobra.Command, args []string) {
-			chosenServer, err := app.selectServer()
+		Short: "Reset selected servers (hardware reset)",
+		Long: `Reset selected servers in hetzner account using hardware reset or the reset type given with
+--type, servers can be chosen interactively or by flags. Servers can be processed in batches waiting
+for a port to become reachable again between the batches. Issued resets are recorded in the local
+reset history.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
+			}
+
+			if err := validateRollingOptions(&rolling); err != nil {
+				return err
+			}
+
+			description, ok := resetTypes[resetType]
+			if !ok {
+				return fmt.Errorf("unknown reset type %q, use one of: %s", resetType, strings.Join(resetTypeNames(), ", "))
+			}
+
+			chosenServers, err := app.selectServers(&sel)
 			if err != nil {
-				app.logger.Errorln(err)
-				return
+				return err
 			}
 
-			confirmPrompt := promptui.Prompt{
-				Label:     fmt.Sprintf("Really reset server %s (%s) ", chosenServer.ServerName, chosenServer.ServerIP),
-				IsConfirm: true,
+			if err := app.confirmServers(chosenServers, "reset"); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
 			}
 
-			_, confirmErr := confirmPrompt.Run()
-			if confirmErr != nil {
-				app.logger.Errorln("Prompt failed: ", confirmErr)
-				return
+			resetServer := func(server models.Server) error {
+				resetInput := &models.ResetSetInput{
+					Type: resetType,
+				}
+
+				if _, err := app.client.ResetSet(server.ServerIP, resetInput); err != nil {
+					return err
+				}
+
+				app.recordReset(server, resetType)
+				return nil
 			}
 
-			resetInput := &models.ResetSetInput{
-				Type: models.ResetTypeHardware,
+			return app.executeRolling(cmd.Name(), chosenServers, description, resetServer, &rolling, &opts)
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+	cmd.Flags().StringVar(&resetType, "type", models.ResetTypeHardware, fmt.Sprintf("type of the reset (%s)", strings.Join(resetTypeNames(), ", ")))
+	addRollingFlags(cmd, &rolling)
+	addBulkFlags(cmd, &opts)
+
+	return cmd
+}
+
+func (app *RobotApp) NewServerWolCmd() *cobra.Command {
+	var sel serverSelector
+	var opts bulkOptions
+	var waitPort int
+	var waitTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "server:wol",
+		Short: "Wake selected servers using Wake on LAN",
+		Long: `Send a Wake on LAN packet to selected servers in hetzner account, servers without Wake on LAN
+support fail. Servers can be chosen interactively or by flags, with flags the command runs without any
+prompt. Optionally waits until a TCP port of every server is reachable, the command exits with an
+error if any server failed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkOptions(&opts); err != nil {
+				return err
 			}
 
-			_, resetErr := app.client.ResetSet(chosenServer.ServerIP, resetInput)
-			if resetErr != nil {
-				app.logger.Errorln("Error while rebooting server:", resetErr)
-				return
+			if waitPort < 0 || waitPort > 65535 {
+				return fmt.Errorf("invalid port %d", waitPort)
+			}
+
+			chosenServers, err := app.selectServers(&sel)
+			if err != nil {
+				return err
+			}
+
+			var tasks []bulkTask
+			for _, server := range chosenServers {
+				server := server
+
+				tasks = append(tasks, bulkTask{
+					Item:   server.ServerIP,
+					Action: "wake on LAN",
+					Run: func() error {
+						if _, err := app.client.WolGet(server.ServerIP); err != nil {
+							if robot.ErrorCode(err) == robot.CodeWolNotAvailable {
+								return errors.New("Wake on LAN not available")
+							}
+
+							return err
+						}
+
+						if _, err := app.client.WolSend(server.ServerIP); err != nil {
+							return err
+						}
+
+						if waitPort > 0 {
+							app.logger.Infof("Waiting for port %d on %s ...", waitPort, server.ServerIP)
+							return waitForPortReachable(server.ServerIP, waitPort, waitTimeout)
+						}
+
+						return nil
+					},
+				})
 			}
 
-			color.Cyan("Server rebooted successfully.")
+			return app.executeBulk(tasks, &opts)
 		},
 	}
+
+	addServerSelectorFlags(cmd, &sel)
+	cmd.Flags().IntVar(&waitPort, "wait-port", 0, "wait until this TCP port is reachable on every server")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 10*time.Minute, "maximum time to wait for the port of a server")
+	addBulkFlags(cmd, &opts)
+
+	return cmd
 }
 
 func (app *RobotApp) NewServerGenerateAnsibleInventoryCmd() *cobra.Command {
@@ -406,14 +695,11 @@ func (app *RobotApp) NewServerGenerateAnsibleInventoryCmd() *cobra.Command {
 
 			fmt.Println("[servers]")
 			for _, server := range servers {
-				dc := strings.Split(server.Dc, "-")
-				dcLocation := strings.ToLower(dc[0])
-
-				srvInf := strings.Split(server.ServerName, "-")
-				hostGroup := srvInf[0] // first token should define host group (=purpose) of the host, i.e. mongodb
+				location := dcLocation(server.Dc)
+				group := hostGroup(server.ServerName)
 
-				invDcs[dcLocation] = append(invDcs[dcLocation], server.ServerName)
-				invGroups[hostGroup] = append(invGroups[hostGroup], server.ServerName)
+				invDcs[location] = append(invDcs[location], server.ServerName)
+				invGroups[group] = append(invGroups[group], server.ServerName)
 
 				fmt.Printf("%s ansible_host=%s\n", server.ServerName, server.ServerIP)
 			}
@@ -435,6 +721,18 @@ func (app *RobotApp) NewServerGenerateAnsibleInventoryCmd() *cobra.Command {
 	}
 }
 
+// hostGroup returns the first token of the server name, it should define the
+// host group (=purpose) of the host, i.e. mongodb
+func hostGroup(serverName string) string {
+	return strings.Split(serverName, "-")[0]
+}
+
+// dcLocation returns the location of the data center in lower case, i.e. fsn1
+// for FSN1-DC14
+func dcLocation(dc string) string {
+	return strings.ToLower(strings.Split(dc, "-")[0])
+}
+
 func (app *RobotApp) selectServer() (*models.Server, error) {
 	servers, err := app.client.ServerGetList()
 	if err != nil {
@@ -448,6 +746,7 @@ func (app *RobotApp) selectServer() (*models.Server, error) {
 		Size:              10,
 		Templates:         getServerSelectTemplates(),
 		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
 	}
 
 	chosenIdx, _, err := prompt.Run()
@@ -457,86 +756,36 @@ func (app *RobotApp) selectServer() (*models.Server, error) {
 	}
 
 	chosenServer := servers[chosenIdx]
-	color.Cyan(fmt.Sprint("Chosen server: ", chosenServer.ServerIP))
+	app.printMessage("Chosen server: %s", chosenServer.ServerIP)
 
 	return &chosenServer, nil
 }
 
 func (app *RobotApp) selectMultipleServers() ([]models.Server, error) {
-	var selectServers []models.Server
-
-	selectServers = append(selectServers, models.Server{
-		ServerName: "Done",
-	})
-
 	servers, err := app.client.ServerGetList()
 	if err != nil {
 		return []models.Server{}, err
 	}
 
-	for _, server := range servers {
-		selectServers = append(selectServers, server)
+	prompt := multiselect.MultiSelect{
+		Label:             "Choose servers",
+		Items:             servers,
+		Searcher:          getServerSearcher(servers),
+		Size:              10,
+		Templates:         getServerSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
 	}
 
-	chosenIdx := 1
-	var chosenServers []models.Server
-
-	for chosenIdx > 0 {
-		if len(chosenServers) > 0 {
-			color.Cyan("Servers  currently selected:")
-
-			t := table.NewWriter()
-			t.SetOutputMirror(os.Stdout)
-
-			t.AppendHeader(table.Row{"name", "ip"})
-
-			for _, chServ := range chosenServers {
-				t.AppendRow(table.Row{
-					chServ.ServerName,
-					chServ.ServerIP,
-				})
-			}
-
-			t.AppendFooter(table.Row{"Total", len(chosenServers)})
-			t.Render()
-		} else {
-			color.Cyan("No servers currently selected.")
-		}
-
-		prompt := promptui.Select{
-			Label:             "Choose servers",
-			Items:             selectServers,
-			Searcher:          getServerSearcher(selectServers),
-			Size:              10,
-			Templates:         getServerSelectTemplates(),
-			StartInSearchMode: true,
-		}
-
-		chosenIdx, _, err = prompt.Run()
-		if err != nil {
-			app.logger.Errorln("Prompt failed: ", err)
-			return []models.Server{}, err
-		}
-
-		if chosenIdx > 0 {
-			chosenServer := selectServers[chosenIdx]
-			color.Cyan(fmt.Sprint("Chosen server: ", chosenServer.ServerIP))
-
-			selectedBefore := false
-			for _, chServ := range chosenServers {
-				if chServ.ServerIP == chosenServer.ServerIP {
-					selectedBefore = true
-				}
-			}
+	chosenIdxs, err := prompt.Run()
+	if err != nil {
+		app.logger.Errorln("Prompt failed: ", err)
+		return []models.Server{}, err
+	}
 
-			if selectedBefore {
-				app.logger.Infof("Server %s was selected already.", chosenServer.ServerIP)
-			} else {
-				chosenServers = append(chosenServers, chosenServer)
-				// remove chosen server from select list = re-slicing
-				selectServers = append(selectServers[:chosenIdx], selectServers[chosenIdx+1:]...)
-			}
-		}
+	var chosenServers []models.Server
+	for _, chosenIdx := range chosenIdxs {
+		chosenServers = append(chosenServers, servers[chosenIdx])
 	}
 
 	return chosenServers, nil
@@ -1,11 +1,15 @@
 package cmd_test
 
 import (
+	"errors"
+
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	. "gopkg.in/check.v1"
 
 	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-cli/robot"
 	"github.com/nl2go/hrobot-cli/test/mock"
 	"github.com/nl2go/hrobot-go/models"
 )
@@ -84,3 +88,261 @@ func (s *AppSuite) TestServerGenerateAnsibleInventoryCommandSuccess(c *C) {
 	_, err := executeCommand(rootCmd, "server:ansible-inv")
 	c.Assert(err, IsNil)
 }
+
+func (s *AppSuite) TestServerResetCommandUnknownServer(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 42,
+			ServerName:   "app-prod-42",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset", "--servers", "42,app-prod-84")
+	c.Assert(err, ErrorMatches, `no server found for "app-prod-84"`)
+}
+
+func (s *AppSuite) TestServerResetCommandFilterWithoutMatch(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.Server{
+		{
+			ServerIP:   "123.123.123.123",
+			ServerName: "app-prod-42",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset", "--filter", "mongodb")
+	c.Assert(err, ErrorMatches, `no server matches filter "mongodb"`)
+}
+
+func (s *AppSuite) TestServerResetCommandNotConfirmed(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.Server{
+		{
+			ServerIP:   "123.123.123.123",
+			ServerName: "app-prod-42",
+		},
+		{
+			ServerIP:   "124.124.124.124",
+			ServerName: "app-prod-84",
+		},
+	}
+
+	// no reset is expected as the confirmation can't be given without a terminal
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset", "--filter", "app-prod", "--batch-size", "1")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestServerResetCommandInvalidBatchSize(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:reset", "--batch-size", "-1")
+	c.Assert(err, ErrorMatches, "batch size must not be negative, got -1")
+}
+
+func (s *AppSuite) TestServerSetNameCommandInvalidConcurrency(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:set-name", "--concurrency", "0")
+	c.Assert(err, ErrorMatches, "concurrency must be at least 1, got 0")
+}
+
+func (s *AppSuite) TestServerWolCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "batch-1",
+		},
+	}
+
+	wol := &robot.Wol{
+		ServerIP:     "123.123.123.123",
+		ServerNumber: 321,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().WolGet("123.123.123.123").Times(1).Return(wol, nil)
+	mockRobotClient.EXPECT().WolSend("123.123.123.123").Times(1).Return(wol, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:wol", "--servers", "batch-1")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestServerWolCommandNotAvailable(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "batch-1",
+		},
+	}
+
+	notAvailable := errors.New(`{"error":{"status":404,"code":"WOL_NOT_AVAILABLE","message":"Wake On LAN is not available for this server"}}`)
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().WolGet("123.123.123.123").Times(1).Return(nil, notAvailable)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "server:wol", "--servers", "batch-1")
+	c.Assert(err, ErrorMatches, "1 of 1 items failed")
+}
+
+func (s *AppSuite) TestServerActivateRescueRecordsReset(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	server := models.Server{
+		ServerIP:     "123.123.123.123",
+		ServerNumber: 321,
+		ServerName:   "batch-1",
+	}
+
+	input := &models.RescueSetInput{OS: "linux", Arch: 64}
+	resetInput := &models.ResetSetInput{Type: models.ResetTypeHardware}
+
+	store := history.NewStore(c.MkDir())
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().BootRescueSet("123.123.123.123", input).Times(1).Return(&models.Rescue{Password: "secret"}, nil)
+	mockRobotClient.EXPECT().ResetSet("123.123.123.123", resetInput).Times(1).Return(&models.ResetPost{}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetHistory(store)
+
+	c.Assert(app.ActivateRescue(server, input), IsNil)
+
+	resets, err := store.Resets()
+	c.Assert(err, IsNil)
+	c.Assert(resets, HasLen, 1)
+	c.Assert(resets[0].ServerIP, Equals, "123.123.123.123")
+	c.Assert(resets[0].ServerName, Equals, "batch-1")
+	c.Assert(resets[0].Type, Equals, models.ResetTypeHardware)
+}
+
+func (s *AppSuite) TestServerCheckRescueOptions(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{ServerIP: "123.123.123.123", ServerName: "batch-1"},
+		{ServerIP: "123.123.123.124", ServerName: "batch-2"},
+		{ServerIP: "123.123.123.125", ServerName: "batch-3"},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(&models.Rescue{
+		Os:   []interface{}{"linux", "vkvm"},
+		Arch: []interface{}{float64(64), float64(32)},
+	}, nil)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.124").Times(1).Return(&models.Rescue{
+		Os:   []interface{}{"linux"},
+		Arch: []interface{}{float64(64)},
+	}, nil)
+	// active rescue systems return the chosen values instead of lists
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.125").Times(1).Return(&models.Rescue{
+		Active: true,
+		Os:     "linux",
+		Arch:   float64(64),
+	}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	errs, err := app.CheckRescueOptions(servers, "vkvm", 64)
+	c.Assert(err, IsNil)
+	c.Assert(errs[0], IsNil)
+	c.Assert(errs[1], ErrorMatches, "rescue system vkvm is not available")
+	c.Assert(errs[2], ErrorMatches, "rescue system is already active")
+}
+
+func (s *AppSuite) TestServerCheckRescueOptionsUnexpected(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{{ServerIP: "123.123.123.123"}}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().BootRescueGet("123.123.123.123").Times(1).Return(&models.Rescue{
+		Os:   "linux",
+		Arch: []interface{}{float64(64)},
+	}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	_, err := app.CheckRescueOptions(servers, "linux", 64)
+	c.Assert(err, ErrorMatches, "error while fetching rescue options of 123.123.123.123: unexpected operating systems linux")
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/snapshot"
+)
+
+const (
+	snapshotLive = "live"
+
+	outputText = "text"
+)
+
+func (app *RobotApp) NewSnapshotSaveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snapshot:save [file]",
+		Short: "Save snapshot of servers, IP's, rDNS entries, failover IP's and keys",
+		Long: `Save a normalized JSON snapshot of servers, IP's, reverse DNS entries, failover IP's and ssh keys.
+Without file the snapshot is saved in the snapshots directory of the local history, where
+snapshot:diff finds the latest snapshot.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			snap, err := app.takeSnapshot()
+			if err != nil {
+				return err
+			}
+
+			path, err = app.saveSnapshot(snap, path)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Snapshot saved to %s", path)
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewSnapshotDiffCmd() *cobra.Command {
+	var save, exitCode bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot:diff [old] [new|live]",
+		Short: "Print changes between snapshots",
+		Long: `Print added, removed and changed servers, IP's, reverse DNS entries, failover IP's and ssh keys
+between two snapshots. The old snapshot defaults to the latest snapshot saved by snapshot:save,
+the new snapshot defaults to the live state of the account.
+
+A nightly cron job reporting changes made in the web interface runs:
+
+  hrobot-cli snapshot:diff --save --exit-code > changes.txt || post-to-chat < changes.txt`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputText, outputJSON); err != nil {
+				return err
+			}
+
+			oldPath := ""
+			if len(args) > 0 {
+				oldPath = args[0]
+			} else {
+				if app.history == nil {
+					return errors.New("snapshot directory is not enabled, pass the old snapshot")
+				}
+
+				latest, err := app.history.LatestSnapshot()
+				if err != nil {
+					return err
+				}
+
+				if latest == "" {
+					return errors.New("no snapshot saved yet, run snapshot:save first")
+				}
+
+				oldPath = latest
+			}
+
+			newPath := snapshotLive
+			if len(args) > 1 {
+				newPath = args[1]
+			}
+
+			if save && newPath != snapshotLive {
+				return errors.New("--save requires the live state as new snapshot")
+			}
+
+			oldSnap, err := snapshot.Load(oldPath)
+			if err != nil {
+				return err
+			}
+
+			var newSnap *snapshot.Snapshot
+			if newPath == snapshotLive {
+				newSnap, err = app.takeSnapshot()
+			} else {
+				newSnap, err = snapshot.Load(newPath)
+			}
+			if err != nil {
+				return err
+			}
+
+			changes := snapshot.Diff(oldSnap, newSnap)
+
+			if output == outputJSON {
+				err = printJSON(changes)
+			} else {
+				printSnapshotChanges(oldSnap, newSnap, changes)
+			}
+			if err != nil {
+				return err
+			}
+
+			if save {
+				path, err := app.saveSnapshot(newSnap, "")
+				if err != nil {
+					return err
+				}
+
+				app.logger.Debugf("Snapshot saved to %s", path)
+			}
+
+			if exitCode && len(changes) > 0 {
+				return fmt.Errorf("%d changes found", len(changes))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&save, "save", false, "save the live state as latest snapshot after the diff")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "exit with a non-zero exit code if there are changes")
+	cmd.Flags().StringVarP(&output, "output", "o", outputText, "output format (text, json)")
+
+	return cmd
+}
+
+// takeSnapshot bypasses the response cache, cached lists may be older than
+// changes made in the web interface
+func (app *RobotApp) takeSnapshot() (*snapshot.Snapshot, error) {
+	if cachedClient, ok := app.client.(cacheControl); ok {
+		cachedClient.SetRefresh(true)
+	}
+
+	return snapshot.Take(app.client, time.Now())
+}
+
+// saveSnapshot saves the snapshot to the path, an empty path selects the
+// snapshots directory of the history
+func (app *RobotApp) saveSnapshot(snap *snapshot.Snapshot, path string) (string, error) {
+	if path == "" {
+		if app.history == nil {
+			return "", errors.New("snapshot directory is not enabled, pass a file")
+		}
+
+		path = app.history.SnapshotPath(snap.Time)
+	}
+
+	return path, snap.Save(path)
+}
+
+func printSnapshotChanges(oldSnap *snapshot.Snapshot, newSnap *snapshot.Snapshot, changes []snapshot.Change) {
+	if len(changes) == 0 {
+		fmt.Printf("No changes since %s\n", oldSnap.Time.Format(time.RFC3339))
+		return
+	}
+
+	color.Cyan(fmt.Sprintf("%d changes between %s and %s:", len(changes), oldSnap.Time.Format(time.RFC3339), newSnap.Time.Format(time.RFC3339)))
+
+	for _, change := range changes {
+		item := change.Resource + "/" + change.ID
+		if change.Name != "" {
+			item += " (" + change.Name + ")"
+		}
+
+		switch change.Type {
+		case snapshot.ChangeAdded:
+			fmt.Printf("+ %s\n", item)
+		case snapshot.ChangeRemoved:
+			fmt.Printf("- %s\n", item)
+		default:
+			var fields []string
+			for _, field := range change.Fields {
+				fields = append(fields, fmt.Sprintf("%s: %q -> %q", field.Field, field.Old, field.New))
+			}
+
+			fmt.Printf("~ %s %s\n", item, strings.Join(fields, ", "))
+		}
+	}
+}
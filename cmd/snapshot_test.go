@@ -0,0 +1,93 @@
+package cmd_test
+
+import (
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func expectSnapshotLists(mockRobotClient *mock.MockRobotClient, servers []models.Server) {
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return([]models.IP{}, nil)
+	mockRobotClient.EXPECT().RDnsGetList().Times(1).Return([]models.Rdns{}, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Times(1).Return([]models.Failover{}, nil)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return([]models.Key{}, nil)
+}
+
+func (s *AppSuite) TestSnapshotSaveAndDiffCommand(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	store := history.NewStore(filepath.Join(c.MkDir(), "default"))
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	expectSnapshotLists(mockRobotClient, []models.Server{{ServerNumber: 321, ServerName: "app-1"}})
+	expectSnapshotLists(mockRobotClient, []models.Server{{ServerNumber: 321, ServerName: "web-1"}})
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetHistory(store)
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "snapshot:save")
+	c.Assert(err, IsNil)
+
+	latest, err := store.LatestSnapshot()
+	c.Assert(err, IsNil)
+	c.Assert(latest, Not(Equals), "")
+
+	_, err = executeCommand(rootCmd, "snapshot:diff", "--exit-code")
+	c.Assert(err, ErrorMatches, "1 changes found")
+}
+
+func (s *AppSuite) TestSnapshotDiffCommandFiles(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	dir := c.MkDir()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	expectSnapshotLists(mockRobotClient, []models.Server{{ServerNumber: 321, ServerName: "app-1"}})
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	path := filepath.Join(dir, "snapshot.json")
+	_, err := executeCommand(rootCmd, "snapshot:save", path)
+	c.Assert(err, IsNil)
+
+	_, err = executeCommand(rootCmd, "snapshot:diff", path, path, "--exit-code", "-o", "json")
+	c.Assert(err, IsNil)
+
+	_, err = executeCommand(rootCmd, "snapshot:diff", path, path, "--save")
+	c.Assert(err, ErrorMatches, "--save requires the live state as new snapshot")
+}
+
+func (s *AppSuite) TestSnapshotDiffCommandNoSnapshot(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+	app.SetHistory(history.NewStore(filepath.Join(c.MkDir(), "default")))
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "snapshot:diff")
+	c.Assert(err, ErrorMatches, "no snapshot saved yet, run snapshot:save first")
+}
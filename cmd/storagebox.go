@@ -0,0 +1,927 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+// storageBoxAccess holds the flags toggling the access to a Storage Box or a
+// subaccount, only flags given on the command line are applied
+type storageBoxAccess struct {
+	ssh                  bool
+	samba                bool
+	webdav               bool
+	externalReachability bool
+}
+
+// addStorageBoxAccessFlags adds the access flags, sshDefault is the default
+// of --ssh for new subaccounts
+func addStorageBoxAccessFlags(cmd *cobra.Command, access *storageBoxAccess, sshDefault bool) {
+	cmd.Flags().BoolVar(&access.ssh, "ssh", sshDefault, "enable SSH/SFTP access")
+	cmd.Flags().BoolVar(&access.samba, "samba", false, "enable Samba/CIFS access")
+	cmd.Flags().BoolVar(&access.webdav, "webdav", false, "enable WebDAV access")
+	cmd.Flags().BoolVar(&access.externalReachability, "external-reachability", false, "allow access from outside of the hetzner network")
+}
+
+func (access *storageBoxAccess) apply(cmd *cobra.Command, ssh, samba, webdav, externalReachability *bool) {
+	flags := cmd.Flags()
+
+	if flags.Changed("ssh") {
+		*ssh = access.ssh
+	}
+
+	if flags.Changed("samba") {
+		*samba = access.samba
+	}
+
+	if flags.Changed("webdav") {
+		*webdav = access.webdav
+	}
+
+	if flags.Changed("external-reachability") {
+		*externalReachability = access.externalReachability
+	}
+}
+
+func (app *RobotApp) NewStorageBoxGetListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storagebox:list",
+		Short: "Print list of Storage Boxes",
+		Long:  "Print list of Storage Boxes in the hetzner account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			storageBoxes, err := app.client.StorageBoxGetList()
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"id", "login", "name", "product", "location", "linked server", "paid until", "locked", "cancelled"})
+
+			for _, storageBox := range storageBoxes {
+				linkedServer := ""
+				if storageBox.LinkedServer > 0 {
+					linkedServer = strconv.Itoa(storageBox.LinkedServer)
+				}
+
+				t.AppendRow(table.Row{
+					storageBox.ID,
+					storageBox.Login,
+					storageBox.Name,
+					storageBox.Product,
+					storageBox.Location,
+					linkedServer,
+					storageBox.PaidUntil,
+					storageBox.Locked,
+					storageBox.Cancelled,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "", "", "", "", "Total", len(storageBoxes)})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewStorageBoxGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storagebox:get [id]",
+		Short: "Print single Storage Box",
+		Long: `Print details of single Storage Box in hetzner account including disk usage and access settings,
+Storage Box can be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			storageBox, err := app.client.StorageBoxGet(id)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"id", storageBox.ID})
+			t.AppendRow(table.Row{"login", storageBox.Login})
+			t.AppendRow(table.Row{"name", storageBox.Name})
+			t.AppendRow(table.Row{"product", storageBox.Product})
+			t.AppendRow(table.Row{"location", storageBox.Location})
+			t.AppendRow(table.Row{"server", storageBox.Server})
+			t.AppendRow(table.Row{"host system", storageBox.HostSystem})
+			t.AppendRow(table.Row{"paid until", storageBox.PaidUntil})
+			t.AppendRow(table.Row{"locked", storageBox.Locked})
+			t.AppendRow(table.Row{"cancelled", storageBox.Cancelled})
+			t.AppendRow(table.Row{"disk quota", formatMB(storageBox.DiskQuota)})
+			t.AppendRow(table.Row{"disk usage", fmt.Sprintf("%s (%s)", formatMB(storageBox.DiskUsage), formatPercent(storageBox.DiskUsage, storageBox.DiskQuota))})
+			t.AppendRow(table.Row{"disk usage data", formatMB(storageBox.DiskUsageData)})
+			t.AppendRow(table.Row{"disk usage snapshots", formatMB(storageBox.DiskUsageSnapshots)})
+			t.AppendRow(table.Row{"ssh", storageBox.SSH})
+			t.AppendRow(table.Row{"samba", storageBox.Samba})
+			t.AppendRow(table.Row{"webdav", storageBox.WebDAV})
+			t.AppendRow(table.Row{"zfs", storageBox.ZFS})
+			t.AppendRow(table.Row{"external reachability", storageBox.ExternalReachability})
+
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewStorageBoxUpdateCmd() *cobra.Command {
+	var access storageBoxAccess
+	var name string
+	var zfs bool
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:update [id]",
+		Short: "Rename Storage Box or toggle its access settings",
+		Long: `Change name or toggle SSH, Samba, WebDAV, ZFS and external access of single Storage Box in
+hetzner account, settings not given are kept. Access is disabled with e.g. --samba=false. Storage Box
+can be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if !flags.Changed("name") && !flags.Changed("ssh") && !flags.Changed("samba") && !flags.Changed("webdav") &&
+				!flags.Changed("zfs") && !flags.Changed("external-reachability") {
+				return errors.New("nothing to update, use --name, --ssh, --samba, --webdav, --zfs or --external-reachability")
+			}
+
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			storageBox, err := app.client.StorageBoxGet(id)
+			if err != nil {
+				return err
+			}
+
+			input := &robot.StorageBoxInput{
+				Name:                 storageBox.Name,
+				WebDAV:               storageBox.WebDAV,
+				Samba:                storageBox.Samba,
+				SSH:                  storageBox.SSH,
+				ExternalReachability: storageBox.ExternalReachability,
+				ZFS:                  storageBox.ZFS,
+			}
+
+			if flags.Changed("name") {
+				input.Name = name
+			}
+
+			if flags.Changed("zfs") {
+				input.ZFS = zfs
+			}
+
+			access.apply(cmd, &input.SSH, &input.Samba, &input.WebDAV, &input.ExternalReachability)
+
+			updated, err := app.client.StorageBoxUpdate(id, input)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Updated Storage Box %d (%s): ssh=%t samba=%t webdav=%t zfs=%t external_reachability=%t",
+				updated.ID, updated.Name, updated.SSH, updated.Samba, updated.WebDAV, updated.ZFS, updated.ExternalReachability)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "new name of the Storage Box")
+	addStorageBoxAccessFlags(cmd, &access, false)
+	cmd.Flags().BoolVar(&zfs, "zfs", false, "make snapshots available in the .zfs directory")
+
+	return cmd
+}
+
+func (app *RobotApp) NewStorageBoxSubaccountListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storagebox:subaccount:list [id]",
+		Short: "Print list of subaccounts of Storage Box",
+		Long: `Print list of subaccounts of single Storage Box in hetzner account, Storage Box can be given as
+argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			subaccounts, err := app.client.StorageBoxSubaccountGetList(id)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"username", "home directory", "ssh", "samba", "webdav", "external", "readonly", "created", "comment"})
+
+			for _, subaccount := range subaccounts {
+				t.AppendRow(table.Row{
+					subaccount.Username,
+					subaccount.HomeDirectory,
+					subaccount.SSH,
+					subaccount.Samba,
+					subaccount.WebDAV,
+					subaccount.ExternalReachability,
+					subaccount.ReadOnly,
+					subaccount.CreateTime,
+					subaccount.Comment,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "", "", "", "", "Total", len(subaccounts)})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewStorageBoxSubaccountCreateCmd() *cobra.Command {
+	var access storageBoxAccess
+	var input robot.StorageBoxSubaccountInput
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:subaccount:create [id]",
+		Short: "Create subaccount of Storage Box",
+		Long: `Create a subaccount restricted to a home directory of single Storage Box in hetzner account, the
+generated password is printed once. Storage Box can be given as argument or chosen interactively.
+SSH/SFTP access is enabled unless --ssh=false is given, Samba, WebDAV and external reachability are
+only enabled with their flags.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if input.HomeDirectory == "" {
+				return errors.New("home directory must not be empty")
+			}
+
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			// the webservice expects all access settings, flags not given use their default
+			input.SSH = access.ssh
+			input.Samba = access.samba
+			input.WebDAV = access.webdav
+			input.ExternalReachability = access.externalReachability
+
+			subaccount, err := app.client.StorageBoxSubaccountCreate(id, &input)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"username", subaccount.Username})
+			t.AppendRow(table.Row{"password", subaccount.Password})
+			t.AppendRow(table.Row{"server", subaccount.Server})
+			t.AppendRow(table.Row{"home directory", subaccount.HomeDirectory})
+
+			t.Render()
+
+			app.logger.Warnln("The password is not shown again, store it now")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&input.HomeDirectory, "home-directory", "", "home directory of the subaccount")
+	cmd.Flags().BoolVar(&input.ReadOnly, "readonly", false, "allow read access only")
+	cmd.Flags().StringVar(&input.Comment, "comment", "", "comment of the subaccount")
+	addStorageBoxAccessFlags(cmd, &access, true)
+
+	return cmd
+}
+
+func (app *RobotApp) NewStorageBoxSubaccountUpdateCmd() *cobra.Command {
+	var access storageBoxAccess
+	var username, homeDirectory, comment string
+	var readOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:subaccount:update [id]",
+		Short: "Change settings of subaccount of Storage Box",
+		Long: `Change home directory, access settings or comment of a subaccount of single Storage Box in
+hetzner account, settings not given are kept. Storage Box can be given as argument or chosen
+interactively, the subaccount can be given by flag or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if !flags.Changed("home-directory") && !flags.Changed("readonly") && !flags.Changed("comment") && !flags.Changed("ssh") &&
+				!flags.Changed("samba") && !flags.Changed("webdav") && !flags.Changed("external-reachability") {
+				return errors.New("nothing to update, use --home-directory, --readonly, --comment, --ssh, --samba, --webdav or --external-reachability")
+			}
+
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			subaccount, err := app.selectSubaccount(id, username)
+			if err != nil {
+				return err
+			}
+
+			input := &robot.StorageBoxSubaccountInput{
+				HomeDirectory:        subaccount.HomeDirectory,
+				Samba:                subaccount.Samba,
+				SSH:                  subaccount.SSH,
+				ExternalReachability: subaccount.ExternalReachability,
+				WebDAV:               subaccount.WebDAV,
+				ReadOnly:             subaccount.ReadOnly,
+				Comment:              subaccount.Comment,
+			}
+
+			if flags.Changed("home-directory") {
+				input.HomeDirectory = homeDirectory
+			}
+
+			if flags.Changed("readonly") {
+				input.ReadOnly = readOnly
+			}
+
+			if flags.Changed("comment") {
+				input.Comment = comment
+			}
+
+			access.apply(cmd, &input.SSH, &input.Samba, &input.WebDAV, &input.ExternalReachability)
+
+			if err := app.client.StorageBoxSubaccountUpdate(id, subaccount.Username, input); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Updated subaccount %s of Storage Box %d", subaccount.Username, id)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "username of the subaccount")
+	cmd.Flags().StringVar(&homeDirectory, "home-directory", "", "new home directory of the subaccount")
+	cmd.Flags().BoolVar(&readOnly, "readonly", false, "allow read access only")
+	cmd.Flags().StringVar(&comment, "comment", "", "new comment of the subaccount")
+	addStorageBoxAccessFlags(cmd, &access, false)
+
+	return cmd
+}
+
+func (app *RobotApp) NewStorageBoxSubaccountDeleteCmd() *cobra.Command {
+	var username string
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:subaccount:delete [id]",
+		Short: "Delete subaccount of Storage Box",
+		Long: `Delete a subaccount of single Storage Box in hetzner account, the files in its home directory
+are kept. Storage Box can be given as argument or chosen interactively, the subaccount can be given
+by flag or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			subaccount, err := app.selectSubaccount(id, username)
+			if err != nil {
+				return err
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Really delete subaccount %s (%s) of Storage Box %d", subaccount.Username, subaccount.HomeDirectory, id),
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			if err := app.client.StorageBoxSubaccountDelete(id, subaccount.Username); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Deleted subaccount %s of Storage Box %d", subaccount.Username, id)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "username of the subaccount")
+
+	return cmd
+}
+
+func (app *RobotApp) NewStorageBoxSnapshotListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storagebox:snapshot:list [id]",
+		Short: "Print list of snapshots of Storage Box",
+		Long: `Print list of snapshots of single Storage Box in hetzner account, Storage Box can be given as
+argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			snapshots, err := app.client.StorageBoxSnapshotGetList(id)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"name", "timestamp", "size", "filesystem size", "automatic", "comment"})
+
+			var size int
+			for _, snapshot := range snapshots {
+				t.AppendRow(table.Row{
+					snapshot.Name,
+					snapshot.Timestamp,
+					formatMB(snapshot.Size),
+					formatMB(snapshot.FilesystemSize),
+					snapshot.Automatic,
+					snapshot.Comment,
+				})
+
+				size += snapshot.Size
+			}
+
+			t.AppendFooter(table.Row{"Total", len(snapshots), formatMB(size), "", "", ""})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewStorageBoxSnapshotCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storagebox:snapshot:create [id]",
+		Short: "Create snapshot of Storage Box",
+		Long: `Create a snapshot of single Storage Box in hetzner account, Storage Box can be given as argument
+or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := app.client.StorageBoxSnapshotCreate(id)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Created snapshot %s of Storage Box %d", snapshot.Name, id)
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewStorageBoxSnapshotDeleteCmd() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:snapshot:delete [id]",
+		Short: "Delete snapshot of Storage Box",
+		Long: `Delete a snapshot of single Storage Box in hetzner account, Storage Box can be given as argument
+or chosen interactively, the snapshot can be given by flag or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := app.selectSnapshot(id, name)
+			if err != nil {
+				return err
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Really delete snapshot %s (%s) of Storage Box %d", snapshot.Name, snapshot.Timestamp, id),
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			if err := app.client.StorageBoxSnapshotDelete(id, snapshot.Name); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Deleted snapshot %s of Storage Box %d", snapshot.Name, id)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name of the snapshot")
+
+	return cmd
+}
+
+func (app *RobotApp) NewStorageBoxSnapshotRevertCmd() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:snapshot:revert [id]",
+		Short: "Revert Storage Box to snapshot",
+		Long: `Revert single Storage Box in hetzner account to a snapshot, all changes made after the snapshot
+are lost. Storage Box can be given as argument or chosen interactively, the snapshot can be given by
+flag or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := app.selectSnapshot(id, name)
+			if err != nil {
+				return err
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Really revert Storage Box %d to snapshot %s (%s), all newer changes are lost", id, snapshot.Name, snapshot.Timestamp),
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			if err := app.client.StorageBoxSnapshotRevert(id, snapshot.Name); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Reverted Storage Box %d to snapshot %s", id, snapshot.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name of the snapshot")
+
+	return cmd
+}
+
+func (app *RobotApp) NewStorageBoxSnapshotPlanGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storagebox:snapshot:plan:get [id]",
+		Short: "Print snapshot plan of Storage Box",
+		Long: `Print the plan for automatic snapshots of single Storage Box in hetzner account, Storage Box can
+be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			plan, err := app.client.StorageBoxSnapshotPlanGet(id)
+			if err != nil {
+				return err
+			}
+
+			renderSnapshotPlan(plan)
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewStorageBoxSnapshotPlanSetCmd() *cobra.Command {
+	var minute, hour, dayOfWeek, dayOfMonth, maxSnapshots int
+	var disable bool
+
+	cmd := &cobra.Command{
+		Use:   "storagebox:snapshot:plan:set [id]",
+		Short: "Configure snapshot plan of Storage Box",
+		Long: `Enable and configure or disable the plan for automatic snapshots of single Storage Box in hetzner
+account, settings not given are kept. Times are given in UTC, without day of week and day of month a
+snapshot is created every day, 0 removes them. Storage Box can be given as argument or chosen
+interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.storageBoxArg(args)
+			if err != nil {
+				return err
+			}
+
+			plan, err := app.client.StorageBoxSnapshotPlanGet(id)
+			if err != nil {
+				return err
+			}
+
+			if disable {
+				plan.Status = robot.SnapshotPlanStatusDisabled
+			} else {
+				flags := cmd.Flags()
+				plan.Status = robot.SnapshotPlanStatusEnabled
+
+				if flags.Changed("minute") {
+					plan.Minute = minute
+				}
+
+				if flags.Changed("hour") {
+					plan.Hour = hour
+				}
+
+				if flags.Changed("max-snapshots") {
+					plan.MaxSnapshots = maxSnapshots
+				}
+
+				if flags.Changed("day-of-week") {
+					plan.DayOfWeek = optionalDay(dayOfWeek)
+				}
+
+				if flags.Changed("day-of-month") {
+					plan.DayOfMonth = optionalDay(dayOfMonth)
+				}
+
+				if err := validateSnapshotPlan(plan); err != nil {
+					return err
+				}
+			}
+
+			updated, err := app.client.StorageBoxSnapshotPlanSet(id, plan)
+			if err != nil {
+				return err
+			}
+
+			renderSnapshotPlan(updated)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&minute, "minute", 0, "minute of the snapshots (0-59)")
+	cmd.Flags().IntVar(&hour, "hour", 0, "hour of the snapshots in UTC (0-23)")
+	cmd.Flags().IntVar(&dayOfWeek, "day-of-week", 0, "day of week of the snapshots (1-7, 1 is monday)")
+	cmd.Flags().IntVar(&dayOfMonth, "day-of-month", 0, "day of month of the snapshots (1-31)")
+	cmd.Flags().IntVar(&maxSnapshots, "max-snapshots", 0, "number of automatic snapshots to keep")
+	cmd.Flags().BoolVar(&disable, "disable", false, "disable automatic snapshots")
+
+	return cmd
+}
+
+func validateSnapshotPlan(plan *robot.StorageBoxSnapshotPlan) error {
+	if plan.Minute < 0 || plan.Minute > 59 {
+		return fmt.Errorf("minute must be between 0 and 59, got %d", plan.Minute)
+	}
+
+	if plan.Hour < 0 || plan.Hour > 23 {
+		return fmt.Errorf("hour must be between 0 and 23, got %d", plan.Hour)
+	}
+
+	if plan.DayOfWeek != nil && (*plan.DayOfWeek < 1 || *plan.DayOfWeek > 7) {
+		return fmt.Errorf("day of week must be between 1 and 7, got %d", *plan.DayOfWeek)
+	}
+
+	if plan.DayOfMonth != nil && (*plan.DayOfMonth < 1 || *plan.DayOfMonth > 31) {
+		return fmt.Errorf("day of month must be between 1 and 31, got %d", *plan.DayOfMonth)
+	}
+
+	if plan.MaxSnapshots < 1 {
+		return fmt.Errorf("max snapshots must be positive, got %d", plan.MaxSnapshots)
+	}
+
+	return nil
+}
+
+// optionalDay returns nil for 0 to remove a day of week or month from the plan
+func optionalDay(day int) *int {
+	if day == 0 {
+		return nil
+	}
+
+	return &day
+}
+
+func renderSnapshotPlan(plan *robot.StorageBoxSnapshotPlan) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"field", "value"})
+	t.AppendRow(table.Row{"status", plan.Status})
+
+	if plan.Status == robot.SnapshotPlanStatusEnabled {
+		t.AppendRow(table.Row{"time (UTC)", fmt.Sprintf("%02d:%02d", plan.Hour, plan.Minute)})
+		t.AppendRow(table.Row{"day of week", formatOptionalDay(plan.DayOfWeek)})
+		t.AppendRow(table.Row{"day of month", formatOptionalDay(plan.DayOfMonth)})
+		t.AppendRow(table.Row{"max snapshots", plan.MaxSnapshots})
+	}
+
+	t.Render()
+}
+
+func formatOptionalDay(day *int) string {
+	if day == nil {
+		return "every"
+	}
+
+	return strconv.Itoa(*day)
+}
+
+// formatMB converts a size in MB to a human readable size
+func formatMB(mb int) string {
+	switch {
+	case mb >= 1024*1024:
+		return fmt.Sprintf("%.2f TB", float64(mb)/1024/1024)
+	case mb >= 1024:
+		return fmt.Sprintf("%.2f GB", float64(mb)/1024)
+	}
+
+	return fmt.Sprintf("%d MB", mb)
+}
+
+func formatPercent(value int, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(value)/float64(total)*100)
+}
+
+// storageBoxArg returns the Storage Box given as argument, the Storage Box is
+// chosen interactively if there is no argument
+func (app *RobotApp) storageBoxArg(args []string) (int, error) {
+	if len(args) > 0 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid Storage Box id %q", args[0])
+		}
+
+		return id, nil
+	}
+
+	storageBoxes, err := app.client.StorageBoxGetList()
+	if err != nil {
+		return 0, err
+	}
+
+	prompt := promptui.Select{
+		Label:             "Select Storage Box",
+		Items:             storageBoxes,
+		Searcher:          getStorageBoxSearcher(storageBoxes),
+		Size:              10,
+		Templates:         getStorageBoxSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return 0, err
+	}
+
+	return storageBoxes[chosenIdx].ID, nil
+}
+
+// selectSubaccount returns the subaccount with the given username, the
+// subaccount is chosen interactively if no username is given
+func (app *RobotApp) selectSubaccount(id int, username string) (*robot.StorageBoxSubaccount, error) {
+	subaccounts, err := app.client.StorageBoxSubaccountGetList(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if username != "" {
+		for i := range subaccounts {
+			if subaccounts[i].Username == username {
+				return &subaccounts[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("subaccount %s of Storage Box %d not found", username, id)
+	}
+
+	if len(subaccounts) == 0 {
+		return nil, fmt.Errorf("Storage Box %d has no subaccounts", id)
+	}
+
+	prompt := promptui.Select{
+		Label: "Select subaccount",
+		Items: subaccounts,
+		Searcher: func(input string, index int) bool {
+			subaccount := subaccounts[index]
+			content := strings.ToLower(subaccount.Username + subaccount.HomeDirectory + subaccount.Comment)
+
+			return strings.Contains(content, strings.ToLower(input))
+		},
+		Size: 10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "→ {{ .Username | green }} ({{ .HomeDirectory | yellow }})",
+			Inactive: "  {{ .Username | cyan }} ({{ .HomeDirectory | red }})",
+			Selected: "→ {{ .Username | cyan }}",
+		},
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &subaccounts[chosenIdx], nil
+}
+
+// selectSnapshot returns the snapshot with the given name, the snapshot is
+// chosen interactively if no name is given
+func (app *RobotApp) selectSnapshot(id int, name string) (*robot.StorageBoxSnapshot, error) {
+	snapshots, err := app.client.StorageBoxSnapshotGetList(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		for i := range snapshots {
+			if snapshots[i].Name == name {
+				return &snapshots[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("snapshot %s of Storage Box %d not found", name, id)
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("Storage Box %d has no snapshots", id)
+	}
+
+	prompt := promptui.Select{
+		Label: "Select snapshot",
+		Items: snapshots,
+		Searcher: func(input string, index int) bool {
+			snapshot := snapshots[index]
+			content := strings.ToLower(snapshot.Name + snapshot.Timestamp + snapshot.Comment)
+
+			return strings.Contains(content, strings.ToLower(input))
+		},
+		Size: 10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "→ {{ .Name | green }} ({{ .Timestamp | yellow }})",
+			Inactive: "  {{ .Name | cyan }} ({{ .Timestamp | red }})",
+			Selected: "→ {{ .Name | cyan }}",
+		},
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshots[chosenIdx], nil
+}
+
+func getStorageBoxSearcher(storageBoxes []robot.StorageBox) func(string, int) bool {
+	return func(input string, index int) bool {
+		storageBox := storageBoxes[index]
+		name := strings.Replace(strings.ToLower(storageBox.Name), " ", "", -1)
+		input = strings.Replace(strings.ToLower(input), " ", "", -1)
+
+		return strings.Contains(name, input) || strings.Contains(storageBox.Login, input) || strings.Contains(strconv.Itoa(storageBox.ID), input)
+	}
+}
+
+func getStorageBoxSelectTemplates() *promptui.SelectTemplates {
+	return &promptui.SelectTemplates{
+		Label:    "{{ . }}?",
+		Active:   "→ {{ .Name | green }} ({{ .ID | yellow }} - {{ .Login | yellow }})",
+		Inactive: "  {{ .Name | cyan }} ({{ .ID | red }} - {{ .Login | blue }})",
+		Selected: "→ {{ .Name | cyan }}",
+		Details: `
+	--------- Selected Storage Box ----------
+	{{ "ID:" | faint }}	          {{ .ID }}
+	{{ "Login:" | faint }}	  {{ .Login }}
+	{{ "Name:" | faint }}	  {{ .Name }}
+	{{ "Product:" | faint }}	  {{ .Product }}
+	{{ "Location:" | faint }}	  {{ .Location }}`,
+	}
+}
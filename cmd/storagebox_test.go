@@ -0,0 +1,207 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+)
+
+func (s *AppSuite) TestStorageBoxListCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []robot.StorageBox{
+		{
+			ID:       123456,
+			Login:    "u12345",
+			Name:     "Backup Server 1",
+			Product:  "BX60",
+			Location: "FSN1",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().StorageBoxGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:list")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestStorageBoxUpdateCommandToggles(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	current := &robot.StorageBox{
+		ID:     123456,
+		Name:   "Backup Server 1",
+		SSH:    true,
+		Samba:  true,
+		WebDAV: true,
+	}
+
+	input := &robot.StorageBoxInput{
+		Name:   "Backup Server 1",
+		SSH:    true,
+		Samba:  false,
+		WebDAV: true,
+		ZFS:    true,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().StorageBoxGet(123456).Times(1).Return(current, nil)
+	mockRobotClient.EXPECT().StorageBoxUpdate(123456, input).Times(1).Return(current, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:update", "123456", "--samba=false", "--zfs")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestStorageBoxUpdateCommandNothingToUpdate(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:update", "123456")
+	c.Assert(err, ErrorMatches, "nothing to update.*")
+}
+
+func (s *AppSuite) TestStorageBoxSubaccountCreateCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	input := &robot.StorageBoxSubaccountInput{
+		HomeDirectory: "backups/db",
+		SSH:           true,
+		ReadOnly:      true,
+	}
+
+	result := &robot.StorageBoxSubaccount{
+		Username:      "u12345-sub1",
+		Password:      "E6jqdPyn4AU2Uw6a",
+		Server:        "u12345-sub1.your-storagebox.de",
+		HomeDirectory: "backups/db",
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().StorageBoxSubaccountCreate(123456, input).Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:subaccount:create", "123456", "--home-directory", "backups/db", "--ssh", "--readonly")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestStorageBoxSubaccountCreateCommandDefaultAccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := &robot.StorageBoxSubaccount{
+		Username:      "u12345-sub1",
+		Password:      "E6jqdPyn4AU2Uw6a",
+		Server:        "u12345-sub1.your-storagebox.de",
+		HomeDirectory: "backups/db",
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().StorageBoxSubaccountCreate(123456, &robot.StorageBoxSubaccountInput{
+		HomeDirectory: "backups/db",
+		SSH:           true,
+	}).Times(1).Return(result, nil)
+	mockRobotClient.EXPECT().StorageBoxSubaccountCreate(123456, &robot.StorageBoxSubaccountInput{
+		HomeDirectory: "backups/db",
+		Samba:         true,
+	}).Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:subaccount:create", "123456", "--home-directory", "backups/db")
+	c.Assert(err, IsNil)
+
+	rootCmd = app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err = executeCommand(rootCmd, "storagebox:subaccount:create", "123456", "--home-directory", "backups/db", "--ssh=false", "--samba")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestStorageBoxSnapshotPlanSetCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	current := &robot.StorageBoxSnapshotPlan{
+		Status: robot.SnapshotPlanStatusDisabled,
+	}
+
+	dayOfWeek := 7
+	plan := &robot.StorageBoxSnapshotPlan{
+		Status:       robot.SnapshotPlanStatusEnabled,
+		Minute:       30,
+		Hour:         3,
+		DayOfWeek:    &dayOfWeek,
+		MaxSnapshots: 4,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().StorageBoxSnapshotPlanGet(123456).Times(1).Return(current, nil)
+	mockRobotClient.EXPECT().StorageBoxSnapshotPlanSet(123456, plan).Times(1).Return(plan, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:snapshot:plan:set", "123456", "--hour", "3", "--minute", "30", "--day-of-week", "7", "--max-snapshots", "4")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestStorageBoxSnapshotPlanSetCommandInvalid(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	current := &robot.StorageBoxSnapshotPlan{
+		Status: robot.SnapshotPlanStatusDisabled,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().StorageBoxSnapshotPlanGet(123456).Times(1).Return(current, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "storagebox:snapshot:plan:set", "123456", "--hour", "24", "--max-snapshots", "4")
+	c.Assert(err, ErrorMatches, "hour must be between 0 and 23, got 24")
+}
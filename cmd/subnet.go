@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+// hostRange is the usable address range of a subnet, the broadcast address
+// is only set for IPv4
+type hostRange struct {
+	Network   string
+	Broadcast string
+	First     string
+	Last      string
+	Count     *big.Int
+}
+
+func (app *RobotApp) NewSubnetGetListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "subnet:list",
+		Short: "Print list of subnets",
+		Long:  "Print list of subnets in the hetzner account including their usable host ranges",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subnets, err := app.client.SubnetGetList()
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"subnet", "gateway", "server_ip", "server_number", "failover", "locked", "traffic_warnings", "usable range"})
+
+			for _, subnet := range subnets {
+				usable := ""
+				if hosts, err := newHostRange(subnet.IP, subnet.Mask); err == nil {
+					usable = hosts.First + " - " + hosts.Last
+				}
+
+				t.AppendRow(table.Row{
+					fmt.Sprintf("%s/%d", subnet.IP, subnet.Mask),
+					subnet.Gateway,
+					subnet.ServerIP,
+					subnet.ServerNumber,
+					subnet.Failover,
+					subnet.Locked,
+					subnet.TrafficWarnings,
+					usable,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "", "", "", "", "Total", len(subnets)})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewSubnetGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "subnet:get [subnet]",
+		Short: "Print single subnet",
+		Long: `Print details of single subnet in hetzner account including the usable host range,
+subnet can be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := app.subnetArg(args)
+			if err != nil {
+				return err
+			}
+
+			subnet, err := app.client.SubnetGet(addr)
+			if err != nil {
+				return err
+			}
+
+			hosts, err := newHostRange(subnet.IP, subnet.Mask)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"ip", subnet.IP})
+			t.AppendRow(table.Row{"mask", subnet.Mask})
+			t.AppendRow(table.Row{"gateway", subnet.Gateway})
+			t.AppendRow(table.Row{"server ip", subnet.ServerIP})
+			t.AppendRow(table.Row{"server number", subnet.ServerNumber})
+			t.AppendRow(table.Row{"failover", subnet.Failover})
+			t.AppendRow(table.Row{"locked", subnet.Locked})
+			t.AppendRow(table.Row{"traffic warnings", subnet.TrafficWarnings})
+			t.AppendRow(table.Row{"traffic hourly (MB)", subnet.TrafficHourly})
+			t.AppendRow(table.Row{"traffic daily (MB)", subnet.TrafficDaily})
+			t.AppendRow(table.Row{"traffic monthly (GB)", subnet.TrafficMonthly})
+			t.AppendRow(table.Row{"network", hosts.Network})
+			if hosts.Broadcast != "" {
+				t.AppendRow(table.Row{"broadcast", hosts.Broadcast})
+			}
+			t.AppendRow(table.Row{"first usable", hosts.First})
+			t.AppendRow(table.Row{"last usable", hosts.Last})
+			t.AppendRow(table.Row{"usable hosts", hosts.Count.String()})
+
+			t.Render()
+			return nil
+		},
+	}
+}
+
+// subnetArg returns the subnet given as argument, the subnet is chosen
+// interactively if there is no argument
+func (app *RobotApp) subnetArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return subnetIP(args[0]), nil
+	}
+
+	subnet, err := app.selectSubnet()
+	if err != nil {
+		return "", err
+	}
+
+	return subnet.IP, nil
+}
+
+// selectSubnet lets the user choose a subnet, errors of the prompt are
+// returned as prompt failed unlike errors fetching the subnets
+func (app *RobotApp) selectSubnet() (*robot.Subnet, error) {
+	subnets, err := app.client.SubnetGetList()
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := promptui.Select{
+		Label:             "Select subnet",
+		Items:             subnets,
+		Searcher:          getSubnetSearcher(subnets),
+		Size:              10,
+		Templates:         getSubnetSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return nil, fmt.Errorf("prompt failed: %s", err)
+	}
+
+	return &subnets[chosenIdx], nil
+}
+
+// newHostRange computes the usable addresses of a subnet. The network and
+// broadcast address of IPv4 subnets are not usable except for /31 and /32,
+// the first address of IPv6 subnets is the subnet router anycast address.
+func newHostRange(ip string, mask int) (*hostRange, error) {
+	_, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", ip, mask))
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet %s/%d", ip, mask)
+	}
+
+	bits := 128
+	if network.IP.To4() != nil {
+		bits = 32
+	}
+
+	base := new(big.Int).SetBytes(network.IP)
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-mask))
+	last := new(big.Int).Sub(new(big.Int).Add(base, size), big.NewInt(1))
+
+	hosts := &hostRange{
+		Network: network.IP.String(),
+		First:   bigToIP(base, bits),
+		Last:    bigToIP(last, bits),
+		Count:   size,
+	}
+
+	switch {
+	case bits == 32 && mask < 31:
+		hosts.Broadcast = bigToIP(last, bits)
+		hosts.First = bigToIP(new(big.Int).Add(base, big.NewInt(1)), bits)
+		hosts.Last = bigToIP(new(big.Int).Sub(last, big.NewInt(1)), bits)
+		hosts.Count = new(big.Int).Sub(size, big.NewInt(2))
+	case bits == 128 && mask < 128:
+		hosts.First = bigToIP(new(big.Int).Add(base, big.NewInt(1)), bits)
+		hosts.Count = new(big.Int).Sub(size, big.NewInt(1))
+	}
+
+	return hosts, nil
+}
+
+func bigToIP(value *big.Int, bits int) string {
+	buf := make([]byte, bits/8)
+	bytes := value.Bytes()
+	copy(buf[len(buf)-len(bytes):], bytes)
+
+	return net.IP(buf).String()
+}
+
+func getSubnetSearcher(subnets []robot.Subnet) func(string, int) bool {
+	return func(input string, index int) bool {
+		subnet := subnets[index]
+		addr := strings.Replace(strings.ToLower(subnet.IP), " ", "", -1)
+		serverIP := strings.Replace(strings.ToLower(subnet.ServerIP), " ", "", -1)
+		input = strings.Replace(strings.ToLower(input), " ", "", -1)
+
+		return strings.Contains(addr, input) || strings.Contains(serverIP, input)
+	}
+}
+
+func getSubnetSelectTemplates() *promptui.SelectTemplates {
+	return &promptui.SelectTemplates{
+		Label:    "{{ . }}?",
+		Active:   "→ {{ .IP | green }}/{{ .Mask | green }} ({{ .ServerIP | yellow }} - {{ .ServerNumber | yellow }})",
+		Inactive: "  {{ .IP | cyan }}/{{ .Mask | cyan }} ({{ .ServerIP | red }} - {{ .ServerNumber | blue }})",
+		Selected: "→ {{ .IP | cyan }}/{{ .Mask | cyan }}",
+		Details: `
+	--------- Selected subnet ----------
+	{{ "Subnet:" | faint }}	          {{ .IP }}/{{ .Mask }}
+	{{ "Gateway:" | faint }}	  {{ .Gateway }}
+	{{ "Server IP:" | faint }}	  {{ .ServerIP }}
+	{{ "Server number:" | faint }}	  {{ .ServerNumber }}`,
+	}
+}
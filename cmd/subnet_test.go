@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+)
+
+func (s *AppSuite) TestSubnetListCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []robot.Subnet{
+		{
+			IP:           "123.123.123.96",
+			Mask:         29,
+			Gateway:      "123.123.123.97",
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+		},
+		{
+			IP:           "2a01:4f8:111:4221::",
+			Mask:         64,
+			Gateway:      "fe80::1",
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "subnet:list")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestSubnetGetCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := &robot.Subnet{
+		IP:           "123.123.123.96",
+		Mask:         29,
+		Gateway:      "123.123.123.97",
+		ServerIP:     "123.123.123.123",
+		ServerNumber: 321,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().SubnetGet("123.123.123.96").Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "subnet:get", "123.123.123.96/29")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestSubnetGetCommandListError(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().SubnetGetList().Times(1).Return(nil, errors.New("unauthorized"))
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "subnet:get")
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *AppSuite) TestNewHostRange(c *C) {
+	tests := []struct {
+		ip        string
+		mask      int
+		broadcast string
+		first     string
+		last      string
+		count     string
+	}{
+		{"123.123.123.96", 29, "123.123.123.103", "123.123.123.97", "123.123.123.102", "6"},
+		{"123.123.123.100", 29, "123.123.123.103", "123.123.123.97", "123.123.123.102", "6"},
+		{"123.123.123.96", 31, "", "123.123.123.96", "123.123.123.97", "2"},
+		{"123.123.123.96", 32, "", "123.123.123.96", "123.123.123.96", "1"},
+		{"2a01:4f8:111:4221::", 64, "", "2a01:4f8:111:4221::1", "2a01:4f8:111:4221:ffff:ffff:ffff:ffff", "18446744073709551615"},
+	}
+
+	for _, test := range tests {
+		hosts, err := cmd.NewHostRange(test.ip, test.mask)
+		c.Assert(err, IsNil)
+		c.Assert(hosts.Broadcast, Equals, test.broadcast)
+		c.Assert(hosts.First, Equals, test.first)
+		c.Assert(hosts.Last, Equals, test.last)
+		c.Assert(hosts.Count.String(), Equals, test.count)
+	}
+
+	_, err := cmd.NewHostRange("123.123.123.96", 33)
+	c.Assert(err, ErrorMatches, "invalid subnet 123.123.123.96/33")
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// date layouts of the traffic range per traffic type
+var trafficLayouts = map[string]string{
+	robot.TrafficTypeDay:   "2006-01-02T15",
+	robot.TrafficTypeMonth: "2006-01-02",
+	robot.TrafficTypeYear:  "2006-01",
+}
+
+type trafficRow struct {
+	IP           string  `json:"ip"`
+	ServerNumber int     `json:"server_number,omitempty"`
+	ServerName   string  `json:"server_name,omitempty"`
+	In           float64 `json:"in"`
+	Out          float64 `json:"out"`
+	Sum          float64 `json:"sum"`
+}
+
+type trafficUsage struct {
+	ServerNumber int     `json:"server_number"`
+	ServerName   string  `json:"server_name"`
+	Included     string  `json:"included"`
+	Used         float64 `json:"used"`
+	UsagePercent float64 `json:"usage_percent"`
+	NearLimit    bool    `json:"near_limit"`
+}
+
+type trafficReport struct {
+	Type    string         `json:"type"`
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	IPs     []trafficRow   `json:"ips"`
+	Servers []trafficUsage `json:"servers,omitempty"`
+}
+
+func (app *RobotApp) NewTrafficShowCmd() *cobra.Command {
+	var sel ipSelector
+	var subnets []string
+	var trafficType, from, to, output string
+	var warnPercent float64
+
+	cmd := &cobra.Command{
+		Use:   "traffic:show",
+		Short: "Print traffic statistics of IP's and subnets",
+		Long: `Print incoming and outgoing traffic in GB of IP's and subnets in the hetzner account. IP's can be
+chosen interactively, given by flags or taken from the servers selected by flags. For monthly
+statistics servers nearing their included traffic are highlighted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output, outputTable, outputJSON, outputCSV); err != nil {
+				return err
+			}
+
+			input, err := newTrafficGetInput(trafficType, from, to, time.Now())
+			if err != nil {
+				return err
+			}
+
+			servers, err := app.client.ServerGetList()
+			if err != nil {
+				return err
+			}
+
+			ipList, err := app.client.IPGetList()
+			if err != nil {
+				return err
+			}
+
+			for _, subnet := range subnets {
+				input.Subnets = append(input.Subnets, subnetIP(subnet))
+			}
+
+			// subnets given alone don't need a selection of IP's
+			if sel.isSet() || len(input.Subnets) == 0 {
+				chosenIPs, err := app.selectIPs(&sel, ipList)
+				if err != nil {
+					return err
+				}
+
+				for _, ip := range chosenIPs {
+					input.IPs = append(input.IPs, ip.IP)
+				}
+			}
+
+			if len(input.IPs) == 0 && len(input.Subnets) == 0 {
+				return errors.New("no IP's or subnets selected")
+			}
+
+			traffic, err := app.client.TrafficGet(input)
+			if err != nil {
+				return err
+			}
+
+			report := newTrafficReport(traffic, input, servers, ipList, warnPercent)
+
+			switch output {
+			case outputJSON:
+				return printJSON(report)
+			case outputCSV:
+				return printCSV(trafficCSV(report))
+			}
+
+			renderTrafficReport(report)
+			return nil
+		},
+	}
+
+	addIPSelectorFlags(cmd, &sel)
+	cmd.Flags().StringSliceVar(&subnets, "subnets", nil, "comma separated subnets")
+	cmd.Flags().StringVar(&trafficType, "range", robot.TrafficTypeMonth, "range of the statistics (day, month, year)")
+	cmd.Flags().StringVar(&from, "from", "", "start of the range, defaults to the start of the current day, month or year")
+	cmd.Flags().StringVar(&to, "to", "", "end of the range, defaults to now")
+	cmd.Flags().Float64Var(&warnPercent, "warn-percent", 80, "highlight servers using more than this percentage of their included traffic")
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format (table, json, csv)")
+
+	return cmd
+}
+
+// newTrafficGetInput builds the range of the statistics, missing bounds
+// default to the current day, month or year up to now
+func newTrafficGetInput(trafficType string, from string, to string, now time.Time) (*robot.TrafficGetInput, error) {
+	layout, ok := trafficLayouts[trafficType]
+	if !ok {
+		return nil, fmt.Errorf("unknown range %q, use one of: day, month, year", trafficType)
+	}
+
+	if from == "" {
+		switch trafficType {
+		case robot.TrafficTypeDay:
+			from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Format(layout)
+		case robot.TrafficTypeMonth:
+			from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format(layout)
+		case robot.TrafficTypeYear:
+			from = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location()).Format(layout)
+		}
+	}
+
+	if to == "" {
+		to = now.Format(layout)
+	}
+
+	for _, value := range []string{from, to} {
+		if _, err := time.Parse(layout, value); err != nil {
+			return nil, fmt.Errorf("invalid date %q for range %s, expected format %s", value, trafficType, layout)
+		}
+	}
+
+	return &robot.TrafficGetInput{
+		Type: trafficType,
+		From: from,
+		To:   to,
+	}, nil
+}
+
+func newTrafficReport(traffic *robot.Traffic, input *robot.TrafficGetInput, servers []models.Server, ipList []models.IP, warnPercent float64) *trafficReport {
+	report := &trafficReport{
+		Type: traffic.Type,
+		From: traffic.From,
+		To:   traffic.To,
+	}
+
+	serverNumbers := make(map[string]int)
+	for _, ip := range ipList {
+		serverNumbers[ip.IP] = ip.ServerNumber
+	}
+
+	serverByNumber := make(map[int]models.Server)
+	for _, server := range servers {
+		serverByNumber[server.ServerNumber] = server
+	}
+
+	usedByServer := make(map[int]float64)
+	var serverOrder []int
+
+	for _, addr := range append(append([]string{}, input.IPs...), input.Subnets...) {
+		data := traffic.Data[addr]
+		row := trafficRow{
+			IP:  addr,
+			In:  data.In,
+			Out: data.Out,
+			Sum: data.Sum,
+		}
+
+		if number, ok := serverNumbers[addr]; ok {
+			row.ServerNumber = number
+			row.ServerName = serverByNumber[number].ServerName
+
+			if _, seen := usedByServer[number]; !seen {
+				serverOrder = append(serverOrder, number)
+			}
+			// only outgoing traffic counts towards the included traffic
+			usedByServer[number] += data.Out
+		}
+
+		report.IPs = append(report.IPs, row)
+	}
+
+	// included traffic is a monthly volume, other ranges can't be compared with it
+	if traffic.Type != robot.TrafficTypeMonth {
+		return report
+	}
+
+	for _, number := range serverOrder {
+		server := serverByNumber[number]
+		usage := trafficUsage{
+			ServerNumber: number,
+			ServerName:   server.ServerName,
+			Included:     server.Traffic,
+			Used:         usedByServer[number],
+		}
+
+		if included, ok := parseTrafficGB(server.Traffic); ok && included > 0 {
+			usage.UsagePercent = usage.Used / included * 100
+			usage.NearLimit = usage.UsagePercent >= warnPercent
+		}
+
+		report.Servers = append(report.Servers, usage)
+	}
+
+	return report
+}
+
+// parseTrafficGB converts the included traffic of a server like "20 TB" to GB,
+// unlimited traffic can't be converted
+func parseTrafficGB(traffic string) (float64, bool) {
+	fields := strings.Fields(traffic)
+	if len(fields) != 2 {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	switch strings.ToUpper(fields[1]) {
+	case "GB":
+		return value, true
+	case "TB":
+		return value * 1024, true
+	}
+
+	return 0, false
+}
+
+func renderTrafficReport(report *trafficReport) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendHeader(table.Row{"ip", "server number", "server name", "in (GB)", "out (GB)", "sum (GB)"})
+
+	var in, out, sum float64
+	for _, row := range report.IPs {
+		t.AppendRow(table.Row{
+			row.IP,
+			row.ServerNumber,
+			row.ServerName,
+			fmt.Sprintf("%.2f", row.In),
+			fmt.Sprintf("%.2f", row.Out),
+			fmt.Sprintf("%.2f", row.Sum),
+		})
+
+		in += row.In
+		out += row.Out
+		sum += row.Sum
+	}
+
+	t.AppendFooter(table.Row{"", "", "Total", fmt.Sprintf("%.2f", in), fmt.Sprintf("%.2f", out), fmt.Sprintf("%.2f", sum)})
+	t.SetCaption(fmt.Sprintf("Traffic from %s to %s", report.From, report.To))
+	t.Render()
+
+	if len(report.Servers) == 0 {
+		return
+	}
+
+	tUsage := table.NewWriter()
+	tUsage.SetOutputMirror(os.Stdout)
+
+	tUsage.AppendHeader(table.Row{"server number", "server name", "included", "used out (GB)", "usage"})
+
+	for _, usage := range report.Servers {
+		percent := "-"
+		if usage.UsagePercent > 0 {
+			percent = fmt.Sprintf("%.1f%%", usage.UsagePercent)
+		}
+
+		if usage.NearLimit {
+			percent = color.RedString(percent)
+		}
+
+		tUsage.AppendRow(table.Row{
+			usage.ServerNumber,
+			usage.ServerName,
+			usage.Included,
+			fmt.Sprintf("%.2f", usage.Used),
+			percent,
+		})
+	}
+
+	tUsage.SetCaption("Usage of included traffic")
+	tUsage.Render()
+}
+
+func trafficCSV(report *trafficReport) ([]string, [][]string) {
+	header := []string{"ip", "server_number", "server_name", "in", "out", "sum"}
+
+	var rows [][]string
+	for _, row := range report.IPs {
+		rows = append(rows, []string{
+			row.IP,
+			strconv.Itoa(row.ServerNumber),
+			row.ServerName,
+			strconv.FormatFloat(row.In, 'f', 4, 64),
+			strconv.FormatFloat(row.Out, 'f', 4, 64),
+			strconv.FormatFloat(row.Sum, 'f', 4, 64),
+		})
+	}
+
+	return header, rows
+}
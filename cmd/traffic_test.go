@@ -0,0 +1,106 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func newTrafficMockClient(ctrl *gomock.Controller) *mock.MockRobotClient {
+	servers := []models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-prod-42",
+			Traffic:      "1 TB",
+		},
+	}
+
+	ips := []models.IP{
+		{
+			IP:           "123.123.123.123",
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+		},
+	}
+
+	traffic := &robot.Traffic{
+		Type: robot.TrafficTypeMonth,
+		From: "2020-01-01",
+		To:   "2020-01-31",
+		Data: map[string]robot.TrafficData{
+			"123.123.123.123": {In: 100, Out: 900, Sum: 1000},
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().ServerGetList().AnyTimes().Return(servers, nil)
+	mockRobotClient.EXPECT().IPGetList().Times(1).Return(ips, nil)
+	mockRobotClient.EXPECT().TrafficGet(gomock.Any()).Times(1).Return(traffic, nil)
+
+	return mockRobotClient
+}
+
+func (s *AppSuite) TestTrafficShowCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newTrafficMockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "traffic:show", "--ips", "123.123.123.123", "--from", "2020-01-01", "--to", "2020-01-31")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestTrafficShowCommandServers(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newTrafficMockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "traffic:show", "--servers", "app-prod-42", "--output", "json")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestTrafficShowCommandCSV(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := cmd.NewRobotApp(newTrafficMockClient(ctrl), log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "traffic:show", "--ips", "123.123.123.123", "--output", "csv")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestTrafficShowCommandInvalidRange(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "traffic:show", "--ips", "123.123.123.123", "--range", "week")
+	c.Assert(err, ErrorMatches, `unknown range "week".*`)
+
+	_, err = executeCommand(rootCmd, "traffic:show", "--ips", "123.123.123.123", "--range", "month", "--from", "2020-01")
+	c.Assert(err, ErrorMatches, `invalid date "2020-01".*`)
+}
@@ -0,0 +1,437 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// range of VLAN ID's allowed for vSwitches
+const (
+	vlanMin = 4000
+	vlanMax = 4091
+)
+
+func (app *RobotApp) NewVSwitchGetListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vswitch:list",
+		Short: "Print list of vSwitches",
+		Long:  "Print list of vSwitches in the hetzner account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vSwitches, err := app.client.VSwitchGetList()
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"id", "name", "vlan", "cancelled"})
+
+			for _, vSwitch := range vSwitches {
+				t.AppendRow(table.Row{
+					vSwitch.ID,
+					vSwitch.Name,
+					vSwitch.Vlan,
+					vSwitch.Cancelled,
+				})
+			}
+
+			t.AppendFooter(table.Row{"", "", "Total", len(vSwitches)})
+			t.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewVSwitchGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vswitch:get [id]",
+		Short: "Print single vSwitch",
+		Long: `Print details of single vSwitch in hetzner account including attached servers, subnets and
+cloud networks, vSwitch can be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := app.vSwitchArg(args)
+			if err != nil {
+				return err
+			}
+
+			vSwitch, err := app.client.VSwitchGet(id)
+			if err != nil {
+				return err
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+
+			t.AppendHeader(table.Row{"field", "value"})
+			t.AppendRow(table.Row{"id", vSwitch.ID})
+			t.AppendRow(table.Row{"name", vSwitch.Name})
+			t.AppendRow(table.Row{"vlan", vSwitch.Vlan})
+			t.AppendRow(table.Row{"cancelled", vSwitch.Cancelled})
+
+			t.Render()
+
+			tServers := table.NewWriter()
+			tServers.SetOutputMirror(os.Stdout)
+
+			tServers.AppendHeader(table.Row{"server number", "server ip", "ipv6 net", "status"})
+
+			for _, server := range vSwitch.Servers {
+				tServers.AppendRow(table.Row{
+					server.ServerNumber,
+					server.ServerIP,
+					server.ServerIPv6Net,
+					server.Status,
+				})
+			}
+
+			tServers.SetCaption("Servers")
+			tServers.Render()
+
+			tNetworks := table.NewWriter()
+			tNetworks.SetOutputMirror(os.Stdout)
+
+			tNetworks.AppendHeader(table.Row{"type", "id", "network", "gateway"})
+
+			for _, subnet := range vSwitch.Subnets {
+				tNetworks.AppendRow(table.Row{
+					"subnet",
+					"",
+					fmt.Sprintf("%s/%d", subnet.IP, subnet.Mask),
+					subnet.Gateway,
+				})
+			}
+
+			for _, network := range vSwitch.CloudNetworks {
+				tNetworks.AppendRow(table.Row{
+					"cloud network",
+					network.ID,
+					fmt.Sprintf("%s/%d", network.IP, network.Mask),
+					network.Gateway,
+				})
+			}
+
+			tNetworks.SetCaption("Subnets and cloud networks")
+			tNetworks.Render()
+
+			return nil
+		},
+	}
+}
+
+func (app *RobotApp) NewVSwitchCreateCmd() *cobra.Command {
+	var input robot.VSwitchInput
+
+	cmd := &cobra.Command{
+		Use:   "vswitch:create",
+		Short: "Create vSwitch",
+		Long:  fmt.Sprintf("Create a vSwitch with the given name and VLAN ID (%d-%d) in the hetzner account", vlanMin, vlanMax),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if input.Name == "" {
+				return errors.New("name must not be empty")
+			}
+
+			if err := validateVlan(input.Vlan); err != nil {
+				return err
+			}
+
+			vSwitch, err := app.client.VSwitchCreate(&input)
+			if err != nil {
+				return err
+			}
+
+			app.logger.Infof("Created vSwitch %d (%s, VLAN %d)", vSwitch.ID, vSwitch.Name, vSwitch.Vlan)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&input.Name, "name", "", "name of the vSwitch")
+	cmd.Flags().IntVar(&input.Vlan, "vlan", 0, "VLAN ID of the vSwitch")
+
+	return cmd
+}
+
+func (app *RobotApp) NewVSwitchUpdateCmd() *cobra.Command {
+	var name string
+	var vlan int
+
+	cmd := &cobra.Command{
+		Use:   "vswitch:update [id]",
+		Short: "Rename vSwitch or change its VLAN ID",
+		Long: `Change name or VLAN ID of single vSwitch in hetzner account, values not given are kept.
+vSwitch can be given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("vlan") {
+				return errors.New("nothing to update, use --name or --vlan")
+			}
+
+			id, err := app.vSwitchArg(args)
+			if err != nil {
+				return err
+			}
+
+			vSwitch, err := app.client.VSwitchGet(id)
+			if err != nil {
+				return err
+			}
+
+			input := &robot.VSwitchInput{
+				Name: vSwitch.Name,
+				Vlan: vSwitch.Vlan,
+			}
+
+			if cmd.Flags().Changed("name") {
+				input.Name = name
+			}
+
+			if cmd.Flags().Changed("vlan") {
+				if err := validateVlan(vlan); err != nil {
+					return err
+				}
+
+				input.Vlan = vlan
+			}
+
+			if err := app.client.VSwitchUpdate(id, input); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Updated vSwitch %d (%s, VLAN %d)", id, input.Name, input.Vlan)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "new name of the vSwitch")
+	cmd.Flags().IntVar(&vlan, "vlan", 0, "new VLAN ID of the vSwitch")
+
+	return cmd
+}
+
+func (app *RobotApp) NewVSwitchAddServersCmd() *cobra.Command {
+	var sel serverSelector
+
+	cmd := &cobra.Command{
+		Use:   "vswitch:add-servers [id]",
+		Short: "Attach selected servers to vSwitch",
+		Long: `Attach selected servers to single vSwitch in hetzner account, vSwitch can be given as argument
+or chosen interactively, servers can be chosen interactively or by flags`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.changeVSwitchServers(args, &sel, "attach", app.client.VSwitchAddServers)
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+
+	return cmd
+}
+
+func (app *RobotApp) NewVSwitchRemoveServersCmd() *cobra.Command {
+	var sel serverSelector
+
+	cmd := &cobra.Command{
+		Use:   "vswitch:remove-servers [id]",
+		Short: "Detach selected servers from vSwitch",
+		Long: `Detach selected servers from single vSwitch in hetzner account, vSwitch can be given as argument
+or chosen interactively, servers can be chosen interactively or by flags`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.changeVSwitchServers(args, &sel, "detach", app.client.VSwitchRemoveServers)
+		},
+	}
+
+	addServerSelectorFlags(cmd, &sel)
+
+	return cmd
+}
+
+// changeVSwitchServers selects a vSwitch and servers and applies the change
+// after a confirmation, all servers are changed with a single request
+func (app *RobotApp) changeVSwitchServers(args []string, sel *serverSelector, action string, change func(int, []string) error) error {
+	id, err := app.vSwitchArg(args)
+	if err != nil {
+		return err
+	}
+
+	vSwitch, err := app.client.VSwitchGet(id)
+	if err != nil {
+		return err
+	}
+
+	chosenServers, err := app.selectServers(sel)
+	if err != nil {
+		return err
+	}
+
+	attached := make(map[int]bool)
+	for _, server := range vSwitch.Servers {
+		attached[server.ServerNumber] = true
+	}
+
+	for _, server := range chosenServers {
+		if action == "attach" && attached[server.ServerNumber] {
+			return fmt.Errorf("server %s (%d) is already attached to vSwitch %d", server.ServerName, server.ServerNumber, vSwitch.ID)
+		}
+
+		if action == "detach" && !attached[server.ServerNumber] {
+			return fmt.Errorf("server %s (%d) is not attached to vSwitch %d", server.ServerName, server.ServerNumber, vSwitch.ID)
+		}
+	}
+
+	color.Cyan(fmt.Sprintf("vSwitch %d (%s, VLAN %d)", vSwitch.ID, vSwitch.Name, vSwitch.Vlan))
+
+	if err := app.confirmServers(chosenServers, action); err != nil {
+		app.logger.Errorln("Prompt failed: ", err)
+		return nil
+	}
+
+	if err := change(vSwitch.ID, vSwitchServerRefs(chosenServers)); err != nil {
+		return err
+	}
+
+	app.logger.Infof("Requested to %s %d servers, the change is processed in the background", action, len(chosenServers))
+	return nil
+}
+
+func (app *RobotApp) NewVSwitchCancelCmd() *cobra.Command {
+	var date string
+
+	cmd := &cobra.Command{
+		Use:   "vswitch:cancel [id]",
+		Short: "Cancel vSwitch",
+		Long: `Cancel single vSwitch in hetzner account immediately or at the given date, vSwitch can be
+given as argument or chosen interactively`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if date != "now" {
+				if _, err := time.Parse("2006-01-02", date); err != nil {
+					return fmt.Errorf("invalid cancellation date %q, expected format 2006-01-02 or now", date)
+				}
+			}
+
+			id, err := app.vSwitchArg(args)
+			if err != nil {
+				return err
+			}
+
+			vSwitch, err := app.client.VSwitchGet(id)
+			if err != nil {
+				return err
+			}
+
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Really cancel vSwitch %d (%s, %d servers attached) %s", vSwitch.ID, vSwitch.Name, len(vSwitch.Servers), date),
+				IsConfirm: true,
+				Stdout:    app.promptOutput(),
+			}
+
+			if _, err := confirmPrompt.Run(); err != nil {
+				app.logger.Errorln("Prompt failed: ", err)
+				return nil
+			}
+
+			if err := app.client.VSwitchCancel(id, date); err != nil {
+				return err
+			}
+
+			app.logger.Infof("Cancelled vSwitch %d", id)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "now", "cancellation date (2006-01-02) or now")
+
+	return cmd
+}
+
+// vSwitchArg returns the vSwitch given as argument, the vSwitch is chosen
+// interactively if there is no argument
+func (app *RobotApp) vSwitchArg(args []string) (int, error) {
+	if len(args) > 0 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid vSwitch id %q", args[0])
+		}
+
+		return id, nil
+	}
+
+	vSwitches, err := app.client.VSwitchGetList()
+	if err != nil {
+		return 0, err
+	}
+
+	prompt := promptui.Select{
+		Label:             "Select vSwitch",
+		Items:             vSwitches,
+		Searcher:          getVSwitchSearcher(vSwitches),
+		Size:              10,
+		Templates:         getVSwitchSelectTemplates(),
+		StartInSearchMode: true,
+		Stdout:            app.promptOutput(),
+	}
+
+	chosenIdx, _, err := prompt.Run()
+	if err != nil {
+		return 0, err
+	}
+
+	return vSwitches[chosenIdx].ID, nil
+}
+
+func validateVlan(vlan int) error {
+	if vlan < vlanMin || vlan > vlanMax {
+		return fmt.Errorf("VLAN ID must be between %d and %d, got %d", vlanMin, vlanMax, vlan)
+	}
+
+	return nil
+}
+
+func vSwitchServerRefs(servers []models.Server) []string {
+	var refs []string
+	for _, server := range servers {
+		refs = append(refs, server.ServerIP)
+	}
+
+	return refs
+}
+
+func getVSwitchSearcher(vSwitches []robot.VSwitch) func(string, int) bool {
+	return func(input string, index int) bool {
+		vSwitch := vSwitches[index]
+		name := strings.Replace(strings.ToLower(vSwitch.Name), " ", "", -1)
+		input = strings.Replace(strings.ToLower(input), " ", "", -1)
+
+		return strings.Contains(name, input) || strings.Contains(strconv.Itoa(vSwitch.ID), input) || strings.Contains(strconv.Itoa(vSwitch.Vlan), input)
+	}
+}
+
+func getVSwitchSelectTemplates() *promptui.SelectTemplates {
+	return &promptui.SelectTemplates{
+		Label:    "{{ . }}?",
+		Active:   "→ {{ .Name | green }} ({{ .ID | yellow }} - VLAN {{ .Vlan | yellow }})",
+		Inactive: "  {{ .Name | cyan }} ({{ .ID | red }} - VLAN {{ .Vlan | blue }})",
+		Selected: "→ {{ .Name | cyan }}",
+		Details: `
+	--------- Selected vSwitch ----------
+	{{ "ID:" | faint }}	          {{ .ID }}
+	{{ "Name:" | faint }}	  {{ .Name }}
+	{{ "VLAN:" | faint }}	  {{ .Vlan }}
+	{{ "Cancelled:" | faint }}	  {{ .Cancelled }}`,
+	}
+}
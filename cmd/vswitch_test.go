@@ -0,0 +1,195 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/cmd"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *AppSuite) TestVSwitchListCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []robot.VSwitch{
+		{
+			ID:   4321,
+			Name: "vswitch 1",
+			Vlan: 4000,
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().VSwitchGetList().Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:list")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestVSwitchGetCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := &robot.VSwitch{
+		ID:   4321,
+		Name: "vswitch 1",
+		Vlan: 4000,
+		Servers: []robot.VSwitchServer{
+			{
+				ServerIP:     "123.123.123.123",
+				ServerNumber: 321,
+				Status:       "ready",
+			},
+		},
+		Subnets: []robot.VSwitchSubnet{
+			{
+				IP:      "213.239.252.48",
+				Mask:    29,
+				Gateway: "213.239.252.49",
+			},
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().VSwitchGet(4321).Times(1).Return(result, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:get", "4321")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestVSwitchCreateCommandSuccess(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	input := &robot.VSwitchInput{
+		Name: "vswitch 1",
+		Vlan: 4000,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().VSwitchCreate(input).Times(1).Return(&robot.VSwitch{ID: 4321, Name: "vswitch 1", Vlan: 4000}, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:create", "--name", "vswitch 1", "--vlan", "4000")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestVSwitchCreateCommandInvalidVlan(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:create", "--name", "vswitch 1", "--vlan", "100")
+	c.Assert(err, ErrorMatches, "VLAN ID must be between 4000 and 4091, got 100")
+}
+
+func (s *AppSuite) TestVSwitchUpdateCommandKeepsName(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	current := &robot.VSwitch{
+		ID:   4321,
+		Name: "vswitch 1",
+		Vlan: 4000,
+	}
+
+	input := &robot.VSwitchInput{
+		Name: "vswitch 1",
+		Vlan: 4010,
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().VSwitchGet(4321).Times(1).Return(current, nil)
+	mockRobotClient.EXPECT().VSwitchUpdate(4321, input).Times(1).Return(nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:update", "4321", "--vlan", "4010")
+	c.Assert(err, IsNil)
+}
+
+func (s *AppSuite) TestVSwitchRemoveServersCommandNotAttached(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	servers := []models.Server{
+		{
+			ServerIP:     "124.124.124.124",
+			ServerNumber: 421,
+			ServerName:   "app-prod-84",
+		},
+	}
+
+	current := &robot.VSwitch{
+		ID:   4321,
+		Name: "vswitch 1",
+		Vlan: 4000,
+		Servers: []robot.VSwitchServer{
+			{
+				ServerIP:     "123.123.123.123",
+				ServerNumber: 321,
+			},
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+	mockRobotClient.EXPECT().VSwitchGet(4321).Times(1).Return(current, nil)
+	mockRobotClient.EXPECT().ServerGetList().Times(1).Return(servers, nil)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:remove-servers", "4321", "--servers", "app-prod-84")
+	c.Assert(err, ErrorMatches, `server app-prod-84 \(421\) is not attached to vSwitch 4321`)
+}
+
+func (s *AppSuite) TestVSwitchCancelCommandInvalidDate(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().SetUserAgent(gomock.Any()).Times(1)
+
+	app := cmd.NewRobotApp(mockRobotClient, log.StandardLogger())
+
+	rootCmd := app.NewRootCommand(log.StandardLogger())
+	rootCmd.SetErr(log.StandardLogger().Out)
+
+	_, err := executeCommand(rootCmd, "vswitch:cancel", "4321", "--date", "tomorrow")
+	c.Assert(err, ErrorMatches, `invalid cancellation date "tomorrow".*`)
+}
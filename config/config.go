@@ -1,6 +1,20 @@
 package config
 
+import "time"
+
 type Config struct {
-	User     string
-	Password string
+	User           string
+	Password       string
+	Profile        string        `default:"default"`
+	CacheDir       string        `split_words:"true"`
+	CacheTTL       time.Duration `split_words:"true" default:"5m"`
+	HistoryDir     string        `split_words:"true"`
+	RequestTimeout time.Duration `split_words:"true" default:"1m"`
+	RetryMax       int           `split_words:"true" default:"5"`
+	RetryDelay     time.Duration `split_words:"true" default:"2s"`
+	RetryMaxWait   time.Duration `split_words:"true" default:"5m"`
+
+	NotifyURL     string        `split_words:"true"`
+	NotifyFormat  string        `split_words:"true" default:"webhook"`
+	NotifyTimeout time.Duration `split_words:"true" default:"10s"`
 }
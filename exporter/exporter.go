@@ -0,0 +1,329 @@
+package exporter
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	collectorServers   = "servers"
+	collectorFailovers = "failovers"
+	collectorTraffic   = "traffic"
+	collectorRescue    = "rescue"
+)
+
+// Options selects the collected metrics, traffic and rescue status cost
+// additional requests per poll
+type Options struct {
+	Interval time.Duration
+	Traffic  bool
+	Rescue   bool
+}
+
+// Exporter polls the webservice periodically and serves the latest metrics
+// in the Prometheus text format. Metrics of a failed collector keep their last
+// value until the next successful poll.
+type Exporter struct {
+	client robot.RobotClient
+	logger *log.Logger
+	opts   Options
+	now    func() time.Time
+
+	mu          sync.Mutex
+	families    map[string][]*family
+	requests    map[string]float64
+	errors      map[string]float64
+	lastSuccess time.Time
+	duration    time.Duration
+}
+
+func New(client robot.RobotClient, logger *log.Logger, opts Options) *Exporter {
+	return &Exporter{
+		client:   client,
+		logger:   logger,
+		opts:     opts,
+		now:      time.Now,
+		families: make(map[string][]*family),
+		requests: make(map[string]float64),
+		errors:   make(map[string]float64),
+	}
+}
+
+// Run collects the metrics immediately and then once per interval until stop
+// is closed
+func (e *Exporter) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(e.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Collect(); err != nil {
+			e.logger.Warnf("Collecting metrics failed: %s", err)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect polls the webservice once, the first error is returned after all
+// collectors ran
+func (e *Exporter) Collect() error {
+	start := e.now()
+
+	var servers []models.Server
+	err := e.call("ServerGetList", func() (err error) {
+		servers, err = e.client.ServerGetList()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	collectors := []struct {
+		name    string
+		enabled bool
+		collect func([]models.Server) ([]*family, error)
+	}{
+		{collectorServers, true, e.collectServers},
+		{collectorFailovers, true, e.collectFailovers},
+		{collectorTraffic, e.opts.Traffic, e.collectTraffic},
+		{collectorRescue, e.opts.Rescue, e.collectRescue},
+	}
+
+	var firstErr error
+	for _, collector := range collectors {
+		if !collector.enabled {
+			continue
+		}
+
+		families, err := collector.collect(servers)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %s", collector.name, err)
+			}
+			continue
+		}
+
+		e.mu.Lock()
+		e.families[collector.name] = families
+		e.mu.Unlock()
+	}
+
+	if firstErr == nil {
+		e.mu.Lock()
+		e.lastSuccess = e.now()
+		e.duration = e.now().Sub(start)
+		e.mu.Unlock()
+	}
+
+	return firstErr
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+
+	var families []*family
+	for _, name := range []string{collectorServers, collectorFailovers, collectorTraffic, collectorRescue} {
+		families = append(families, e.families[name]...)
+	}
+
+	families = append(families,
+		counterFamily("hrobot_api_requests_total", "Requests sent to the robot webservice.", e.requests),
+		counterFamily("hrobot_api_errors_total", "Failed requests to the robot webservice.", e.errors),
+	)
+
+	if !e.lastSuccess.IsZero() {
+		lastSuccess := newFamily("hrobot_exporter_last_success_timestamp_seconds", typeGauge, "Time of the last poll without errors.")
+		lastSuccess.add(float64(e.lastSuccess.Unix()))
+		duration := newFamily("hrobot_exporter_collect_duration_seconds", typeGauge, "Duration of the last poll without errors.")
+		duration.add(e.duration.Seconds())
+		families = append(families, lastSuccess, duration)
+	}
+
+	e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := writeFamilies(w, families); err != nil {
+		e.logger.Warnf("Writing metrics failed: %s", err)
+	}
+}
+
+func (e *Exporter) collectServers(servers []models.Server) ([]*family, error) {
+	counts := make(map[[3]string]int)
+	for _, server := range servers {
+		counts[[3]string{server.Dc, server.Product, server.Status}]++
+	}
+
+	var keys [][3]string
+	for key := range counts {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Join(keys[i][:], "\x00") < strings.Join(keys[j][:], "\x00")
+	})
+
+	serverCount := newFamily("hrobot_servers", typeGauge, "Number of servers by datacenter, product and status.")
+	for _, key := range keys {
+		serverCount.add(float64(counts[key]), "dc", key[0], "product", key[1], "status", key[2])
+	}
+
+	cancelled := newFamily("hrobot_server_cancelled", typeGauge, "Whether the server is cancelled.")
+	for _, server := range servers {
+		cancelled.add(boolValue(server.Cancelled), serverLabels(server)...)
+	}
+
+	return []*family{serverCount, cancelled}, nil
+}
+
+func (e *Exporter) collectFailovers(servers []models.Server) ([]*family, error) {
+	var failovers []models.Failover
+	err := e.call("FailoverGetList", func() (err error) {
+		failovers, err = e.client.FailoverGetList()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := serverNames(servers)
+
+	active := newFamily("hrobot_failover_active_server", typeGauge, "Server the failover IP is routed to, always 1.")
+	routedToOwner := newFamily("hrobot_failover_routed_to_owner", typeGauge, "Whether the failover IP is routed to the server it belongs to.")
+
+	for _, failover := range failovers {
+		active.add(1,
+			"ip", failover.IP,
+			"server_ip", failover.ServerIP,
+			"active_server_ip", failover.ActiveServerIP,
+			"active_server_name", names[failover.ActiveServerIP],
+		)
+		routedToOwner.add(boolValue(failover.ActiveServerIP == failover.ServerIP), "ip", failover.IP)
+	}
+
+	return []*family{active, routedToOwner}, nil
+}
+
+func (e *Exporter) collectTraffic(servers []models.Server) ([]*family, error) {
+	var ips []models.IP
+	err := e.call("IPGetList", func() (err error) {
+		ips, err = e.client.IPGetList()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	in := newFamily("hrobot_traffic_in_gigabytes", typeGauge, "Incoming traffic of the current month per IP in GB.")
+	out := newFamily("hrobot_traffic_out_gigabytes", typeGauge, "Outgoing traffic of the current month per IP in GB.")
+
+	if len(ips) == 0 {
+		return []*family{in, out}, nil
+	}
+
+	now := e.now()
+	input := &robot.TrafficGetInput{
+		Type: robot.TrafficTypeMonth,
+		From: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02"),
+		To:   now.Format("2006-01-02"),
+	}
+
+	for _, ip := range ips {
+		input.IPs = append(input.IPs, ip.IP)
+	}
+
+	var traffic *robot.Traffic
+	err = e.call("TrafficGet", func() (err error) {
+		traffic, err = e.client.TrafficGet(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := serverNames(servers)
+
+	for _, ip := range ips {
+		data, ok := traffic.Data[ip.IP]
+		if !ok {
+			continue
+		}
+
+		in.add(data.In, "ip", ip.IP, "server_name", names[ip.ServerIP])
+		out.add(data.Out, "ip", ip.IP, "server_name", names[ip.ServerIP])
+	}
+
+	return []*family{in, out}, nil
+}
+
+func (e *Exporter) collectRescue(servers []models.Server) ([]*family, error) {
+	active := newFamily("hrobot_rescue_active", typeGauge, "Whether the rescue system is activated for the next boot.")
+
+	for _, server := range servers {
+		var rescue *models.Rescue
+		err := e.call("BootRescueGet", func() (err error) {
+			rescue, err = e.client.BootRescueGet(server.ServerIP)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		active.add(boolValue(rescue.Active), serverLabels(server)...)
+	}
+
+	return []*family{active}, nil
+}
+
+// call counts the request and its failure, retries of the client are not
+// counted separately
+func (e *Exporter) call(name string, fn func() error) error {
+	err := fn()
+
+	e.mu.Lock()
+	e.requests[name]++
+	if err != nil {
+		e.errors[name]++
+	}
+	e.mu.Unlock()
+
+	return err
+}
+
+func serverLabels(server models.Server) []string {
+	return []string{
+		"server_number", strconv.Itoa(server.ServerNumber),
+		"server_name", server.ServerName,
+		"server_ip", server.ServerIP,
+	}
+}
+
+func serverNames(servers []models.Server) map[string]string {
+	names := make(map[string]string)
+	for _, server := range servers {
+		names[server.ServerIP] = server.ServerName
+	}
+
+	return names
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
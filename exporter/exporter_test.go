@@ -0,0 +1,140 @@
+package exporter_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/exporter"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type ExporterSuite struct{}
+
+var _ = Suite(&ExporterSuite{})
+
+var testServers = []models.Server{
+	{ServerIP: "1.1.1.1", ServerNumber: 1, ServerName: "app-1", Product: "EX41", Dc: "FSN1-DC1", Status: "ready"},
+	{ServerIP: "2.2.2.2", ServerNumber: 2, ServerName: "app-2", Product: "EX41", Dc: "FSN1-DC1", Status: "ready", Cancelled: true},
+	{ServerIP: "3.3.3.3", ServerNumber: 3, ServerName: "db-1", Product: "PX62", Dc: "NBG1-DC3", Status: "ready"},
+}
+
+func scrape(c *C, e *exporter.Exporter) string {
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := ioutil.ReadAll(recorder.Body)
+	c.Assert(err, IsNil)
+
+	return string(body)
+}
+
+func (s *ExporterSuite) TestCollect(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	failovers := []models.Failover{
+		{IP: "4.4.4.4", ServerIP: "1.1.1.1", ActiveServerIP: "3.3.3.3"},
+	}
+	ips := []models.IP{
+		{IP: "1.1.1.1", ServerIP: "1.1.1.1"},
+	}
+	traffic := &robot.Traffic{
+		Data: map[string]robot.TrafficData{
+			"1.1.1.1": {In: 1.5, Out: 20},
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Return(testServers, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Return(failovers, nil)
+	mockRobotClient.EXPECT().IPGetList().Return(ips, nil)
+	mockRobotClient.EXPECT().TrafficGet(gomock.Any()).DoAndReturn(func(input *robot.TrafficGetInput) (*robot.Traffic, error) {
+		c.Assert(input.Type, Equals, robot.TrafficTypeMonth)
+		c.Assert(input.IPs, DeepEquals, []string{"1.1.1.1"})
+		return traffic, nil
+	})
+	mockRobotClient.EXPECT().BootRescueGet("1.1.1.1").Return(&models.Rescue{Active: true}, nil)
+	mockRobotClient.EXPECT().BootRescueGet("2.2.2.2").Return(&models.Rescue{}, nil)
+	mockRobotClient.EXPECT().BootRescueGet("3.3.3.3").Return(&models.Rescue{}, nil)
+
+	e := exporter.New(mockRobotClient, log.New(), exporter.Options{Interval: time.Minute, Traffic: true, Rescue: true})
+	c.Assert(e.Collect(), IsNil)
+
+	body := scrape(c, e)
+
+	for _, line := range []string{
+		"# TYPE hrobot_servers gauge\n",
+		`hrobot_servers{dc="FSN1-DC1",product="EX41",status="ready"} 2` + "\n",
+		`hrobot_servers{dc="NBG1-DC3",product="PX62",status="ready"} 1` + "\n",
+		`hrobot_server_cancelled{server_number="2",server_name="app-2",server_ip="2.2.2.2"} 1` + "\n",
+		`hrobot_server_cancelled{server_number="3",server_name="db-1",server_ip="3.3.3.3"} 0` + "\n",
+		`hrobot_failover_active_server{ip="4.4.4.4",server_ip="1.1.1.1",active_server_ip="3.3.3.3",active_server_name="db-1"} 1` + "\n",
+		`hrobot_failover_routed_to_owner{ip="4.4.4.4"} 0` + "\n",
+		`hrobot_traffic_in_gigabytes{ip="1.1.1.1",server_name="app-1"} 1.5` + "\n",
+		`hrobot_traffic_out_gigabytes{ip="1.1.1.1",server_name="app-1"} 20` + "\n",
+		`hrobot_rescue_active{server_number="1",server_name="app-1",server_ip="1.1.1.1"} 1` + "\n",
+		"# TYPE hrobot_api_requests_total counter\n",
+		`hrobot_api_requests_total{call="BootRescueGet"} 3` + "\n",
+		`hrobot_api_requests_total{call="ServerGetList"} 1` + "\n",
+		"hrobot_exporter_last_success_timestamp_seconds ",
+	} {
+		c.Check(strings.Contains(body, line), Equals, true, Commentf("missing %q", line))
+	}
+}
+
+func (s *ExporterSuite) TestCollectKeepsMetricsOfFailedCollector(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	failovers := []models.Failover{
+		{IP: "4.4.4.4", ServerIP: "1.1.1.1", ActiveServerIP: "1.1.1.1"},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Times(2).Return(testServers, nil)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().FailoverGetList().Return(failovers, nil),
+		mockRobotClient.EXPECT().FailoverGetList().Return(nil, errors.New("rate limit exceeded")),
+	)
+
+	e := exporter.New(mockRobotClient, log.New(), exporter.Options{Interval: time.Minute})
+	c.Assert(e.Collect(), IsNil)
+	c.Assert(e.Collect(), ErrorMatches, "failovers: rate limit exceeded")
+
+	body := scrape(c, e)
+
+	c.Check(body, Matches, `(?s).*hrobot_failover_routed_to_owner\{ip="4\.4\.4\.4"\} 1\n.*`)
+	c.Check(body, Matches, `(?s).*hrobot_api_requests_total\{call="FailoverGetList"\} 2\n.*`)
+	c.Check(body, Matches, `(?s).*hrobot_api_errors_total\{call="FailoverGetList"\} 1\n.*`)
+	c.Check(body, Not(Matches), `(?s).*hrobot_traffic_in_gigabytes.*`)
+}
+
+func (s *ExporterSuite) TestCollectServerListFailed(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Return(nil, errors.New("unauthorized"))
+
+	e := exporter.New(mockRobotClient, log.New(), exporter.Options{Interval: time.Minute})
+	c.Assert(e.Collect(), ErrorMatches, "unauthorized")
+
+	body := scrape(c, e)
+
+	c.Check(body, Matches, `(?s).*hrobot_api_errors_total\{call="ServerGetList"\} 1\n.*`)
+	c.Check(body, Not(Matches), `(?s).*hrobot_servers.*`)
+	c.Check(body, Not(Matches), `(?s).*hrobot_exporter_last_success_timestamp_seconds .*`)
+}
@@ -0,0 +1,91 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	typeGauge   = "gauge"
+	typeCounter = "counter"
+)
+
+// family is a metric with all its samples in the Prometheus text format
+type family struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+type sample struct {
+	labels []string
+	value  float64
+}
+
+func newFamily(name string, typ string, help string) *family {
+	return &family{
+		name: name,
+		typ:  typ,
+		help: help,
+	}
+}
+
+// add appends a sample, labels are given as name and value pairs
+func (f *family) add(value float64, labels ...string) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+func writeFamilies(w io.Writer, families []*family) error {
+	bw := bufio.NewWriter(w)
+
+	for _, f := range families {
+		fmt.Fprintf(bw, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(bw, "# TYPE %s %s\n", f.name, f.typ)
+
+		for _, s := range f.samples {
+			bw.WriteString(f.name)
+
+			if len(s.labels) > 0 {
+				var pairs []string
+				for i := 0; i+1 < len(s.labels); i += 2 {
+					pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", s.labels[i], escapeLabelValue(s.labels[i+1])))
+				}
+
+				bw.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+
+			bw.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+// counterFamily renders the counters per call sorted by call name
+func counterFamily(name string, help string, counts map[string]float64) *family {
+	f := newFamily(name, typeCounter, help)
+
+	var calls []string
+	for call := range counts {
+		calls = append(calls, call)
+	}
+
+	sort.Strings(calls)
+
+	for _, call := range calls {
+		f.add(counts[call], "call", call)
+	}
+
+	return f
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return strings.Replace(value, "\n", `\n`, -1)
+}
@@ -3,15 +3,18 @@ module github.com/nl2go/hrobot-cli
 go 1.13
 
 require (
+	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e
 	github.com/fatih/color v1.7.0
 	github.com/go-openapi/strfmt v0.19.3 // indirect
 	github.com/golang/mock v1.3.1
 	github.com/jedib0t/go-pretty v4.3.0+incompatible
 	github.com/kelseyhightower/envconfig v1.4.0
-	github.com/manifoldco/promptui v0.3.2
+	github.com/manifoldco/promptui v0.7.0
 	github.com/mattn/go-runewidth v0.0.6 // indirect
 	github.com/nl2go/hrobot-go v0.1.3
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/cobra v0.0.5
+	github.com/spf13/pflag v1.0.3
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15
+	gopkg.in/yaml.v2 v2.2.2
 )
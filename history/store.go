@@ -0,0 +1,153 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	resetsFile     = "resets.jsonl"
+	snapshotsDir   = "snapshots"
+	snapshotLayout = "20060102T150405Z"
+)
+
+// Reset is a reset issued with hrobot-cli
+type Reset struct {
+	Time         time.Time `json:"time"`
+	ServerIP     string    `json:"server_ip"`
+	ServerNumber int       `json:"server_number"`
+	ServerName   string    `json:"server_name"`
+	Type         string    `json:"type"`
+	User         string    `json:"user"`
+}
+
+// Store keeps the history of actions of a profile on disk, entries are
+// appended as JSON lines
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewStore(dir string) *Store {
+	return &Store{
+		dir: dir,
+	}
+}
+
+// Dir returns the directory used for the history of the given profile, the
+// user config directory is used if no base directory is configured
+func Dir(baseDir string, profile string) (string, error) {
+	if baseDir == "" {
+		userConfigDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+
+		baseDir = filepath.Join(userConfigDir, "hrobot-cli")
+	}
+
+	return filepath.Join(baseDir, profile), nil
+}
+
+// CurrentUser returns the name of the local user issuing the actions
+func CurrentUser() string {
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+
+	return os.Getenv("USER")
+}
+
+// AddReset appends the reset to the history, it is safe for concurrent use
+func (s *Store) AddReset(reset Reset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bytes, err := json.Marshal(reset)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dir, resetsFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(bytes, '\n'))
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+// Resets returns all recorded resets, oldest first
+func (s *Store) Resets() ([]Reset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(filepath.Join(s.dir, resetsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var resets []Reset
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var reset Reset
+		// lines cut off by an interrupted write are ignored
+		if err := json.Unmarshal(scanner.Bytes(), &reset); err != nil {
+			continue
+		}
+
+		resets = append(resets, reset)
+	}
+
+	return resets, scanner.Err()
+}
+
+// SnapshotPath returns the path of a snapshot of the account taken at the
+// given time
+func (s *Store) SnapshotPath(t time.Time) string {
+	return filepath.Join(s.dir, snapshotsDir, t.UTC().Format(snapshotLayout)+".json")
+}
+
+// LatestSnapshot returns the path of the most recent snapshot, or an empty
+// string if no snapshot was saved
+func (s *Store) LatestSnapshot() (string, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, snapshotsDir, "*.json"))
+	if err != nil {
+		return "", err
+	}
+
+	var snapshots []string
+	for _, file := range files {
+		if _, err := time.Parse(snapshotLayout, strings.TrimSuffix(filepath.Base(file), ".json")); err == nil {
+			snapshots = append(snapshots, file)
+		}
+	}
+
+	if len(snapshots) == 0 {
+		return "", nil
+	}
+
+	// the names sort in chronological order
+	sort.Strings(snapshots)
+	return snapshots[len(snapshots)-1], nil
+}
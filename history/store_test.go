@@ -0,0 +1,84 @@
+package history_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/history"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type HistorySuite struct {
+	dir string
+}
+
+var _ = Suite(&HistorySuite{})
+
+func (s *HistorySuite) SetUpTest(c *C) {
+	s.dir = filepath.Join(c.MkDir(), "default")
+}
+
+func (s *HistorySuite) TestResetsEmpty(c *C) {
+	store := history.NewStore(s.dir)
+
+	resets, err := store.Resets()
+	c.Assert(err, IsNil)
+	c.Assert(resets, HasLen, 0)
+}
+
+func (s *HistorySuite) TestAddReset(c *C) {
+	store := history.NewStore(s.dir)
+	now := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	c.Assert(store.AddReset(history.Reset{Time: now, ServerIP: "123.123.123.123", Type: "hw", User: "alice"}), IsNil)
+	c.Assert(store.AddReset(history.Reset{Time: now.Add(time.Hour), ServerIP: "123.123.123.123", Type: "man", User: "bob"}), IsNil)
+
+	resets, err := store.Resets()
+	c.Assert(err, IsNil)
+	c.Assert(resets, HasLen, 2)
+	c.Assert(resets[0].Time.Equal(now), Equals, true)
+	c.Assert(resets[1].Type, Equals, "man")
+	c.Assert(resets[1].User, Equals, "bob")
+}
+
+func (s *HistorySuite) TestResetsIgnoresBrokenLines(c *C) {
+	store := history.NewStore(s.dir)
+	c.Assert(store.AddReset(history.Reset{ServerIP: "123.123.123.123", Type: "hw"}), IsNil)
+
+	path := filepath.Join(s.dir, "resets.jsonl")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(path, append(data, []byte(`{"server_ip":"12`)...), 0600), IsNil)
+
+	resets, err := store.Resets()
+	c.Assert(err, IsNil)
+	c.Assert(resets, HasLen, 1)
+}
+
+func (s *HistorySuite) TestLatestSnapshot(c *C) {
+	store := history.NewStore(s.dir)
+
+	latest, err := store.LatestSnapshot()
+	c.Assert(err, IsNil)
+	c.Assert(latest, Equals, "")
+
+	now := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	path := store.SnapshotPath(now)
+	c.Assert(path, Equals, filepath.Join(s.dir, "snapshots", "20200401T120000Z.json"))
+
+	c.Assert(os.MkdirAll(filepath.Dir(path), 0700), IsNil)
+	for _, p := range []string{path, store.SnapshotPath(now.Add(-24 * time.Hour)), filepath.Join(s.dir, "snapshots", "notes.json")} {
+		c.Assert(ioutil.WriteFile(p, []byte("{}"), 0600), IsNil)
+	}
+
+	latest, err = store.LatestSnapshot()
+	c.Assert(err, IsNil)
+	c.Assert(latest, Equals, path)
+}
@@ -6,13 +6,18 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/nl2go/hrobot-cli/cache"
 	"github.com/nl2go/hrobot-cli/cmd"
 	"github.com/nl2go/hrobot-cli/config"
-	client "github.com/nl2go/hrobot-go"
+	"github.com/nl2go/hrobot-cli/history"
+	"github.com/nl2go/hrobot-cli/notify"
+	"github.com/nl2go/hrobot-cli/retry"
+	"github.com/nl2go/hrobot-cli/robot"
 )
 
 func main() {
-	log.SetOutput(os.Stdout)
+	// stdout only carries results like tables and reports
+	log.SetOutput(os.Stderr)
 
 	var cfg config.Config
 	err := envconfig.Process("hrobotcli", &cfg)
@@ -20,8 +25,33 @@ func main() {
 		log.Fatal(err.Error())
 	}
 
-	robotClient := client.NewBasicAuthClient(cfg.User, cfg.Password)
+	cacheDir, err := cache.Dir(cfg.CacheDir, cfg.Profile)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	historyDir, err := history.Dir(cfg.HistoryDir, cfg.Profile)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var robotClient robot.RobotClient
+	robotClient = robot.NewClient(cfg.User, cfg.Password, robot.NewHTTPClient(cfg.RequestTimeout))
+	robotClient = retry.NewClient(robotClient, log.StandardLogger(), cfg.RetryMax, cfg.RetryDelay, cfg.RetryMaxWait)
+	robotClient = cache.NewClient(robotClient, cacheDir, cfg.CacheTTL)
+
 	hrobotApp := cmd.NewRobotApp(robotClient, log.StandardLogger())
+	hrobotApp.SetHistory(history.NewStore(historyDir))
+
+	if cfg.NotifyURL != "" {
+		notifier, err := notify.New(cfg.NotifyURL, cfg.NotifyFormat, cfg.Profile, cfg.NotifyTimeout)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		hrobotApp.SetNotifier(notifier)
+	}
+
 	if err := hrobotApp.Run(); err != nil {
 		log.Errorln(err)
 		os.Exit(1)
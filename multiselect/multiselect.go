@@ -0,0 +1,261 @@
+package multiselect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"text/template"
+
+	"github.com/chzyer/readline"
+	"github.com/manifoldco/promptui"
+	"github.com/manifoldco/promptui/list"
+	"github.com/manifoldco/promptui/screenbuf"
+)
+
+const (
+	hideCursor = "\033[?25l"
+	showCursor = "\033[?25h"
+)
+
+// MultiSelect lets the user choose any number of items from a searchable
+// list. Items are toggled with space, all currently visible items with "a"
+// and the selection is finished with enter.
+type MultiSelect struct {
+	// Label is the text displayed on top of the list
+	Label string
+
+	// Items is a slice of the items to choose from
+	Items interface{}
+
+	// Size is the number of items visible at once, defaults to 5
+	Size int
+
+	// Searcher filters the items while searching, search is disabled without it
+	Searcher list.Searcher
+
+	// Templates render the label and the items, the Active, Inactive, Label and
+	// Details templates of promptui are supported
+	Templates *promptui.SelectTemplates
+
+	// StartInSearchMode starts with the search input instead of the selection
+	StartInSearchMode bool
+
+	// Stdout is the output of the list, defaults to stdout
+	Stdout io.WriteCloser
+}
+
+type templates struct {
+	label    *template.Template
+	active   *template.Template
+	inactive *template.Template
+	details  *template.Template
+}
+
+// Run shows the list until the selection is finished and returns the indexes
+// of the chosen items in the order of the items
+func (s *MultiSelect) Run() ([]int, error) {
+	if s.Size == 0 {
+		s.Size = 5
+	}
+
+	if s.Items == nil || reflect.TypeOf(s.Items).Kind() != reflect.Slice {
+		return nil, fmt.Errorf("items %v is not a slice", s.Items)
+	}
+
+	slice := reflect.ValueOf(s.Items)
+	items := make([]interface{}, slice.Len())
+	for i := range items {
+		items[i] = slice.Index(i).Interface()
+	}
+
+	tpls, err := s.prepareTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	st := newState(items, s.Size, s.Searcher)
+	st.searchMode = s.StartInSearchMode && s.Searcher != nil
+
+	c := &readline.Config{}
+	if err := c.Init(); err != nil {
+		return nil, err
+	}
+
+	c.Stdin = readline.NewCancelableStdin(os.Stdin)
+	if s.Stdout != nil {
+		c.Stdout = s.Stdout
+	}
+	c.HistoryLimit = -1
+	c.UniqueEditLine = true
+
+	rl, err := readline.NewEx(c)
+	if err != nil {
+		return nil, err
+	}
+
+	rl.Write([]byte(hideCursor))
+	sb := screenbuf.New(rl)
+
+	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		// enter is handled once readline returns the line
+		if key != promptui.KeyEnter {
+			st.handle(key)
+		}
+
+		s.render(sb, st, tpls)
+
+		return nil, 0, true
+	})
+
+	for {
+		_, err = rl.Readline()
+		if err != nil {
+			break
+		}
+
+		if !st.searchMode {
+			break
+		}
+
+		// enter only finishes the search input, the filter stays active
+		st.searchMode = false
+		s.render(sb, st, tpls)
+	}
+
+	if err != nil {
+		switch {
+		case err == readline.ErrInterrupt, err.Error() == "Interrupt":
+			err = promptui.ErrInterrupt
+		case err == io.EOF:
+			err = promptui.ErrEOF
+		}
+
+		sb.Reset()
+		sb.WriteString("")
+		sb.Flush()
+		rl.Write([]byte(showCursor))
+		rl.Close()
+		return nil, err
+	}
+
+	chosen := st.chosen()
+
+	sb.Reset()
+	sb.WriteString(fmt.Sprintf("%s %s: %d selected", promptui.IconGood, s.Label, len(chosen)))
+	sb.Flush()
+	rl.Write([]byte(showCursor))
+	rl.Close()
+
+	return chosen, nil
+}
+
+func (s *MultiSelect) render(sb *screenbuf.ScreenBuf, st *state, tpls *templates) {
+	if st.searchMode {
+		sb.WriteString(fmt.Sprintf("Search: %s█", string(st.searchInput)))
+	} else {
+		help := fmt.Sprintf("%s %s %s %s navigate, space toggles, a toggles all visible, enter finishes",
+			promptui.KeyNextDisplay, promptui.KeyPrevDisplay, promptui.KeyForwardDisplay, promptui.KeyBackwardDisplay)
+		if s.Searcher != nil {
+			help += ", / searches"
+		}
+		sb.WriteString(promptui.Styler(promptui.FGFaint)(help))
+	}
+
+	label := render(tpls.label, s.Label)
+	sb.Write(append(label, []byte(fmt.Sprintf(" %d of %d selected", len(st.chosen()), len(st.items)))...))
+
+	visible, active := st.page()
+	last := len(visible) - 1
+
+	for i, idx := range visible {
+		page := " "
+
+		switch {
+		case i == 0 && st.start > 0:
+			page = "↑"
+		case i == last && st.start+st.size < len(st.visible):
+			page = "↓"
+		}
+
+		box := "[ ]"
+		if st.selected[idx] {
+			box = "[x]"
+		}
+
+		output := []byte(page + " " + box + " ")
+
+		if i == active {
+			output = append(output, render(tpls.active, st.items[idx])...)
+		} else {
+			output = append(output, render(tpls.inactive, st.items[idx])...)
+		}
+
+		sb.Write(output)
+	}
+
+	if len(visible) == 0 {
+		sb.WriteString("")
+		sb.WriteString("No results")
+	} else if tpls.details != nil {
+		details := render(tpls.details, st.items[visible[active]])
+		for _, line := range bytes.Split(details, []byte("\n")) {
+			sb.Write(line)
+		}
+	}
+
+	sb.Flush()
+}
+
+func (s *MultiSelect) prepareTemplates() (*templates, error) {
+	tpls := s.Templates
+	if tpls == nil {
+		tpls = &promptui.SelectTemplates{}
+	}
+
+	funcMap := tpls.FuncMap
+	if funcMap == nil {
+		funcMap = promptui.FuncMap
+	}
+
+	parse := func(text string, fallback string) (*template.Template, error) {
+		if text == "" {
+			text = fallback
+		}
+
+		return template.New("").Funcs(funcMap).Parse(text)
+	}
+
+	var result templates
+	var err error
+
+	if result.label, err = parse(tpls.Label, fmt.Sprintf("%s {{ . }}:", promptui.IconInitial)); err != nil {
+		return nil, err
+	}
+
+	if result.active, err = parse(tpls.Active, fmt.Sprintf("%s {{ . | underline }}", promptui.IconSelect)); err != nil {
+		return nil, err
+	}
+
+	if result.inactive, err = parse(tpls.Inactive, "  {{ . }}"); err != nil {
+		return nil, err
+	}
+
+	if tpls.Details != "" {
+		if result.details, err = parse(tpls.Details, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+func render(tpl *template.Template, data interface{}) []byte {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return []byte(fmt.Sprintf("%v", data))
+	}
+
+	return buf.Bytes()
+}
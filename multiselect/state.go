@@ -0,0 +1,188 @@
+package multiselect
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/chzyer/readline"
+	"github.com/manifoldco/promptui"
+	"github.com/manifoldco/promptui/list"
+)
+
+const (
+	keyToggle    rune = ' '
+	keyToggleAll rune = 'a'
+	keySearch    rune = '/'
+)
+
+// state holds the selection and the visible part of the list, it is kept
+// apart from the terminal handling
+type state struct {
+	items    []interface{}
+	size     int
+	searcher list.Searcher
+	selected map[int]bool
+
+	// visible holds the indexes of the items matching the search
+	visible []int
+	cursor  int
+	start   int
+
+	searchMode  bool
+	searchInput []rune
+}
+
+func newState(items []interface{}, size int, searcher list.Searcher) *state {
+	st := &state{
+		items:    items,
+		size:     size,
+		searcher: searcher,
+		selected: make(map[int]bool),
+	}
+	st.search()
+
+	return st
+}
+
+// handle applies a key pressed by the user
+func (st *state) handle(key rune) {
+	switch {
+	case key == promptui.KeyNext:
+		st.next()
+	case key == promptui.KeyPrev:
+		st.prev()
+	case key == promptui.KeyForward:
+		st.pageDown()
+	case key == promptui.KeyBackward:
+		st.pageUp()
+	case key == keySearch && st.searcher != nil:
+		st.searchMode = !st.searchMode
+	case key == promptui.KeyBackspace || key == readline.CharCtrlH:
+		if st.searchMode && len(st.searchInput) > 0 {
+			st.searchInput = st.searchInput[:len(st.searchInput)-1]
+			st.search()
+		}
+	case st.searchMode:
+		if unicode.IsPrint(key) {
+			st.searchInput = append(st.searchInput, key)
+			st.search()
+		}
+	case key == keyToggle:
+		st.toggle()
+	case key == keyToggleAll:
+		st.toggleAll()
+	}
+}
+
+func (st *state) search() {
+	term := strings.TrimSpace(string(st.searchInput))
+
+	st.visible = st.visible[:0]
+	for i := range st.items {
+		if term == "" || st.searcher == nil || st.searcher(term, i) {
+			st.visible = append(st.visible, i)
+		}
+	}
+
+	st.cursor = 0
+	st.start = 0
+}
+
+// toggle selects or deselects the active item
+func (st *state) toggle() {
+	if len(st.visible) == 0 {
+		return
+	}
+
+	idx := st.visible[st.cursor]
+	if st.selected[idx] {
+		delete(st.selected, idx)
+	} else {
+		st.selected[idx] = true
+	}
+}
+
+// toggleAll selects all visible items, if all of them are selected already
+// they are deselected instead
+func (st *state) toggleAll() {
+	allSelected := true
+	for _, idx := range st.visible {
+		if !st.selected[idx] {
+			allSelected = false
+			break
+		}
+	}
+
+	for _, idx := range st.visible {
+		if allSelected {
+			delete(st.selected, idx)
+		} else {
+			st.selected[idx] = true
+		}
+	}
+}
+
+func (st *state) next() {
+	if st.cursor < len(st.visible)-1 {
+		st.cursor++
+	}
+
+	if st.start+st.size <= st.cursor {
+		st.start = st.cursor - st.size + 1
+	}
+}
+
+func (st *state) prev() {
+	if st.cursor > 0 {
+		st.cursor--
+	}
+
+	if st.start > st.cursor {
+		st.start = st.cursor
+	}
+}
+
+func (st *state) pageDown() {
+	st.start += st.size
+	if max := len(st.visible) - st.size; st.start > max {
+		st.start = max
+	}
+
+	if st.start < 0 {
+		st.start = 0
+	}
+
+	st.cursor = st.start
+}
+
+func (st *state) pageUp() {
+	st.start -= st.size
+	if st.start < 0 {
+		st.start = 0
+	}
+
+	st.cursor = st.start
+}
+
+// page returns the indexes of the items on the current page and the position
+// of the active item on it
+func (st *state) page() ([]int, int) {
+	end := st.start + st.size
+	if end > len(st.visible) {
+		end = len(st.visible)
+	}
+
+	return st.visible[st.start:end], st.cursor - st.start
+}
+
+// chosen returns the indexes of the selected items in the order of the items
+func (st *state) chosen() []int {
+	var chosen []int
+	for i := range st.items {
+		if st.selected[i] {
+			chosen = append(chosen, i)
+		}
+	}
+
+	return chosen
+}
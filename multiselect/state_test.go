@@ -0,0 +1,109 @@
+package multiselect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/manifoldco/promptui"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type StateSuite struct {
+	items []interface{}
+}
+
+var _ = Suite(&StateSuite{})
+
+func (s *StateSuite) SetUpTest(c *C) {
+	s.items = []interface{}{"app-prod-42", "app-prod-84", "mongodb-prod-1", "mongodb-prod-2", "web-prod-1"}
+}
+
+func (s *StateSuite) newState(size int) *state {
+	return newState(s.items, size, func(input string, index int) bool {
+		return strings.Contains(s.items[index].(string), input)
+	})
+}
+
+func (s *StateSuite) TestToggle(c *C) {
+	st := s.newState(3)
+
+	st.handle(keyToggle)
+	st.handle(promptui.KeyNext)
+	st.handle(promptui.KeyNext)
+	st.handle(keyToggle)
+	c.Assert(st.chosen(), DeepEquals, []int{0, 2})
+
+	st.handle(keyToggle)
+	c.Assert(st.chosen(), DeepEquals, []int{0})
+}
+
+func (s *StateSuite) TestToggleAllVisible(c *C) {
+	st := s.newState(3)
+
+	st.handle(keySearch)
+	for _, key := range "mongo" {
+		st.handle(key)
+	}
+	st.searchMode = false
+
+	st.handle(keyToggleAll)
+	c.Assert(st.chosen(), DeepEquals, []int{2, 3})
+
+	st.handle(keyToggleAll)
+	c.Assert(st.chosen(), HasLen, 0)
+}
+
+func (s *StateSuite) TestSearchModeTakesKeysAsInput(c *C) {
+	st := s.newState(3)
+
+	st.handle(keySearch)
+	for _, key := range "a p" {
+		st.handle(key)
+	}
+
+	c.Assert(string(st.searchInput), Equals, "a p")
+	c.Assert(st.chosen(), HasLen, 0)
+
+	st.handle(promptui.KeyBackspace)
+	st.handle(promptui.KeyBackspace)
+	c.Assert(string(st.searchInput), Equals, "a")
+	c.Assert(st.visible, DeepEquals, []int{0, 1})
+}
+
+func (s *StateSuite) TestPaging(c *C) {
+	st := s.newState(2)
+
+	visible, active := st.page()
+	c.Assert(visible, DeepEquals, []int{0, 1})
+	c.Assert(active, Equals, 0)
+
+	st.handle(promptui.KeyNext)
+	st.handle(promptui.KeyNext)
+	visible, active = st.page()
+	c.Assert(visible, DeepEquals, []int{1, 2})
+	c.Assert(active, Equals, 1)
+
+	st.handle(promptui.KeyForward)
+	visible, _ = st.page()
+	c.Assert(visible, DeepEquals, []int{3, 4})
+
+	st.handle(promptui.KeyBackward)
+	visible, active = st.page()
+	c.Assert(visible, DeepEquals, []int{1, 2})
+	c.Assert(active, Equals, 0)
+}
+
+func (s *StateSuite) TestToggleWithoutResults(c *C) {
+	st := s.newState(3)
+
+	st.handle(keySearch)
+	st.handle('x')
+	st.searchMode = false
+	st.handle(keyToggle)
+	st.handle(keyToggleAll)
+
+	c.Assert(st.chosen(), HasLen, 0)
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatWebhook posts the event as JSON
+	FormatWebhook = "webhook"
+	// FormatSlack posts a message compatible with the incoming webhooks of
+	// Slack and Mattermost
+	FormatSlack = "slack"
+
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// Event is a mutating action issued with hrobot-cli and its outcome per
+// server
+type Event struct {
+	Time    time.Time `json:"time"`
+	Profile string    `json:"profile"`
+	Actor   string    `json:"actor"`
+	Command string    `json:"command"`
+	Action  string    `json:"action"`
+	Targets []Target  `json:"targets"`
+}
+
+type Target struct {
+	ServerNumber int    `json:"server_number"`
+	ServerName   string `json:"server_name"`
+	ServerIP     string `json:"server_ip"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Notifier posts events to a webhook of the profile
+type Notifier struct {
+	url     string
+	format  string
+	profile string
+	client  *http.Client
+}
+
+func New(url string, format string, profile string, timeout time.Duration) (*Notifier, error) {
+	if format != FormatWebhook && format != FormatSlack {
+		return nil, fmt.Errorf("unknown notification format %q, use one of: %s, %s", format, FormatWebhook, FormatSlack)
+	}
+
+	return &Notifier{
+		url:     url,
+		format:  format,
+		profile: profile,
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Send posts the event, the profile of the notifier is set on the event
+func (n *Notifier) Send(event Event) error {
+	event.Profile = n.profile
+
+	var payload interface{} = event
+	if n.format == FormatSlack {
+		payload = struct {
+			Text string `json:"text"`
+		}{Text: event.Message()}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		if message := strings.TrimSpace(string(respBody)); message != "" {
+			return fmt.Errorf("webhook returned %s: %s", resp.Status, message)
+		}
+
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Message returns the event as chat message, one line per server
+func (e Event) Message() string {
+	counts := make(map[string]int)
+	for _, target := range e.Targets {
+		counts[target.Status]++
+	}
+
+	var outcome []string
+	for _, status := range []string{StatusOK, StatusFailed, StatusSkipped} {
+		if counts[status] > 0 {
+			outcome = append(outcome, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s ran %s (%s) in profile %s: %s", e.Actor, e.Command, e.Action, e.Profile, strings.Join(outcome, ", ")),
+	}
+
+	for _, target := range e.Targets {
+		line := fmt.Sprintf("- %s (%s, #%d): %s", target.ServerName, target.ServerIP, target.ServerNumber, target.Status)
+		if target.Error != "" {
+			line += ": " + target.Error
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
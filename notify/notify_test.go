@@ -0,0 +1,96 @@
+package notify_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/notify"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type NotifySuite struct{}
+
+var _ = Suite(&NotifySuite{})
+
+var testEvent = notify.Event{
+	Time:    time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+	Actor:   "alice",
+	Command: "server:reset",
+	Action:  "hardware reset",
+	Targets: []notify.Target{
+		{ServerNumber: 42, ServerName: "app-prod-42", ServerIP: "123.123.123.123", Status: notify.StatusOK},
+		{ServerNumber: 84, ServerName: "app-prod-84", ServerIP: "124.124.124.124", Status: notify.StatusFailed, Error: "server not found"},
+	},
+}
+
+// newStandIn returns a local webhook recording the posted bodies
+func newStandIn(c *C, status int, bodies *[][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), Equals, "application/json")
+
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, IsNil)
+		*bodies = append(*bodies, body)
+
+		w.WriteHeader(status)
+	}))
+}
+
+func (s *NotifySuite) TestSendWebhook(c *C) {
+	var bodies [][]byte
+	server := newStandIn(c, http.StatusOK, &bodies)
+	defer server.Close()
+
+	notifier, err := notify.New(server.URL, notify.FormatWebhook, "prod", time.Second)
+	c.Assert(err, IsNil)
+	c.Assert(notifier.Send(testEvent), IsNil)
+	c.Assert(bodies, HasLen, 1)
+
+	var event notify.Event
+	c.Assert(json.Unmarshal(bodies[0], &event), IsNil)
+
+	expected := testEvent
+	expected.Profile = "prod"
+	c.Assert(event, DeepEquals, expected)
+}
+
+func (s *NotifySuite) TestSendSlack(c *C) {
+	var bodies [][]byte
+	server := newStandIn(c, http.StatusOK, &bodies)
+	defer server.Close()
+
+	notifier, err := notify.New(server.URL, notify.FormatSlack, "prod", time.Second)
+	c.Assert(err, IsNil)
+	c.Assert(notifier.Send(testEvent), IsNil)
+	c.Assert(bodies, HasLen, 1)
+
+	var message map[string]string
+	c.Assert(json.Unmarshal(bodies[0], &message), IsNil)
+	c.Assert(message["text"], Equals, `alice ran server:reset (hardware reset) in profile prod: 1 ok, 1 failed
+- app-prod-42 (123.123.123.123, #42): ok
+- app-prod-84 (124.124.124.124, #84): failed: server not found`)
+}
+
+func (s *NotifySuite) TestSendFailed(c *C) {
+	var bodies [][]byte
+	server := newStandIn(c, http.StatusForbidden, &bodies)
+	defer server.Close()
+
+	notifier, err := notify.New(server.URL, notify.FormatWebhook, "prod", time.Second)
+	c.Assert(err, IsNil)
+	c.Assert(notifier.Send(testEvent), ErrorMatches, "webhook returned 403 Forbidden")
+}
+
+func (s *NotifySuite) TestNewUnknownFormat(c *C) {
+	_, err := notify.New("http://localhost", "teams", "prod", time.Second)
+	c.Assert(err, ErrorMatches, `unknown notification format "teams", use one of: webhook, slack`)
+}
@@ -0,0 +1,746 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const codeRateLimitExceeded = "RATE_LIMIT_EXCEEDED"
+
+// Client decorates a RobotClient and retries calls rejected by the rate limit
+// of the webservice or failing with a transient server error or timeout. Rate
+// limited calls are repeated after the interval of the limit. Calls changing
+// data are only retried on rate limit errors as the webservice did not execute
+// them in that case.
+type Client struct {
+	robot.RobotClient
+	logger     *log.Logger
+	maxRetries int
+	baseDelay  time.Duration
+	maxWait    time.Duration
+}
+
+// ExhaustedError is returned when a call still fails after all retries
+type ExhaustedError struct {
+	Call     string
+	Attempts int
+	Err      error
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("%s failed after %d attempts: %s", e.Call, e.Attempts, e.Err)
+}
+
+func (e *ExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// apiError is the error body returned by the webservice
+type apiError struct {
+	Error struct {
+		Status     int    `json:"status"`
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		MaxRequest int    `json:"max_request"`
+		Interval   int    `json:"interval"`
+	} `json:"error"`
+}
+
+func NewClient(robotClient robot.RobotClient, logger *log.Logger, maxRetries int, baseDelay time.Duration, maxWait time.Duration) *Client {
+	return &Client{
+		RobotClient: robotClient,
+		logger:      logger,
+		maxRetries:  maxRetries,
+		baseDelay:   baseDelay,
+		maxWait:     maxWait,
+	}
+}
+
+func (c *Client) ServerGetList() ([]models.Server, error) {
+	var servers []models.Server
+	err := c.do("ServerGetList", true, func() (err error) {
+		servers, err = c.RobotClient.ServerGetList()
+		return err
+	})
+
+	return servers, err
+}
+
+func (c *Client) ServerGet(ip string) (*models.Server, error) {
+	var server *models.Server
+	err := c.do("ServerGet", true, func() (err error) {
+		server, err = c.RobotClient.ServerGet(ip)
+		return err
+	})
+
+	return server, err
+}
+
+func (c *Client) ServerSetName(ip string, input *models.ServerSetNameInput) (*models.Server, error) {
+	var server *models.Server
+	err := c.do("ServerSetName", false, func() (err error) {
+		server, err = c.RobotClient.ServerSetName(ip, input)
+		return err
+	})
+
+	return server, err
+}
+
+func (c *Client) ServerReverse(ip string) (*models.Cancellation, error) {
+	var cancellation *models.Cancellation
+	err := c.do("ServerReverse", false, func() (err error) {
+		cancellation, err = c.RobotClient.ServerReverse(ip)
+		return err
+	})
+
+	return cancellation, err
+}
+
+func (c *Client) KeyGetList() ([]models.Key, error) {
+	var keys []models.Key
+	err := c.do("KeyGetList", true, func() (err error) {
+		keys, err = c.RobotClient.KeyGetList()
+		return err
+	})
+
+	return keys, err
+}
+
+func (c *Client) IPGetList() ([]models.IP, error) {
+	var ips []models.IP
+	err := c.do("IPGetList", true, func() (err error) {
+		ips, err = c.RobotClient.IPGetList()
+		return err
+	})
+
+	return ips, err
+}
+
+func (c *Client) RDnsGetList() ([]models.Rdns, error) {
+	var rdnsList []models.Rdns
+	err := c.do("RDnsGetList", true, func() (err error) {
+		rdnsList, err = c.RobotClient.RDnsGetList()
+		return err
+	})
+
+	return rdnsList, err
+}
+
+func (c *Client) RDnsGet(ip string) (*models.Rdns, error) {
+	var rdns *models.Rdns
+	err := c.do("RDnsGet", true, func() (err error) {
+		rdns, err = c.RobotClient.RDnsGet(ip)
+		return err
+	})
+
+	return rdns, err
+}
+
+func (c *Client) RDnsSet(ip string, ptr string) (*models.Rdns, error) {
+	var rdns *models.Rdns
+	err := c.do("RDnsSet", false, func() (err error) {
+		rdns, err = c.RobotClient.RDnsSet(ip, ptr)
+		return err
+	})
+
+	return rdns, err
+}
+
+func (c *Client) BootRescueGet(ip string) (*models.Rescue, error) {
+	var rescue *models.Rescue
+	err := c.do("BootRescueGet", true, func() (err error) {
+		rescue, err = c.RobotClient.BootRescueGet(ip)
+		return err
+	})
+
+	return rescue, err
+}
+
+func (c *Client) BootRescueSet(ip string, input *models.RescueSetInput) (*models.Rescue, error) {
+	var rescue *models.Rescue
+	err := c.do("BootRescueSet", false, func() (err error) {
+		rescue, err = c.RobotClient.BootRescueSet(ip, input)
+		return err
+	})
+
+	return rescue, err
+}
+
+func (c *Client) ResetGet(ip string) (*models.Reset, error) {
+	var reset *models.Reset
+	err := c.do("ResetGet", true, func() (err error) {
+		reset, err = c.RobotClient.ResetGet(ip)
+		return err
+	})
+
+	return reset, err
+}
+
+func (c *Client) ResetSet(ip string, input *models.ResetSetInput) (*models.ResetPost, error) {
+	var reset *models.ResetPost
+	err := c.do("ResetSet", false, func() (err error) {
+		reset, err = c.RobotClient.ResetSet(ip, input)
+		return err
+	})
+
+	return reset, err
+}
+
+func (c *Client) FailoverGetList() ([]models.Failover, error) {
+	var failovers []models.Failover
+	err := c.do("FailoverGetList", true, func() (err error) {
+		failovers, err = c.RobotClient.FailoverGetList()
+		return err
+	})
+
+	return failovers, err
+}
+
+func (c *Client) FailoverGet(ip string) (*models.Failover, error) {
+	var failover *models.Failover
+	err := c.do("FailoverGet", true, func() (err error) {
+		failover, err = c.RobotClient.FailoverGet(ip)
+		return err
+	})
+
+	return failover, err
+}
+
+func (c *Client) TrafficGet(input *robot.TrafficGetInput) (*robot.Traffic, error) {
+	var traffic *robot.Traffic
+	err := c.do("TrafficGet", true, func() (err error) {
+		traffic, err = c.RobotClient.TrafficGet(input)
+		return err
+	})
+
+	return traffic, err
+}
+
+func (c *Client) IPGet(ip string) (*robot.IP, error) {
+	var result *robot.IP
+	err := c.do("IPGet", true, func() (err error) {
+		result, err = c.RobotClient.IPGet(ip)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) IPUpdate(ip string, input *robot.IPUpdateInput) (*robot.IP, error) {
+	var result *robot.IP
+	err := c.do("IPUpdate", false, func() (err error) {
+		result, err = c.RobotClient.IPUpdate(ip, input)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) IPMacGet(ip string) (*robot.Mac, error) {
+	var result *robot.Mac
+	err := c.do("IPMacGet", true, func() (err error) {
+		result, err = c.RobotClient.IPMacGet(ip)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) IPMacGenerate(ip string) (*robot.Mac, error) {
+	var result *robot.Mac
+	err := c.do("IPMacGenerate", false, func() (err error) {
+		result, err = c.RobotClient.IPMacGenerate(ip)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) IPMacDelete(ip string) (*robot.Mac, error) {
+	var result *robot.Mac
+	err := c.do("IPMacDelete", false, func() (err error) {
+		result, err = c.RobotClient.IPMacDelete(ip)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) SubnetGetList() ([]robot.Subnet, error) {
+	var subnets []robot.Subnet
+	err := c.do("SubnetGetList", true, func() (err error) {
+		subnets, err = c.RobotClient.SubnetGetList()
+		return err
+	})
+
+	return subnets, err
+}
+
+func (c *Client) SubnetGet(ip string) (*robot.Subnet, error) {
+	var subnet *robot.Subnet
+	err := c.do("SubnetGet", true, func() (err error) {
+		subnet, err = c.RobotClient.SubnetGet(ip)
+		return err
+	})
+
+	return subnet, err
+}
+
+func (c *Client) SubnetMacGet(ip string) (*robot.Mac, error) {
+	var result *robot.Mac
+	err := c.do("SubnetMacGet", true, func() (err error) {
+		result, err = c.RobotClient.SubnetMacGet(ip)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) SubnetMacSet(ip string, mac string) (*robot.Mac, error) {
+	var result *robot.Mac
+	err := c.do("SubnetMacSet", false, func() (err error) {
+		result, err = c.RobotClient.SubnetMacSet(ip, mac)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) SubnetMacDelete(ip string) (*robot.Mac, error) {
+	var result *robot.Mac
+	err := c.do("SubnetMacDelete", false, func() (err error) {
+		result, err = c.RobotClient.SubnetMacDelete(ip)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) FirewallGet(serverIP string) (*robot.Firewall, error) {
+	var firewall *robot.Firewall
+	err := c.do("FirewallGet", true, func() (err error) {
+		firewall, err = c.RobotClient.FirewallGet(serverIP)
+		return err
+	})
+
+	return firewall, err
+}
+
+func (c *Client) FirewallSet(serverIP string, input *robot.FirewallInput) (*robot.Firewall, error) {
+	var firewall *robot.Firewall
+	err := c.do("FirewallSet", false, func() (err error) {
+		firewall, err = c.RobotClient.FirewallSet(serverIP, input)
+		return err
+	})
+
+	return firewall, err
+}
+
+func (c *Client) FirewallApplyTemplate(serverIP string, templateID int) (*robot.Firewall, error) {
+	var firewall *robot.Firewall
+	err := c.do("FirewallApplyTemplate", false, func() (err error) {
+		firewall, err = c.RobotClient.FirewallApplyTemplate(serverIP, templateID)
+		return err
+	})
+
+	return firewall, err
+}
+
+func (c *Client) FirewallTemplateGetList() ([]robot.FirewallTemplate, error) {
+	var templates []robot.FirewallTemplate
+	err := c.do("FirewallTemplateGetList", true, func() (err error) {
+		templates, err = c.RobotClient.FirewallTemplateGetList()
+		return err
+	})
+
+	return templates, err
+}
+
+func (c *Client) FirewallTemplateGet(id int) (*robot.FirewallTemplate, error) {
+	var template *robot.FirewallTemplate
+	err := c.do("FirewallTemplateGet", true, func() (err error) {
+		template, err = c.RobotClient.FirewallTemplateGet(id)
+		return err
+	})
+
+	return template, err
+}
+
+func (c *Client) FirewallTemplateCreate(input *robot.FirewallTemplateInput) (*robot.FirewallTemplate, error) {
+	var template *robot.FirewallTemplate
+	err := c.do("FirewallTemplateCreate", false, func() (err error) {
+		template, err = c.RobotClient.FirewallTemplateCreate(input)
+		return err
+	})
+
+	return template, err
+}
+
+func (c *Client) StorageBoxGetList() ([]robot.StorageBox, error) {
+	var storageBoxes []robot.StorageBox
+	err := c.do("StorageBoxGetList", true, func() (err error) {
+		storageBoxes, err = c.RobotClient.StorageBoxGetList()
+		return err
+	})
+
+	return storageBoxes, err
+}
+
+func (c *Client) StorageBoxGet(id int) (*robot.StorageBox, error) {
+	var storageBox *robot.StorageBox
+	err := c.do("StorageBoxGet", true, func() (err error) {
+		storageBox, err = c.RobotClient.StorageBoxGet(id)
+		return err
+	})
+
+	return storageBox, err
+}
+
+func (c *Client) StorageBoxUpdate(id int, input *robot.StorageBoxInput) (*robot.StorageBox, error) {
+	var storageBox *robot.StorageBox
+	err := c.do("StorageBoxUpdate", false, func() (err error) {
+		storageBox, err = c.RobotClient.StorageBoxUpdate(id, input)
+		return err
+	})
+
+	return storageBox, err
+}
+
+func (c *Client) StorageBoxSnapshotGetList(id int) ([]robot.StorageBoxSnapshot, error) {
+	var snapshots []robot.StorageBoxSnapshot
+	err := c.do("StorageBoxSnapshotGetList", true, func() (err error) {
+		snapshots, err = c.RobotClient.StorageBoxSnapshotGetList(id)
+		return err
+	})
+
+	return snapshots, err
+}
+
+func (c *Client) StorageBoxSnapshotCreate(id int) (*robot.StorageBoxSnapshot, error) {
+	var snapshot *robot.StorageBoxSnapshot
+	err := c.do("StorageBoxSnapshotCreate", false, func() (err error) {
+		snapshot, err = c.RobotClient.StorageBoxSnapshotCreate(id)
+		return err
+	})
+
+	return snapshot, err
+}
+
+func (c *Client) StorageBoxSnapshotDelete(id int, name string) error {
+	return c.do("StorageBoxSnapshotDelete", false, func() error {
+		return c.RobotClient.StorageBoxSnapshotDelete(id, name)
+	})
+}
+
+func (c *Client) StorageBoxSnapshotRevert(id int, name string) error {
+	return c.do("StorageBoxSnapshotRevert", false, func() error {
+		return c.RobotClient.StorageBoxSnapshotRevert(id, name)
+	})
+}
+
+func (c *Client) StorageBoxSnapshotPlanGet(id int) (*robot.StorageBoxSnapshotPlan, error) {
+	var result *robot.StorageBoxSnapshotPlan
+	err := c.do("StorageBoxSnapshotPlanGet", true, func() (err error) {
+		result, err = c.RobotClient.StorageBoxSnapshotPlanGet(id)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) StorageBoxSnapshotPlanSet(id int, plan *robot.StorageBoxSnapshotPlan) (*robot.StorageBoxSnapshotPlan, error) {
+	var result *robot.StorageBoxSnapshotPlan
+	err := c.do("StorageBoxSnapshotPlanSet", false, func() (err error) {
+		result, err = c.RobotClient.StorageBoxSnapshotPlanSet(id, plan)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) StorageBoxSubaccountGetList(id int) ([]robot.StorageBoxSubaccount, error) {
+	var subaccounts []robot.StorageBoxSubaccount
+	err := c.do("StorageBoxSubaccountGetList", true, func() (err error) {
+		subaccounts, err = c.RobotClient.StorageBoxSubaccountGetList(id)
+		return err
+	})
+
+	return subaccounts, err
+}
+
+func (c *Client) StorageBoxSubaccountCreate(id int, input *robot.StorageBoxSubaccountInput) (*robot.StorageBoxSubaccount, error) {
+	var subaccount *robot.StorageBoxSubaccount
+	err := c.do("StorageBoxSubaccountCreate", false, func() (err error) {
+		subaccount, err = c.RobotClient.StorageBoxSubaccountCreate(id, input)
+		return err
+	})
+
+	return subaccount, err
+}
+
+func (c *Client) StorageBoxSubaccountUpdate(id int, username string, input *robot.StorageBoxSubaccountInput) error {
+	return c.do("StorageBoxSubaccountUpdate", false, func() error {
+		return c.RobotClient.StorageBoxSubaccountUpdate(id, username, input)
+	})
+}
+
+func (c *Client) StorageBoxSubaccountDelete(id int, username string) error {
+	return c.do("StorageBoxSubaccountDelete", false, func() error {
+		return c.RobotClient.StorageBoxSubaccountDelete(id, username)
+	})
+}
+
+func (c *Client) WolGet(serverIP string) (*robot.Wol, error) {
+	var wol *robot.Wol
+	err := c.do("WolGet", true, func() (err error) {
+		wol, err = c.RobotClient.WolGet(serverIP)
+		return err
+	})
+
+	return wol, err
+}
+
+func (c *Client) WolSend(serverIP string) (*robot.Wol, error) {
+	var wol *robot.Wol
+	err := c.do("WolSend", false, func() (err error) {
+		wol, err = c.RobotClient.WolSend(serverIP)
+		return err
+	})
+
+	return wol, err
+}
+
+func (c *Client) OrderServerProductGetList() ([]robot.ServerProduct, error) {
+	var products []robot.ServerProduct
+	err := c.do("OrderServerProductGetList", true, func() (err error) {
+		products, err = c.RobotClient.OrderServerProductGetList()
+		return err
+	})
+
+	return products, err
+}
+
+func (c *Client) OrderServerTransactionGetList() ([]robot.ServerTransaction, error) {
+	var transactions []robot.ServerTransaction
+	err := c.do("OrderServerTransactionGetList", true, func() (err error) {
+		transactions, err = c.RobotClient.OrderServerTransactionGetList()
+		return err
+	})
+
+	return transactions, err
+}
+
+func (c *Client) OrderServerTransactionGet(id string) (*robot.ServerTransaction, error) {
+	var transaction *robot.ServerTransaction
+	err := c.do("OrderServerTransactionGet", true, func() (err error) {
+		transaction, err = c.RobotClient.OrderServerTransactionGet(id)
+		return err
+	})
+
+	return transaction, err
+}
+
+func (c *Client) OrderServerTransactionCreate(input *robot.ServerOrderInput) (*robot.ServerTransaction, error) {
+	var transaction *robot.ServerTransaction
+	err := c.do("OrderServerTransactionCreate", false, func() (err error) {
+		transaction, err = c.RobotClient.OrderServerTransactionCreate(input)
+		return err
+	})
+
+	return transaction, err
+}
+
+func (c *Client) MarketProductGetList() ([]robot.MarketProduct, error) {
+	var products []robot.MarketProduct
+	err := c.do("MarketProductGetList", true, func() (err error) {
+		products, err = c.RobotClient.MarketProductGetList()
+		return err
+	})
+
+	return products, err
+}
+
+func (c *Client) MarketTransactionGet(id string) (*robot.MarketTransaction, error) {
+	var transaction *robot.MarketTransaction
+	err := c.do("MarketTransactionGet", true, func() (err error) {
+		transaction, err = c.RobotClient.MarketTransactionGet(id)
+		return err
+	})
+
+	return transaction, err
+}
+
+func (c *Client) MarketTransactionCreate(input *robot.MarketOrderInput) (*robot.MarketTransaction, error) {
+	var transaction *robot.MarketTransaction
+	err := c.do("MarketTransactionCreate", false, func() (err error) {
+		transaction, err = c.RobotClient.MarketTransactionCreate(input)
+		return err
+	})
+
+	return transaction, err
+}
+
+func (c *Client) AddonProductGetList(serverNumber int) ([]robot.AddonProduct, error) {
+	var products []robot.AddonProduct
+	err := c.do("AddonProductGetList", true, func() (err error) {
+		products, err = c.RobotClient.AddonProductGetList(serverNumber)
+		return err
+	})
+
+	return products, err
+}
+
+func (c *Client) AddonTransactionGet(id string) (*robot.AddonTransaction, error) {
+	var transaction *robot.AddonTransaction
+	err := c.do("AddonTransactionGet", true, func() (err error) {
+		transaction, err = c.RobotClient.AddonTransactionGet(id)
+		return err
+	})
+
+	return transaction, err
+}
+
+func (c *Client) AddonTransactionCreate(input *robot.AddonOrderInput) (*robot.AddonTransaction, error) {
+	var transaction *robot.AddonTransaction
+	err := c.do("AddonTransactionCreate", false, func() (err error) {
+		transaction, err = c.RobotClient.AddonTransactionCreate(input)
+		return err
+	})
+
+	return transaction, err
+}
+
+func (c *Client) ServerCancellationGet(serverIP string) (*models.Cancellation, error) {
+	var cancellation *models.Cancellation
+	err := c.do("ServerCancellationGet", true, func() (err error) {
+		cancellation, err = c.RobotClient.ServerCancellationGet(serverIP)
+		return err
+	})
+
+	return cancellation, err
+}
+
+func (c *Client) VSwitchGetList() ([]robot.VSwitch, error) {
+	var vSwitches []robot.VSwitch
+	err := c.do("VSwitchGetList", true, func() (err error) {
+		vSwitches, err = c.RobotClient.VSwitchGetList()
+		return err
+	})
+
+	return vSwitches, err
+}
+
+func (c *Client) VSwitchGet(id int) (*robot.VSwitch, error) {
+	var vSwitch *robot.VSwitch
+	err := c.do("VSwitchGet", true, func() (err error) {
+		vSwitch, err = c.RobotClient.VSwitchGet(id)
+		return err
+	})
+
+	return vSwitch, err
+}
+
+func (c *Client) VSwitchCreate(input *robot.VSwitchInput) (*robot.VSwitch, error) {
+	var vSwitch *robot.VSwitch
+	err := c.do("VSwitchCreate", false, func() (err error) {
+		vSwitch, err = c.RobotClient.VSwitchCreate(input)
+		return err
+	})
+
+	return vSwitch, err
+}
+
+func (c *Client) VSwitchUpdate(id int, input *robot.VSwitchInput) error {
+	return c.do("VSwitchUpdate", false, func() error {
+		return c.RobotClient.VSwitchUpdate(id, input)
+	})
+}
+
+func (c *Client) VSwitchCancel(id int, cancellationDate string) error {
+	return c.do("VSwitchCancel", false, func() error {
+		return c.RobotClient.VSwitchCancel(id, cancellationDate)
+	})
+}
+
+func (c *Client) VSwitchAddServers(id int, servers []string) error {
+	return c.do("VSwitchAddServers", false, func() error {
+		return c.RobotClient.VSwitchAddServers(id, servers)
+	})
+}
+
+func (c *Client) VSwitchRemoveServers(id int, servers []string) error {
+	return c.do("VSwitchRemoveServers", false, func() error {
+		return c.RobotClient.VSwitchRemoveServers(id, servers)
+	})
+}
+
+// do runs the call until it succeeds, fails with an error that can't be
+// retried or the retry budget is used up
+func (c *Client) do(call string, idempotent bool, fn func() error) error {
+	var waited time.Duration
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		reason, delay, retryable := c.backoff(err, attempt, idempotent)
+		if !retryable {
+			return err
+		}
+
+		if attempt > c.maxRetries || waited+delay > c.maxWait {
+			return &ExhaustedError{Call: call, Attempts: attempt, Err: err}
+		}
+
+		c.logger.Warnf("%s: %s, retrying in %s (attempt %d of %d) ...", call, reason, delay, attempt+1, c.maxRetries+1)
+
+		time.Sleep(delay)
+		waited += delay
+	}
+}
+
+// backoff decides whether the error is worth a retry and how long to wait
+// before the given attempt is repeated
+func (c *Client) backoff(err error, attempt int, idempotent bool) (string, time.Duration, bool) {
+	delay := c.baseDelay << uint(attempt-1)
+
+	// server errors of proxies and timeouts don't have a webservice error body
+	var statusErr *robot.StatusError
+	if errors.As(err, &statusErr) {
+		return fmt.Sprintf("server error %d", statusErr.StatusCode), delay, idempotent
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout", delay, idempotent
+	}
+
+	var apiErr apiError
+	if jsonErr := json.Unmarshal([]byte(err.Error()), &apiErr); jsonErr != nil {
+		return "", 0, false
+	}
+
+	switch {
+	case apiErr.Error.Code == codeRateLimitExceeded:
+		// the interval is the time window of the limit, retrying earlier fails again
+		if apiErr.Error.Interval > 0 {
+			delay = time.Duration(apiErr.Error.Interval) * time.Second
+		}
+
+		return "rate limit exceeded", delay, true
+	case apiErr.Error.Status >= 500 && idempotent:
+		return fmt.Sprintf("server error %d", apiErr.Error.Status), delay, true
+	}
+
+	return "", 0, false
+}
@@ -0,0 +1,233 @@
+package retry_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/retry"
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const rateLimitError = `{"error":{"status":403,"code":"RATE_LIMIT_EXCEEDED","message":"Rate limit exceeded","max_request":200,"interval":3600}}`
+const rateLimitShortError = `{"error":{"status":403,"code":"RATE_LIMIT_EXCEEDED","message":"Rate limit exceeded","max_request":200,"interval":1}}`
+const rateLimitNoIntervalError = `{"error":{"status":403,"code":"RATE_LIMIT_EXCEEDED","message":"Rate limit exceeded"}}`
+const serverError = `{"error":{"status":500,"code":"INTERNAL_ERROR","message":"Internal error"}}`
+const notFoundError = `{"error":{"status":404,"code":"SERVER_NOT_FOUND","message":"Server not found"}}`
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type RetrySuite struct {
+	logger *log.Logger
+}
+
+var _ = Suite(&RetrySuite{})
+
+func (s *RetrySuite) SetUpSuite(c *C) {
+	s.logger = log.New()
+	s.logger.SetOutput(ioutil.Discard)
+}
+
+func (s *RetrySuite) TestRateLimitRetried(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	result := []models.Server{
+		{
+			ServerIP:   "123.123.123.123",
+			ServerName: "app-prod-42",
+		},
+	}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().ServerGetList().Times(2).Return(nil, errors.New(rateLimitNoIntervalError)),
+		mockRobotClient.EXPECT().ServerGetList().Times(1).Return(result, nil),
+	)
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	servers, err := retryClient.ServerGetList()
+	c.Assert(err, IsNil)
+	c.Assert(servers, DeepEquals, result)
+}
+
+func (s *RetrySuite) TestRateLimitExhausted(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	input := &models.ServerSetNameInput{Name: "app-prod-43"}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerSetName("123.123.123.123", input).Times(3).Return(nil, errors.New(rateLimitNoIntervalError))
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 2, time.Millisecond, time.Second)
+
+	_, err := retryClient.ServerSetName("123.123.123.123", input)
+
+	var exhaustedErr *retry.ExhaustedError
+	c.Assert(errors.As(err, &exhaustedErr), Equals, true)
+	c.Assert(exhaustedErr.Call, Equals, "ServerSetName")
+	c.Assert(exhaustedErr.Attempts, Equals, 3)
+}
+
+func (s *RetrySuite) TestRateLimitWaitsForInterval(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().KeyGetList().Times(1).Return(nil, errors.New(rateLimitShortError)),
+		mockRobotClient.EXPECT().KeyGetList().Times(1).Return([]models.Key{}, nil),
+	)
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Minute)
+
+	start := time.Now()
+	_, err := retryClient.KeyGetList()
+	c.Assert(err, IsNil)
+	c.Assert(time.Since(start) >= time.Second, Equals, true)
+}
+
+func (s *RetrySuite) TestRateLimitIntervalExceedsMaxWait(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return(nil, errors.New(rateLimitError))
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 5, time.Millisecond, time.Minute)
+
+	_, err := retryClient.KeyGetList()
+
+	var exhaustedErr *retry.ExhaustedError
+	c.Assert(errors.As(err, &exhaustedErr), Equals, true)
+	c.Assert(exhaustedErr.Attempts, Equals, 1)
+}
+
+func (s *RetrySuite) TestMaxWaitExhausted(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().KeyGetList().Times(1).Return(nil, errors.New(rateLimitError))
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 5, time.Second, time.Millisecond)
+
+	_, err := retryClient.KeyGetList()
+
+	var exhaustedErr *retry.ExhaustedError
+	c.Assert(errors.As(err, &exhaustedErr), Equals, true)
+}
+
+func (s *RetrySuite) TestServerErrorRetriedForReads(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().IPGetList().Times(1).Return(nil, errors.New(serverError)),
+		mockRobotClient.EXPECT().IPGetList().Times(1).Return([]models.IP{}, nil),
+	)
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	_, err := retryClient.IPGetList()
+	c.Assert(err, IsNil)
+}
+
+func (s *RetrySuite) TestServerErrorNotRetriedForWrites(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	input := &models.ResetSetInput{Type: models.ResetTypeHardware}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ResetSet("123.123.123.123", input).Times(1).Return(nil, errors.New(serverError))
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	_, err := retryClient.ResetSet("123.123.123.123", input)
+	c.Assert(err, ErrorMatches, ".*INTERNAL_ERROR.*")
+}
+
+func (s *RetrySuite) TestClientErrorNotRetried(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGet("123.123.123.123").Times(1).Return(nil, errors.New(notFoundError))
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	_, err := retryClient.ServerGet("123.123.123.123")
+	c.Assert(err, ErrorMatches, ".*SERVER_NOT_FOUND.*")
+}
+
+func (s *RetrySuite) TestProxyErrorRetriedForReads(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	proxyErr := &url.Error{Op: "Get", URL: "https://robot-ws.your-server.de/server", Err: &robot.StatusError{StatusCode: 502, Status: "502 Bad Gateway"}}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().ServerGetList().Times(1).Return(nil, proxyErr),
+		mockRobotClient.EXPECT().ServerGetList().Times(1).Return([]models.Server{}, nil),
+	)
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	_, err := retryClient.ServerGetList()
+	c.Assert(err, IsNil)
+}
+
+func (s *RetrySuite) TestProxyErrorNotRetriedForWrites(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	input := &models.ResetSetInput{Type: models.ResetTypeHardware}
+	proxyErr := &url.Error{Op: "Post", URL: "https://robot-ws.your-server.de/reset/123.123.123.123", Err: &robot.StatusError{StatusCode: 504, Status: "504 Gateway Timeout"}}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ResetSet("123.123.123.123", input).Times(1).Return(nil, proxyErr)
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	_, err := retryClient.ResetSet("123.123.123.123", input)
+	c.Assert(err, ErrorMatches, ".*webservice returned 504 Gateway Timeout")
+}
+
+// timeoutError is a network error like the ones of the http client
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func (s *RetrySuite) TestTimeoutRetriedForReads(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	timeoutErr := &url.Error{Op: "Get", URL: "https://robot-ws.your-server.de/ip", Err: timeoutError{}}
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	gomock.InOrder(
+		mockRobotClient.EXPECT().IPGetList().Times(2).Return(nil, timeoutErr),
+		mockRobotClient.EXPECT().IPGetList().Times(1).Return([]models.IP{}, nil),
+	)
+
+	retryClient := retry.NewClient(mockRobotClient, s.logger, 3, time.Millisecond, time.Second)
+
+	_, err := retryClient.IPGetList()
+	c.Assert(err, IsNil)
+}
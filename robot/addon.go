@@ -0,0 +1,120 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+type AddonProductResponse struct {
+	Product AddonProduct `json:"product"`
+}
+
+// AddonProduct is an addon like an additional IP or subnet which can be
+// ordered for a single server
+type AddonProduct struct {
+	ID    string        `json:"id"`
+	Name  string        `json:"name"`
+	Type  string        `json:"type"`
+	Price LocationPrice `json:"price"`
+}
+
+// AddonResource is a resource created by an addon order, e.g. an IP or a
+// subnet
+type AddonResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type AddonTransactionResponse struct {
+	Transaction AddonTransaction `json:"transaction"`
+}
+
+// AddonTransaction is the order of an addon, the resources are set once the
+// order is ready
+type AddonTransaction struct {
+	ID           string          `json:"id"`
+	Date         string          `json:"date"`
+	Status       string          `json:"status"`
+	ServerNumber int             `json:"server_number"`
+	Product      AddonProduct    `json:"product"`
+	Resources    []AddonResource `json:"resources"`
+}
+
+// AddonOrderInput orders an addon for a server, additional IP's require a
+// reason. Orders with Test set are validated but not executed.
+type AddonOrderInput struct {
+	ServerNumber int
+	ProductID    string
+	Reason       string
+	Gateway      string
+	Test         bool
+}
+
+func (c *Client) AddonProductGetList(serverNumber int) ([]AddonProduct, error) {
+	url := fmt.Sprintf(c.baseURL+"/order/server_addon/%d/product", serverNumber)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var productsResp []AddonProductResponse
+	err = json.Unmarshal(bytes, &productsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []AddonProduct
+	for _, product := range productsResp {
+		data = append(data, product.Product)
+	}
+
+	return data, nil
+}
+
+func (c *Client) AddonTransactionGet(id string) (*AddonTransaction, error) {
+	url := fmt.Sprintf(c.baseURL+"/order/server_addon/transaction/%s", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAddonTransaction(bytes)
+}
+
+func (c *Client) AddonTransactionCreate(input *AddonOrderInput) (*AddonTransaction, error) {
+	url := c.baseURL + "/order/server_addon/transaction"
+
+	formData := neturl.Values{}
+	formData.Set("server_number", strconv.Itoa(input.ServerNumber))
+	formData.Set("product_id", input.ProductID)
+	if input.Reason != "" {
+		formData.Set("reason", input.Reason)
+	}
+	if input.Gateway != "" {
+		formData.Set("gateway", input.Gateway)
+	}
+	if input.Test {
+		formData.Set("test", "true")
+	}
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAddonTransaction(bytes)
+}
+
+func parseAddonTransaction(bytes []byte) (*AddonTransaction, error) {
+	var transactionResp AddonTransactionResponse
+	err := json.Unmarshal(bytes, &transactionResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResp.Transaction, nil
+}
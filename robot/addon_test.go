@@ -0,0 +1,62 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestAddonProductGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "addon_product_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/order/server_addon/321/product")
+	})
+	defer closeFn()
+
+	products, err := robotClient.AddonProductGetList(321)
+	c.Assert(err, IsNil)
+	c.Assert(len(products), Equals, 2)
+	c.Assert(products[0].ID, Equals, "additional_ipv4")
+	c.Assert(products[0].Type, Equals, "ip_ipv4")
+	c.Assert(products[1].Price.Price.Net, Equals, "6.7200")
+}
+
+func (s *RobotSuite) TestAddonTransactionGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "addon_transaction_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/order/server_addon/transaction/B20220210-1843193-S33055")
+	})
+	defer closeFn()
+
+	transaction, err := robotClient.AddonTransactionGet("B20220210-1843193-S33055")
+	c.Assert(err, IsNil)
+	c.Assert(transaction.Status, Equals, robot.TransactionStatusReady)
+	c.Assert(transaction.Resources, DeepEquals, []robot.AddonResource{{Type: "ip", ID: "124.124.124.124"}})
+}
+
+func (s *RobotSuite) TestAddonTransactionCreateTest(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "addon_transaction_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/order/server_addon/transaction")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("server_number"), Equals, "321")
+		c.Assert(r.PostForm.Get("product_id"), Equals, "additional_ipv4")
+		c.Assert(r.PostForm.Get("reason"), Equals, "VPN endpoint")
+		c.Assert(r.PostForm.Get("test"), Equals, "true")
+		_, ok := r.PostForm["gateway"]
+		c.Assert(ok, Equals, false)
+	})
+	defer closeFn()
+
+	input := &robot.AddonOrderInput{
+		ServerNumber: 321,
+		ProductID:    "additional_ipv4",
+		Reason:       "VPN endpoint",
+		Test:         true,
+	}
+
+	_, err := robotClient.AddonTransactionCreate(input)
+	c.Assert(err, IsNil)
+}
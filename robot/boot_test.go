@@ -0,0 +1,49 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *RobotSuite) TestBootRescueGetInactive(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "boot_rescue_get_inactive.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/boot/123.123.123.123/rescue")
+	})
+	defer closeFn()
+
+	rescue, err := robotClient.BootRescueGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(rescue.Active, Equals, false)
+	c.Assert(rescue.Os, DeepEquals, []interface{}{"linux", "freebsd", "vkvm"})
+}
+
+func (s *RobotSuite) TestBootRescueGetActive(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "boot_rescue_get_active.json", nil)
+	defer closeFn()
+
+	rescue, err := robotClient.BootRescueGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(rescue.Active, Equals, true)
+	c.Assert(rescue.AuthorizedKey, HasLen, 1)
+}
+
+func (s *RobotSuite) TestBootRescueSetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "boot_rescue_set.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/boot/123.123.123.123/rescue")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("os"), Equals, "linux")
+		c.Assert(r.PostForm.Get("arch"), Equals, "64")
+		c.Assert(r.PostForm.Get("authorized_key"), Equals, "fi:ng:er:pr:in:t0")
+	})
+	defer closeFn()
+
+	input := &models.RescueSetInput{OS: "linux", Arch: 64, AuthorizedKey: "fi:ng:er:pr:in:t0"}
+	rescue, err := robotClient.BootRescueSet("123.123.123.123", input)
+	c.Assert(err, IsNil)
+	c.Assert(rescue.Active, Equals, true)
+}
@@ -0,0 +1,27 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// ServerCancellationGet returns the cancellation status of a server, the
+// cancellation date is only set for cancelled servers
+func (c *Client) ServerCancellationGet(serverIP string) (*models.Cancellation, error) {
+	url := fmt.Sprintf(c.baseURL+"/server/%s/cancellation", serverIP)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var cancellationResp models.CancellationResponse
+	err = json.Unmarshal(bytes, &cancellationResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cancellationResp.Cancellation, nil
+}
@@ -0,0 +1,20 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *RobotSuite) TestServerCancellationGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "server_cancellation_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/server/123.123.123.123/cancellation")
+	})
+	defer closeFn()
+
+	cancellation, err := robotClient.ServerCancellationGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(cancellation.Cancelled, Equals, true)
+	c.Assert(cancellation.CancellationDate, Equals, "2030-01-31")
+}
@@ -0,0 +1,162 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BaseURL is the URL of the webservice
+const BaseURL string = "https://robot-ws.your-server.de"
+
+// DefaultTimeout limits a single request including reading the response
+const DefaultTimeout = time.Minute
+
+// hrobotGoVersion is the version of hrobot-go whose endpoints the client
+// implements with its own http client
+const hrobotGoVersion = "0.1.3"
+
+// Client implements the endpoints of hrobot-go and the additional endpoints
+// with a single http client, the requests of hrobot-go can't be given a
+// timeout or transport
+type Client struct {
+	httpClient *http.Client
+	username   string
+	password   string
+	baseURL    string
+	userAgent  string
+}
+
+func NewBasicAuthClient(username, password string) RobotClient {
+	return NewClient(username, password, NewHTTPClient(DefaultTimeout))
+}
+
+func NewClient(username, password string, httpClient *http.Client) RobotClient {
+	return &Client{
+		httpClient: httpClient,
+		username:   username,
+		password:   password,
+		baseURL:    BaseURL,
+	}
+}
+
+// NewHTTPClient returns a http client for the webservice, server errors
+// without webservice error body are returned as StatusError
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewTransport(http.DefaultTransport),
+	}
+}
+
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = baseURL
+}
+
+func (c *Client) SetUserAgent(userAgent string) {
+	c.userAgent = userAgent
+}
+
+func (c *Client) GetVersion() string {
+	return hrobotGoVersion
+}
+
+func (c *Client) doGetRequest(url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRequest(req)
+}
+
+func (c *Client) doPostFormRequest(url string, formData url.Values) ([]byte, error) {
+	return c.doFormRequest("POST", url, formData)
+}
+
+func (c *Client) doPutFormRequest(url string, formData url.Values) ([]byte, error) {
+	return c.doFormRequest("PUT", url, formData)
+}
+
+func (c *Client) doDeleteRequest(url string) ([]byte, error) {
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRequest(req)
+}
+
+func (c *Client) doFormRequest(method string, url string, formData url.Values) ([]byte, error) {
+	req, err := http.NewRequest(method, url, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.doRequest(req)
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	req.SetBasicAuth(c.username, c.password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// same error format as hrobot-go, the body contains the error of the webservice
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s", body)
+	}
+
+	return body, nil
+}
+
+// CodeNotFound is returned for missing objects and by some list calls for
+// empty lists
+const CodeNotFound = "NOT_FOUND"
+
+// ErrorCode returns the code of an error returned by the webservice, e.g.
+// NOT_FOUND, or an empty string for other errors
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+
+	if jsonErr := json.Unmarshal([]byte(err.Error()), &body); jsonErr != nil {
+		return ""
+	}
+
+	return body.Error.Code
+}
+
+// ListError returns nil for NOT_FOUND errors of list calls as the webservice
+// answers with NOT_FOUND instead of an empty list, other errors are returned
+// unchanged
+func ListError(err error) error {
+	if ErrorCode(err) == CodeNotFound {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,32 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *RobotSuite) TestFailoverGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "failover_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/failover")
+	})
+	defer closeFn()
+
+	failovers, err := robotClient.FailoverGetList()
+	c.Assert(err, IsNil)
+	c.Assert(failovers, HasLen, 1)
+	c.Assert(failovers[0].ActiveServerIP, Equals, "78.46.1.93")
+}
+
+func (s *RobotSuite) TestFailoverGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "failover_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/failover/123.123.123.123")
+	})
+	defer closeFn()
+
+	failover, err := robotClient.FailoverGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(failover.ServerIP, Equals, "78.46.1.93")
+}
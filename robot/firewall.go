@@ -0,0 +1,237 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+const (
+	FirewallStatusActive   = "active"
+	FirewallStatusDisabled = "disabled"
+
+	FirewallActionAccept  = "accept"
+	FirewallActionDiscard = "discard"
+)
+
+type FirewallResponse struct {
+	Firewall Firewall `json:"firewall"`
+}
+
+// Firewall is the stateless firewall of a server, rules are applied in order
+// and the first matching rule wins
+type Firewall struct {
+	ServerIP     string        `json:"server_ip"`
+	ServerNumber int           `json:"server_number"`
+	Status       string        `json:"status"`
+	FilterIPv6   bool          `json:"filter_ipv6"`
+	WhitelistHOS bool          `json:"whitelist_hos"`
+	Port         string        `json:"port"`
+	Rules        FirewallRules `json:"rules"`
+}
+
+type FirewallRules struct {
+	Input  []FirewallRule `json:"input" yaml:"input"`
+	Output []FirewallRule `json:"output,omitempty" yaml:"output,omitempty"`
+}
+
+type FirewallRule struct {
+	IPVersion string `json:"ip_version" yaml:"ip_version,omitempty"`
+	Name      string `json:"name" yaml:"name,omitempty"`
+	DstIP     string `json:"dst_ip" yaml:"dst_ip,omitempty"`
+	SrcIP     string `json:"src_ip" yaml:"src_ip,omitempty"`
+	DstPort   string `json:"dst_port" yaml:"dst_port,omitempty"`
+	SrcPort   string `json:"src_port" yaml:"src_port,omitempty"`
+	Protocol  string `json:"protocol" yaml:"protocol,omitempty"`
+	TCPFlags  string `json:"tcp_flags" yaml:"tcp_flags,omitempty"`
+	Action    string `json:"action" yaml:"action"`
+}
+
+// FirewallInput replaces the complete configuration of a firewall
+type FirewallInput struct {
+	Status       string
+	FilterIPv6   bool
+	WhitelistHOS bool
+	Rules        FirewallRules
+}
+
+type FirewallTemplateResponse struct {
+	FirewallTemplate FirewallTemplate `json:"firewall_template"`
+}
+
+// FirewallTemplate is a reusable rule set, the rules are only returned for a
+// single template
+type FirewallTemplate struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	FilterIPv6   bool          `json:"filter_ipv6"`
+	WhitelistHOS bool          `json:"whitelist_hos"`
+	IsDefault    bool          `json:"is_default"`
+	Rules        FirewallRules `json:"rules"`
+}
+
+type FirewallTemplateInput struct {
+	Name         string
+	FilterIPv6   bool
+	WhitelistHOS bool
+	IsDefault    bool
+	Rules        FirewallRules
+}
+
+func (c *Client) FirewallGet(serverIP string) (*Firewall, error) {
+	url := fmt.Sprintf(c.baseURL+"/firewall/%s", serverIP)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFirewall(bytes)
+}
+
+func (c *Client) FirewallSet(serverIP string, input *FirewallInput) (*Firewall, error) {
+	url := fmt.Sprintf(c.baseURL+"/firewall/%s", serverIP)
+
+	formData := neturl.Values{}
+	formData.Set("status", input.Status)
+	formData.Set("filter_ipv6", strconv.FormatBool(input.FilterIPv6))
+	formData.Set("whitelist_hos", strconv.FormatBool(input.WhitelistHOS))
+	addFirewallRules(formData, &input.Rules)
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFirewall(bytes)
+}
+
+// FirewallApplyTemplate replaces the firewall configuration of the server
+// with the template and activates the firewall
+func (c *Client) FirewallApplyTemplate(serverIP string, templateID int) (*Firewall, error) {
+	url := fmt.Sprintf(c.baseURL+"/firewall/%s", serverIP)
+
+	formData := neturl.Values{}
+	formData.Set("template_id", strconv.Itoa(templateID))
+	formData.Set("status", FirewallStatusActive)
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFirewall(bytes)
+}
+
+func (c *Client) FirewallTemplateGetList() ([]FirewallTemplate, error) {
+	url := c.baseURL + "/firewall/template"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var templatesResp []FirewallTemplateResponse
+	err = json.Unmarshal(bytes, &templatesResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []FirewallTemplate
+	for _, template := range templatesResp {
+		data = append(data, template.FirewallTemplate)
+	}
+
+	return data, nil
+}
+
+func (c *Client) FirewallTemplateGet(id int) (*FirewallTemplate, error) {
+	url := fmt.Sprintf(c.baseURL+"/firewall/template/%d", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var templateResp FirewallTemplateResponse
+	err = json.Unmarshal(bytes, &templateResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateResp.FirewallTemplate, nil
+}
+
+func (c *Client) FirewallTemplateCreate(input *FirewallTemplateInput) (*FirewallTemplate, error) {
+	url := c.baseURL + "/firewall/template"
+
+	formData := neturl.Values{}
+	formData.Set("name", input.Name)
+	formData.Set("filter_ipv6", strconv.FormatBool(input.FilterIPv6))
+	formData.Set("whitelist_hos", strconv.FormatBool(input.WhitelistHOS))
+	formData.Set("is_default", strconv.FormatBool(input.IsDefault))
+	addFirewallRules(formData, &input.Rules)
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	var templateResp FirewallTemplateResponse
+	err = json.Unmarshal(bytes, &templateResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateResp.FirewallTemplate, nil
+}
+
+func parseFirewall(bytes []byte) (*Firewall, error) {
+	var firewallResp FirewallResponse
+	err := json.Unmarshal(bytes, &firewallResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &firewallResp.Firewall, nil
+}
+
+// addFirewallRules encodes the rules as rules[input][0][name]=..., empty
+// fields are left out
+func addFirewallRules(formData neturl.Values, rules *FirewallRules) {
+	directions := []struct {
+		name  string
+		rules []FirewallRule
+	}{
+		{"input", rules.Input},
+		{"output", rules.Output},
+	}
+
+	for _, direction := range directions {
+		for i, rule := range direction.rules {
+			prefix := fmt.Sprintf("rules[%s][%d]", direction.name, i)
+
+			fields := []struct {
+				name  string
+				value string
+			}{
+				{"ip_version", rule.IPVersion},
+				{"name", rule.Name},
+				{"dst_ip", rule.DstIP},
+				{"src_ip", rule.SrcIP},
+				{"dst_port", rule.DstPort},
+				{"src_port", rule.SrcPort},
+				{"protocol", rule.Protocol},
+				{"tcp_flags", rule.TCPFlags},
+				{"action", rule.Action},
+			}
+
+			for _, field := range fields {
+				if field.value != "" {
+					formData.Set(prefix+"["+field.name+"]", field.value)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestFirewallGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "firewall_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/firewall/123.123.123.123")
+	})
+	defer closeFn()
+
+	firewall, err := robotClient.FirewallGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(firewall.Status, Equals, robot.FirewallStatusActive)
+	c.Assert(firewall.WhitelistHOS, Equals, true)
+	c.Assert(firewall.Rules.Input, HasLen, 1)
+	c.Assert(firewall.Rules.Input[0].SrcIP, Equals, "1.1.1.1")
+	c.Assert(firewall.Rules.Input[0].Protocol, Equals, "")
+	c.Assert(firewall.Rules.Output, HasLen, 1)
+}
+
+func (s *RobotSuite) TestFirewallSetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "firewall_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/firewall/123.123.123.123")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("status"), Equals, "active")
+		c.Assert(r.PostForm.Get("whitelist_hos"), Equals, "true")
+		c.Assert(r.PostForm.Get("rules[input][0][name]"), Equals, "rule 1")
+		c.Assert(r.PostForm.Get("rules[input][0][dst_port]"), Equals, "80")
+		c.Assert(r.PostForm.Get("rules[input][1][action]"), Equals, "discard")
+		_, ok := r.PostForm["rules[input][0][src_ip]"]
+		c.Assert(ok, Equals, false)
+	})
+	defer closeFn()
+
+	input := &robot.FirewallInput{
+		Status:       robot.FirewallStatusActive,
+		WhitelistHOS: true,
+		Rules: robot.FirewallRules{
+			Input: []robot.FirewallRule{
+				{Name: "rule 1", DstPort: "80", Action: robot.FirewallActionAccept},
+				{Action: robot.FirewallActionDiscard},
+			},
+		},
+	}
+
+	_, err := robotClient.FirewallSet("123.123.123.123", input)
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestFirewallApplyTemplateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "firewall_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("template_id"), Equals, "2")
+		c.Assert(r.PostForm.Get("status"), Equals, "active")
+	})
+	defer closeFn()
+
+	_, err := robotClient.FirewallApplyTemplate("123.123.123.123", 2)
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestFirewallTemplateGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "firewall_template_list.json", func(r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/firewall/template")
+	})
+	defer closeFn()
+
+	templates, err := robotClient.FirewallTemplateGetList()
+	c.Assert(err, IsNil)
+	c.Assert(templates, HasLen, 2)
+	c.Assert(templates[0].IsDefault, Equals, true)
+	c.Assert(templates[1].Name, Equals, "Webserver")
+}
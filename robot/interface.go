@@ -0,0 +1,63 @@
+package robot
+
+import (
+	client "github.com/nl2go/hrobot-go"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// RobotClient extends the client of hrobot-go with webservice endpoints not
+// covered by the library yet
+type RobotClient interface {
+	client.RobotClient
+	TrafficGet(input *TrafficGetInput) (*Traffic, error)
+	RDnsSet(ip string, ptr string) (*models.Rdns, error)
+	IPGet(ip string) (*IP, error)
+	IPUpdate(ip string, input *IPUpdateInput) (*IP, error)
+	IPMacGet(ip string) (*Mac, error)
+	IPMacGenerate(ip string) (*Mac, error)
+	IPMacDelete(ip string) (*Mac, error)
+	SubnetGetList() ([]Subnet, error)
+	SubnetGet(ip string) (*Subnet, error)
+	SubnetMacGet(ip string) (*Mac, error)
+	SubnetMacSet(ip string, mac string) (*Mac, error)
+	SubnetMacDelete(ip string) (*Mac, error)
+	FirewallGet(serverIP string) (*Firewall, error)
+	FirewallSet(serverIP string, input *FirewallInput) (*Firewall, error)
+	FirewallApplyTemplate(serverIP string, templateID int) (*Firewall, error)
+	FirewallTemplateGetList() ([]FirewallTemplate, error)
+	FirewallTemplateGet(id int) (*FirewallTemplate, error)
+	FirewallTemplateCreate(input *FirewallTemplateInput) (*FirewallTemplate, error)
+	StorageBoxGetList() ([]StorageBox, error)
+	StorageBoxGet(id int) (*StorageBox, error)
+	StorageBoxUpdate(id int, input *StorageBoxInput) (*StorageBox, error)
+	StorageBoxSnapshotGetList(id int) ([]StorageBoxSnapshot, error)
+	StorageBoxSnapshotCreate(id int) (*StorageBoxSnapshot, error)
+	StorageBoxSnapshotDelete(id int, name string) error
+	StorageBoxSnapshotRevert(id int, name string) error
+	StorageBoxSnapshotPlanGet(id int) (*StorageBoxSnapshotPlan, error)
+	StorageBoxSnapshotPlanSet(id int, plan *StorageBoxSnapshotPlan) (*StorageBoxSnapshotPlan, error)
+	StorageBoxSubaccountGetList(id int) ([]StorageBoxSubaccount, error)
+	StorageBoxSubaccountCreate(id int, input *StorageBoxSubaccountInput) (*StorageBoxSubaccount, error)
+	StorageBoxSubaccountUpdate(id int, username string, input *StorageBoxSubaccountInput) error
+	StorageBoxSubaccountDelete(id int, username string) error
+	WolGet(serverIP string) (*Wol, error)
+	WolSend(serverIP string) (*Wol, error)
+	OrderServerProductGetList() ([]ServerProduct, error)
+	OrderServerTransactionGetList() ([]ServerTransaction, error)
+	OrderServerTransactionGet(id string) (*ServerTransaction, error)
+	OrderServerTransactionCreate(input *ServerOrderInput) (*ServerTransaction, error)
+	MarketProductGetList() ([]MarketProduct, error)
+	MarketTransactionGet(id string) (*MarketTransaction, error)
+	MarketTransactionCreate(input *MarketOrderInput) (*MarketTransaction, error)
+	AddonProductGetList(serverNumber int) ([]AddonProduct, error)
+	AddonTransactionGet(id string) (*AddonTransaction, error)
+	AddonTransactionCreate(input *AddonOrderInput) (*AddonTransaction, error)
+	ServerCancellationGet(serverIP string) (*models.Cancellation, error)
+	VSwitchGetList() ([]VSwitch, error)
+	VSwitchGet(id int) (*VSwitch, error)
+	VSwitchCreate(input *VSwitchInput) (*VSwitch, error)
+	VSwitchUpdate(id int, input *VSwitchInput) error
+	VSwitchCancel(id int, cancellationDate string) error
+	VSwitchAddServers(id int, servers []string) error
+	VSwitchRemoveServers(id int, servers []string) error
+}
@@ -0,0 +1,100 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+type IPResponse struct {
+	IP IP `json:"ip"`
+}
+
+// IP holds the details of a single IP, the list of hrobot-go lacks the
+// network data
+type IP struct {
+	models.IP
+	Gateway   string `json:"gateway"`
+	Mask      int    `json:"mask"`
+	Broadcast string `json:"broadcast"`
+}
+
+// IPUpdateInput configures the traffic warnings of an IP, thresholds of zero
+// keep the current value. Hourly and daily thresholds are given in MB, the
+// monthly threshold in GB.
+type IPUpdateInput struct {
+	TrafficWarnings bool
+	TrafficHourly   int
+	TrafficDaily    int
+	TrafficMonthly  int
+}
+
+func (c *Client) IPGetList() ([]models.IP, error) {
+	url := c.baseURL + "/ip"
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []models.IPResponse
+	err = json.Unmarshal(bytes, &ips)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.IP
+	for _, ip := range ips {
+		data = append(data, ip.IP)
+	}
+
+	return data, nil
+}
+
+func (c *Client) IPGet(ip string) (*IP, error) {
+	url := fmt.Sprintf(c.baseURL+"/ip/%s", ip)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var ipResp IPResponse
+	err = json.Unmarshal(bytes, &ipResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ipResp.IP, nil
+}
+
+func (c *Client) IPUpdate(ip string, input *IPUpdateInput) (*IP, error) {
+	url := fmt.Sprintf(c.baseURL+"/ip/%s", ip)
+
+	formData := neturl.Values{}
+	formData.Set("traffic_warnings", strconv.FormatBool(input.TrafficWarnings))
+	if input.TrafficHourly > 0 {
+		formData.Set("traffic_hourly", strconv.Itoa(input.TrafficHourly))
+	}
+	if input.TrafficDaily > 0 {
+		formData.Set("traffic_daily", strconv.Itoa(input.TrafficDaily))
+	}
+	if input.TrafficMonthly > 0 {
+		formData.Set("traffic_monthly", strconv.Itoa(input.TrafficMonthly))
+	}
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	var ipResp IPResponse
+	err = json.Unmarshal(bytes, &ipResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ipResp.IP, nil
+}
@@ -0,0 +1,64 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestIPGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "ip_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/ip")
+	})
+	defer closeFn()
+
+	ips, err := robotClient.IPGetList()
+	c.Assert(err, IsNil)
+	c.Assert(ips, HasLen, 2)
+	c.Assert(ips[1].IP, Equals, "124.124.124.124")
+}
+
+func (s *RobotSuite) TestIPGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "ip_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/ip/123.123.123.123")
+	})
+	defer closeFn()
+
+	ip, err := robotClient.IPGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(ip.IP.IP, Equals, "123.123.123.123")
+	c.Assert(ip.ServerNumber, Equals, 321)
+	c.Assert(ip.Gateway, Equals, "123.123.123.97")
+	c.Assert(ip.Mask, Equals, 27)
+	c.Assert(ip.Broadcast, Equals, "123.123.123.127")
+	c.Assert(ip.TrafficDaily, Equals, 2000)
+}
+
+func (s *RobotSuite) TestIPUpdateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "ip_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/ip/123.123.123.123")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("traffic_warnings"), Equals, "true")
+		c.Assert(r.PostForm.Get("traffic_daily"), Equals, "2000")
+		c.Assert(r.PostForm.Get("traffic_monthly"), Equals, "20")
+		// thresholds not given keep their current value
+		_, ok := r.PostForm["traffic_hourly"]
+		c.Assert(ok, Equals, false)
+	})
+	defer closeFn()
+
+	input := &robot.IPUpdateInput{
+		TrafficWarnings: true,
+		TrafficDaily:    2000,
+		TrafficMonthly:  20,
+	}
+
+	ip, err := robotClient.IPUpdate("123.123.123.123", input)
+	c.Assert(err, IsNil)
+	c.Assert(ip.TrafficWarnings, Equals, true)
+}
@@ -0,0 +1,20 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *RobotSuite) TestKeyGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "key_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/key")
+	})
+	defer closeFn()
+
+	keys, err := robotClient.KeyGetList()
+	c.Assert(err, IsNil)
+	c.Assert(keys, HasLen, 2)
+	c.Assert(keys[0].Name, Equals, "key1")
+}
@@ -0,0 +1,88 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+)
+
+type MacResponse struct {
+	Mac Mac `json:"mac"`
+}
+
+// Mac holds the separate MAC address of an IP or IPv6 subnet, possible MAC
+// addresses are only returned for subnets and map a MAC to its server IP
+type Mac struct {
+	IP          string            `json:"ip"`
+	Mask        int               `json:"mask,omitempty"`
+	Mac         string            `json:"mac"`
+	PossibleMac map[string]string `json:"possible_mac,omitempty"`
+}
+
+func (c *Client) IPMacGet(ip string) (*Mac, error) {
+	return c.getMac(fmt.Sprintf(c.baseURL+"/ip/%s/mac", ip))
+}
+
+// IPMacGenerate generates a separate MAC address for a single IP
+func (c *Client) IPMacGenerate(ip string) (*Mac, error) {
+	bytes, err := c.doPutFormRequest(fmt.Sprintf(c.baseURL+"/ip/%s/mac", ip), neturl.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMac(bytes)
+}
+
+func (c *Client) IPMacDelete(ip string) (*Mac, error) {
+	bytes, err := c.doDeleteRequest(fmt.Sprintf(c.baseURL+"/ip/%s/mac", ip))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMac(bytes)
+}
+
+func (c *Client) SubnetMacGet(ip string) (*Mac, error) {
+	return c.getMac(fmt.Sprintf(c.baseURL+"/subnet/%s/mac", ip))
+}
+
+// SubnetMacSet routes an IPv6 subnet to one of its possible MAC addresses
+func (c *Client) SubnetMacSet(ip string, mac string) (*Mac, error) {
+	formData := neturl.Values{}
+	formData.Set("mac", mac)
+
+	bytes, err := c.doPutFormRequest(fmt.Sprintf(c.baseURL+"/subnet/%s/mac", ip), formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMac(bytes)
+}
+
+func (c *Client) SubnetMacDelete(ip string) (*Mac, error) {
+	bytes, err := c.doDeleteRequest(fmt.Sprintf(c.baseURL+"/subnet/%s/mac", ip))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMac(bytes)
+}
+
+func (c *Client) getMac(url string) (*Mac, error) {
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMac(bytes)
+}
+
+func parseMac(bytes []byte) (*Mac, error) {
+	var macResp MacResponse
+	err := json.Unmarshal(bytes, &macResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &macResp.Mac, nil
+}
@@ -0,0 +1,67 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *RobotSuite) TestIPMacGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "ip_mac_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/ip/123.123.123.123/mac")
+	})
+	defer closeFn()
+
+	mac, err := robotClient.IPMacGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(mac.Mac, Equals, "00:50:56:00:9c:5e")
+}
+
+func (s *RobotSuite) TestIPMacGenerateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "ip_mac_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "PUT")
+		c.Assert(r.URL.Path, Equals, "/ip/123.123.123.123/mac")
+	})
+	defer closeFn()
+
+	mac, err := robotClient.IPMacGenerate("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(mac.Mac, Equals, "00:50:56:00:9c:5e")
+}
+
+func (s *RobotSuite) TestIPMacDeleteSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "ip_mac_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "DELETE")
+		c.Assert(r.URL.Path, Equals, "/ip/123.123.123.123/mac")
+	})
+	defer closeFn()
+
+	_, err := robotClient.IPMacDelete("123.123.123.123")
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestSubnetMacGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "subnet_mac_get.json", func(r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/subnet/2a01:4f8:111:4221::/mac")
+	})
+	defer closeFn()
+
+	mac, err := robotClient.SubnetMacGet("2a01:4f8:111:4221::")
+	c.Assert(err, IsNil)
+	c.Assert(mac.Mask, Equals, 64)
+	c.Assert(mac.PossibleMac, HasLen, 2)
+	c.Assert(mac.PossibleMac["00:21:85:62:3d:8c"], Equals, "123.123.123.124")
+}
+
+func (s *RobotSuite) TestSubnetMacSetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "subnet_mac_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "PUT")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("mac"), Equals, "00:21:85:62:3e:9b")
+	})
+	defer closeFn()
+
+	_, err := robotClient.SubnetMacSet("2a01:4f8:111:4221::", "00:21:85:62:3e:9b")
+	c.Assert(err, IsNil)
+}
@@ -0,0 +1,151 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+type MarketProductResponse struct {
+	Product MarketProduct `json:"product"`
+}
+
+// MarketProduct is a server of the server auction, memory and disk sizes are
+// given in GB and prices as monthly net amounts in euro
+type MarketProduct struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Description    []string `json:"description"`
+	Traffic        string   `json:"traffic"`
+	Dist           []string `json:"dist"`
+	Arch           []int    `json:"arch"`
+	Lang           []string `json:"lang"`
+	CPU            string   `json:"cpu"`
+	CPUBenchmark   int      `json:"cpu_benchmark"`
+	MemorySize     int      `json:"memory_size"`
+	HddSize        int      `json:"hdd_size"`
+	HddText        string   `json:"hdd_text"`
+	HddCount       int      `json:"hdd_count"`
+	Datacenter     string   `json:"datacenter"`
+	NetworkSpeed   string   `json:"network_speed"`
+	Price          string   `json:"price"`
+	PriceSetup     string   `json:"price_setup"`
+	PriceVat       string   `json:"price_vat"`
+	PriceSetupVat  string   `json:"price_setup_vat"`
+	FixedPrice     bool     `json:"fixed_price"`
+	NextReduce     int      `json:"next_reduce"`
+	NextReduceDate string   `json:"next_reduce_date"`
+}
+
+type MarketTransactionResponse struct {
+	Transaction MarketTransaction `json:"transaction"`
+}
+
+// MarketTransaction is the order of a server auction server, server number
+// and IP are set once the server is ready
+type MarketTransaction struct {
+	ID           string                   `json:"id"`
+	Date         string                   `json:"date"`
+	Status       string                   `json:"status"`
+	ServerNumber int                      `json:"server_number"`
+	ServerIP     string                   `json:"server_ip"`
+	Comment      string                   `json:"comment"`
+	Product      MarketTransactionProduct `json:"product"`
+}
+
+type MarketTransactionProduct struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Dist       string `json:"dist"`
+	Arch       int    `json:"arch"`
+	Lang       string `json:"lang"`
+	Datacenter string `json:"datacenter"`
+}
+
+// MarketOrderInput orders a server auction server, orders with Test set are
+// validated but not executed
+type MarketOrderInput struct {
+	ProductID int
+	Keys      []string
+	Dist      string
+	Arch      int
+	Lang      string
+	Comment   string
+	Test      bool
+}
+
+func (c *Client) MarketProductGetList() ([]MarketProduct, error) {
+	url := c.baseURL + "/order/server_market/product"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var productsResp []MarketProductResponse
+	err = json.Unmarshal(bytes, &productsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []MarketProduct
+	for _, product := range productsResp {
+		data = append(data, product.Product)
+	}
+
+	return data, nil
+}
+
+func (c *Client) MarketTransactionGet(id string) (*MarketTransaction, error) {
+	url := fmt.Sprintf(c.baseURL+"/order/server_market/transaction/%s", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMarketTransaction(bytes)
+}
+
+func (c *Client) MarketTransactionCreate(input *MarketOrderInput) (*MarketTransaction, error) {
+	url := c.baseURL + "/order/server_market/transaction"
+
+	formData := neturl.Values{}
+	formData.Set("product_id", strconv.Itoa(input.ProductID))
+	for _, key := range input.Keys {
+		formData.Add("authorized_key[]", key)
+	}
+	if input.Dist != "" {
+		formData.Set("dist", input.Dist)
+	}
+	if input.Arch > 0 {
+		formData.Set("arch", strconv.Itoa(input.Arch))
+	}
+	if input.Lang != "" {
+		formData.Set("lang", input.Lang)
+	}
+	if input.Comment != "" {
+		formData.Set("comment", input.Comment)
+	}
+	if input.Test {
+		formData.Set("test", "true")
+	}
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMarketTransaction(bytes)
+}
+
+func parseMarketTransaction(bytes []byte) (*MarketTransaction, error) {
+	var transactionResp MarketTransactionResponse
+	err := json.Unmarshal(bytes, &transactionResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResp.Transaction, nil
+}
@@ -0,0 +1,49 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestMarketProductGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "market_product_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/order/server_market/product")
+	})
+	defer closeFn()
+
+	products, err := robotClient.MarketProductGetList()
+	c.Assert(err, IsNil)
+	c.Assert(len(products), Equals, 1)
+	c.Assert(products[0].ID, Equals, 283693)
+	c.Assert(products[0].MemorySize, Equals, 64)
+	c.Assert(products[0].HddCount, Equals, 2)
+	c.Assert(products[0].Price, Equals, "39.0000")
+	c.Assert(products[0].Datacenter, Equals, "FSN1-DC14")
+}
+
+func (s *RobotSuite) TestMarketTransactionCreateTest(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "market_transaction_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/order/server_market/transaction")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("product_id"), Equals, "283693")
+		c.Assert(r.PostForm["authorized_key[]"], DeepEquals, []string{"15:28:b0:03:95:f0:77:b3:10:56:15:6b:77:22:a5:bb"})
+		c.Assert(r.PostForm.Get("test"), Equals, "true")
+	})
+	defer closeFn()
+
+	input := &robot.MarketOrderInput{
+		ProductID: 283693,
+		Keys:      []string{"15:28:b0:03:95:f0:77:b3:10:56:15:6b:77:22:a5:bb"},
+		Test:      true,
+	}
+
+	transaction, err := robotClient.MarketTransactionCreate(input)
+	c.Assert(err, IsNil)
+	c.Assert(transaction.ID, Equals, "B20150121-344958-251479")
+	c.Assert(transaction.Product.ID, Equals, 283693)
+}
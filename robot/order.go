@@ -0,0 +1,188 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	TransactionStatusReady     = "ready"
+	TransactionStatusInProcess = "in process"
+	TransactionStatusCancelled = "cancelled"
+)
+
+// Price is a monthly or setup price in euro, the webservice returns the
+// amounts as decimal strings
+type Price struct {
+	Net   string `json:"net"`
+	Gross string `json:"gross"`
+}
+
+type LocationPrice struct {
+	Location   string `json:"location"`
+	Price      Price  `json:"price"`
+	PriceSetup Price  `json:"price_setup"`
+}
+
+type ServerProductResponse struct {
+	Product ServerProduct `json:"product"`
+}
+
+// ServerProduct is a dedicated server of the standard product catalog
+type ServerProduct struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description []string        `json:"description"`
+	Traffic     string          `json:"traffic"`
+	Dist        []string        `json:"dist"`
+	Arch        []int           `json:"arch"`
+	Lang        []string        `json:"lang"`
+	Location    []string        `json:"location"`
+	Prices      []LocationPrice `json:"prices"`
+}
+
+type ServerTransactionResponse struct {
+	Transaction ServerTransaction `json:"transaction"`
+}
+
+// ServerTransaction is the order of a server, server number and IP are set
+// once the server is ready
+type ServerTransaction struct {
+	ID            string                   `json:"id"`
+	Date          string                   `json:"date"`
+	Status        string                   `json:"status"`
+	ServerNumber  int                      `json:"server_number"`
+	ServerIP      string                   `json:"server_ip"`
+	AuthorizedKey []models.KeyResponse     `json:"authorized_key"`
+	Comment       string                   `json:"comment"`
+	Product       ServerTransactionProduct `json:"product"`
+	Addons        []string                 `json:"addons"`
+}
+
+type ServerTransactionProduct struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description []string `json:"description"`
+	Traffic     string   `json:"traffic"`
+	Dist        string   `json:"dist"`
+	Arch        int      `json:"arch"`
+	Lang        string   `json:"lang"`
+	Location    string   `json:"location"`
+}
+
+// ServerOrderInput orders a server, orders with Test set are validated but
+// not executed
+type ServerOrderInput struct {
+	ProductID string
+	Keys      []string
+	Location  string
+	Dist      string
+	Arch      int
+	Lang      string
+	Comment   string
+	Test      bool
+}
+
+func (c *Client) OrderServerProductGetList() ([]ServerProduct, error) {
+	url := c.baseURL + "/order/server/product"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var productsResp []ServerProductResponse
+	err = json.Unmarshal(bytes, &productsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []ServerProduct
+	for _, product := range productsResp {
+		data = append(data, product.Product)
+	}
+
+	return data, nil
+}
+
+func (c *Client) OrderServerTransactionGetList() ([]ServerTransaction, error) {
+	url := c.baseURL + "/order/server/transaction"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionsResp []ServerTransactionResponse
+	err = json.Unmarshal(bytes, &transactionsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []ServerTransaction
+	for _, transaction := range transactionsResp {
+		data = append(data, transaction.Transaction)
+	}
+
+	return data, nil
+}
+
+func (c *Client) OrderServerTransactionGet(id string) (*ServerTransaction, error) {
+	url := fmt.Sprintf(c.baseURL+"/order/server/transaction/%s", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseServerTransaction(bytes)
+}
+
+func (c *Client) OrderServerTransactionCreate(input *ServerOrderInput) (*ServerTransaction, error) {
+	url := c.baseURL + "/order/server/transaction"
+
+	formData := neturl.Values{}
+	formData.Set("product_id", input.ProductID)
+	for _, key := range input.Keys {
+		formData.Add("authorized_key[]", key)
+	}
+	if input.Location != "" {
+		formData.Set("location", input.Location)
+	}
+	if input.Dist != "" {
+		formData.Set("dist", input.Dist)
+	}
+	if input.Arch > 0 {
+		formData.Set("arch", strconv.Itoa(input.Arch))
+	}
+	if input.Lang != "" {
+		formData.Set("lang", input.Lang)
+	}
+	if input.Comment != "" {
+		formData.Set("comment", input.Comment)
+	}
+	if input.Test {
+		formData.Set("test", "true")
+	}
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseServerTransaction(bytes)
+}
+
+func parseServerTransaction(bytes []byte) (*ServerTransaction, error) {
+	var transactionResp ServerTransactionResponse
+	err := json.Unmarshal(bytes, &transactionResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResp.Transaction, nil
+}
@@ -0,0 +1,65 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestOrderServerProductGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "order_server_product_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/order/server/product")
+	})
+	defer closeFn()
+
+	products, err := robotClient.OrderServerProductGetList()
+	c.Assert(err, IsNil)
+	c.Assert(len(products), Equals, 1)
+	c.Assert(products[0].ID, Equals, "EX44")
+	c.Assert(products[0].Location, DeepEquals, []string{"FSN1", "HEL1"})
+	c.Assert(products[0].Prices[0].Price.Net, Equals, "44.0000")
+	c.Assert(products[0].Prices[0].PriceSetup.Net, Equals, "39.0000")
+}
+
+func (s *RobotSuite) TestOrderServerTransactionGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "order_server_transaction_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/order/server/transaction/B20150121-344958-251479")
+	})
+	defer closeFn()
+
+	transaction, err := robotClient.OrderServerTransactionGet("B20150121-344958-251479")
+	c.Assert(err, IsNil)
+	c.Assert(transaction.Status, Equals, robot.TransactionStatusInProcess)
+	c.Assert(transaction.ServerNumber, Equals, 0)
+	c.Assert(transaction.Product.Location, Equals, "FSN1")
+	c.Assert(transaction.AuthorizedKey[0].Key.Name, Equals, "key1")
+}
+
+func (s *RobotSuite) TestOrderServerTransactionCreateTest(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "order_server_transaction_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/order/server/transaction")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("product_id"), Equals, "EX44")
+		c.Assert(r.PostForm["authorized_key[]"], DeepEquals, []string{"15:28:b0:03:95:f0:77:b3:10:56:15:6b:77:22:a5:bb"})
+		c.Assert(r.PostForm.Get("location"), Equals, "FSN1")
+		c.Assert(r.PostForm.Get("test"), Equals, "true")
+		c.Assert(r.PostForm.Get("arch"), Equals, "")
+	})
+	defer closeFn()
+
+	input := &robot.ServerOrderInput{
+		ProductID: "EX44",
+		Keys:      []string{"15:28:b0:03:95:f0:77:b3:10:56:15:6b:77:22:a5:bb"},
+		Location:  "FSN1",
+		Test:      true,
+	}
+
+	transaction, err := robotClient.OrderServerTransactionCreate(input)
+	c.Assert(err, IsNil)
+	c.Assert(transaction.ID, Equals, "B20150121-344958-251479")
+}
@@ -0,0 +1,46 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *RobotSuite) TestRDnsGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "rdns_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/rdns")
+	})
+	defer closeFn()
+
+	rdnsList, err := robotClient.RDnsGetList()
+	c.Assert(err, IsNil)
+	c.Assert(rdnsList, HasLen, 2)
+	c.Assert(rdnsList[0].Ptr, Equals, "testen.de")
+}
+
+func (s *RobotSuite) TestRDnsGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "rdns_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/rdns/2a01:4f8:111:4221::2")
+	})
+	defer closeFn()
+
+	rdns, err := robotClient.RDnsGet("2a01:4f8:111:4221::2")
+	c.Assert(err, IsNil)
+	c.Assert(rdns.Ptr, Equals, "app-prod-42-vm1.example.com")
+}
+
+func (s *RobotSuite) TestRDnsSetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "rdns_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/rdns/2a01:4f8:111:4221::2")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("ptr"), Equals, "app-prod-42-vm1.example.com")
+	})
+	defer closeFn()
+
+	rdns, err := robotClient.RDnsSet("2a01:4f8:111:4221::2", "app-prod-42-vm1.example.com")
+	c.Assert(err, IsNil)
+	c.Assert(rdns.Ptr, Equals, "app-prod-42-vm1.example.com")
+}
@@ -0,0 +1,35 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *RobotSuite) TestResetGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "reset_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/reset/123.123.123.123")
+	})
+	defer closeFn()
+
+	reset, err := robotClient.ResetGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(reset.Type, DeepEquals, []string{"sw", "hw", "man"})
+}
+
+func (s *RobotSuite) TestResetSetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "reset_post.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/reset/123.123.123.123")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("type"), Equals, models.ResetTypeHardware)
+	})
+	defer closeFn()
+
+	reset, err := robotClient.ResetSet("123.123.123.123", &models.ResetSetInput{Type: models.ResetTypeHardware})
+	c.Assert(err, IsNil)
+	c.Assert(reset.Type, Equals, models.ResetTypeHardware)
+}
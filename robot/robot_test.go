@@ -0,0 +1,67 @@
+package robot_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type RobotSuite struct{}
+
+var _ = Suite(&RobotSuite{})
+
+// newTestClient returns a client talking to a test server answering with the
+// given status and response fixture, requests are passed to the handler first
+func newTestClient(c *C, status int, fixture string, handler func(r *http.Request)) (robot.RobotClient, func()) {
+	var body []byte
+	if fixture != "" {
+		var err error
+		body, err = ioutil.ReadFile("../test/response/" + fixture)
+		c.Assert(err, IsNil)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler != nil {
+			handler(r)
+		}
+
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+
+	robotClient := robot.NewBasicAuthClient("user", "pass")
+	robotClient.SetBaseURL(ts.URL)
+
+	return robotClient, ts.Close
+}
+
+// readForm parses the form body of requests of any method, the http package
+// ignores the body of DELETE requests
+func readForm(c *C, r *http.Request) url.Values {
+	body, err := ioutil.ReadAll(r.Body)
+	c.Assert(err, IsNil)
+
+	values, err := url.ParseQuery(string(body))
+	c.Assert(err, IsNil)
+
+	return values
+}
+
+func (s *RobotSuite) TestListError(c *C) {
+	notFound := errors.New(`{"error":{"status":404,"code":"NOT_FOUND","message":"Not found"}}`)
+	c.Assert(robot.ListError(notFound), IsNil)
+	c.Assert(robot.ListError(nil), IsNil)
+
+	unauthorized := errors.New(`{"error":{"status":401,"code":"UNAUTHORIZED","message":"Unauthorized"}}`)
+	c.Assert(robot.ListError(unauthorized), Equals, unauthorized)
+}
@@ -0,0 +1,68 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-go/models"
+)
+
+func (s *RobotSuite) TestServerGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "server_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/server")
+	})
+	defer closeFn()
+
+	servers, err := robotClient.ServerGetList()
+	c.Assert(err, IsNil)
+	c.Assert(len(servers) > 0, Equals, true)
+	c.Assert(servers[0].ServerName, Equals, "server1")
+}
+
+func (s *RobotSuite) TestServerGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "server_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/server/123.123.123.123")
+	})
+	defer closeFn()
+
+	server, err := robotClient.ServerGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(server.ServerNumber, Equals, 321)
+}
+
+func (s *RobotSuite) TestServerGetNotFound(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusNotFound, "", nil)
+	defer closeFn()
+
+	_, err := robotClient.ServerGet("123.123.123.123")
+	c.Assert(err, NotNil)
+}
+
+func (s *RobotSuite) TestServerSetNameSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "server_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/server/123.123.123.123")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("server_name"), Equals, "server1")
+	})
+	defer closeFn()
+
+	server, err := robotClient.ServerSetName("123.123.123.123", &models.ServerSetNameInput{Name: "server1"})
+	c.Assert(err, IsNil)
+	c.Assert(server.ServerName, Equals, "server1")
+}
+
+func (s *RobotSuite) TestServerReverseSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "server_reverse.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/server/123.123.123.123/reversal")
+	})
+	defer closeFn()
+
+	cancellation, err := robotClient.ServerReverse("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(cancellation.Cancelled, Equals, true)
+}
@@ -0,0 +1,357 @@
+package robot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+const (
+	SnapshotPlanStatusEnabled  = "enabled"
+	SnapshotPlanStatusDisabled = "disabled"
+)
+
+type StorageBoxResponse struct {
+	StorageBox StorageBox `json:"storagebox"`
+}
+
+// StorageBox is a backup space, disk usage, access settings and the host are
+// only returned for a single Storage Box. Disk quota and usage are given in MB.
+type StorageBox struct {
+	ID                   int    `json:"id"`
+	Login                string `json:"login"`
+	Name                 string `json:"name"`
+	Product              string `json:"product"`
+	Cancelled            bool   `json:"cancelled"`
+	Locked               bool   `json:"locked"`
+	Location             string `json:"location"`
+	LinkedServer         int    `json:"linked_server"`
+	PaidUntil            string `json:"paid_until"`
+	DiskQuota            int    `json:"disk_quota"`
+	DiskUsage            int    `json:"disk_usage"`
+	DiskUsageData        int    `json:"disk_usage_data"`
+	DiskUsageSnapshots   int    `json:"disk_usage_snapshots"`
+	WebDAV               bool   `json:"webdav"`
+	Samba                bool   `json:"samba"`
+	SSH                  bool   `json:"ssh"`
+	ExternalReachability bool   `json:"external_reachability"`
+	ZFS                  bool   `json:"zfs"`
+	Server               string `json:"server"`
+	HostSystem           string `json:"host_system"`
+}
+
+// StorageBoxInput replaces name and access settings of a Storage Box
+type StorageBoxInput struct {
+	Name                 string
+	WebDAV               bool
+	Samba                bool
+	SSH                  bool
+	ExternalReachability bool
+	ZFS                  bool
+}
+
+type StorageBoxSnapshotResponse struct {
+	Snapshot StorageBoxSnapshot `json:"snapshot"`
+}
+
+// StorageBoxSnapshot is a snapshot of a Storage Box, sizes are given in MB
+type StorageBoxSnapshot struct {
+	Name           string `json:"name"`
+	Timestamp      string `json:"timestamp"`
+	Size           int    `json:"size"`
+	FilesystemSize int    `json:"filesystem_size"`
+	Automatic      bool   `json:"automatic"`
+	Comment        string `json:"comment"`
+}
+
+type StorageBoxSnapshotPlanResponse struct {
+	SnapshotPlan StorageBoxSnapshotPlan `json:"snapshotplan"`
+}
+
+// StorageBoxSnapshotPlan creates snapshots automatically, times are given in
+// UTC. Without day of week and day of month a snapshot is created every day.
+type StorageBoxSnapshotPlan struct {
+	Status       string `json:"status"`
+	Minute       int    `json:"minute"`
+	Hour         int    `json:"hour"`
+	DayOfWeek    *int   `json:"day_of_week"`
+	DayOfMonth   *int   `json:"day_of_month"`
+	MaxSnapshots int    `json:"max_snapshots"`
+}
+
+type StorageBoxSubaccountResponse struct {
+	Subaccount StorageBoxSubaccount `json:"subaccount"`
+}
+
+// StorageBoxSubaccount is an additional login of a Storage Box restricted to
+// a home directory, the password is only returned on creation
+type StorageBoxSubaccount struct {
+	Username             string `json:"username"`
+	Password             string `json:"password,omitempty"`
+	AccountID            string `json:"accountid"`
+	Server               string `json:"server"`
+	HomeDirectory        string `json:"homedirectory"`
+	Samba                bool   `json:"samba"`
+	SSH                  bool   `json:"ssh"`
+	ExternalReachability bool   `json:"external_reachability"`
+	WebDAV               bool   `json:"webdav"`
+	ReadOnly             bool   `json:"readonly"`
+	CreateTime           string `json:"createtime"`
+	Comment              string `json:"comment"`
+}
+
+// StorageBoxSubaccountInput replaces the settings of a subaccount
+type StorageBoxSubaccountInput struct {
+	HomeDirectory        string
+	Samba                bool
+	SSH                  bool
+	ExternalReachability bool
+	WebDAV               bool
+	ReadOnly             bool
+	Comment              string
+}
+
+func (c *Client) StorageBoxGetList() ([]StorageBox, error) {
+	url := c.baseURL + "/storagebox"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var storageBoxesResp []StorageBoxResponse
+	err = json.Unmarshal(bytes, &storageBoxesResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []StorageBox
+	for _, storageBox := range storageBoxesResp {
+		data = append(data, storageBox.StorageBox)
+	}
+
+	return data, nil
+}
+
+func (c *Client) StorageBoxGet(id int) (*StorageBox, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStorageBox(bytes)
+}
+
+func (c *Client) StorageBoxUpdate(id int, input *StorageBoxInput) (*StorageBox, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d", id)
+
+	formData := neturl.Values{}
+	formData.Set("storagebox_name", input.Name)
+	formData.Set("webdav", strconv.FormatBool(input.WebDAV))
+	formData.Set("samba", strconv.FormatBool(input.Samba))
+	formData.Set("ssh", strconv.FormatBool(input.SSH))
+	formData.Set("external_reachability", strconv.FormatBool(input.ExternalReachability))
+	formData.Set("zfs", strconv.FormatBool(input.ZFS))
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStorageBox(bytes)
+}
+
+func (c *Client) StorageBoxSnapshotGetList(id int) ([]StorageBoxSnapshot, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/snapshot", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshotsResp []StorageBoxSnapshotResponse
+	err = json.Unmarshal(bytes, &snapshotsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []StorageBoxSnapshot
+	for _, snapshot := range snapshotsResp {
+		data = append(data, snapshot.Snapshot)
+	}
+
+	return data, nil
+}
+
+func (c *Client) StorageBoxSnapshotCreate(id int) (*StorageBoxSnapshot, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/snapshot", id)
+
+	bytes, err := c.doPostFormRequest(url, neturl.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshotResp StorageBoxSnapshotResponse
+	err = json.Unmarshal(bytes, &snapshotResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotResp.Snapshot, nil
+}
+
+func (c *Client) StorageBoxSnapshotDelete(id int, name string) error {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/snapshot/%s", id, name)
+
+	_, err := c.doDeleteRequest(url)
+	return err
+}
+
+// StorageBoxSnapshotRevert resets the Storage Box to the snapshot, all newer
+// changes are lost
+func (c *Client) StorageBoxSnapshotRevert(id int, name string) error {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/snapshot/%s", id, name)
+
+	formData := neturl.Values{}
+	formData.Set("revert", "true")
+
+	_, err := c.doPostFormRequest(url, formData)
+	return err
+}
+
+func (c *Client) StorageBoxSnapshotPlanGet(id int) (*StorageBoxSnapshotPlan, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/snapshotplan", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStorageBoxSnapshotPlan(bytes)
+}
+
+func (c *Client) StorageBoxSnapshotPlanSet(id int, plan *StorageBoxSnapshotPlan) (*StorageBoxSnapshotPlan, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/snapshotplan", id)
+
+	formData := neturl.Values{}
+	formData.Set("status", plan.Status)
+	if plan.Status == SnapshotPlanStatusEnabled {
+		formData.Set("minute", strconv.Itoa(plan.Minute))
+		formData.Set("hour", strconv.Itoa(plan.Hour))
+		formData.Set("max_snapshots", strconv.Itoa(plan.MaxSnapshots))
+		if plan.DayOfWeek != nil {
+			formData.Set("day_of_week", strconv.Itoa(*plan.DayOfWeek))
+		}
+		if plan.DayOfMonth != nil {
+			formData.Set("day_of_month", strconv.Itoa(*plan.DayOfMonth))
+		}
+	}
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStorageBoxSnapshotPlan(bytes)
+}
+
+func (c *Client) StorageBoxSubaccountGetList(id int) ([]StorageBoxSubaccount, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/subaccount", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var subaccountsResp []StorageBoxSubaccountResponse
+	err = json.Unmarshal(bytes, &subaccountsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []StorageBoxSubaccount
+	for _, subaccount := range subaccountsResp {
+		data = append(data, subaccount.Subaccount)
+	}
+
+	return data, nil
+}
+
+func (c *Client) StorageBoxSubaccountCreate(id int, input *StorageBoxSubaccountInput) (*StorageBoxSubaccount, error) {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/subaccount", id)
+
+	bytes, err := c.doPostFormRequest(url, subaccountFormData(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var subaccountResp StorageBoxSubaccountResponse
+	err = json.Unmarshal(bytes, &subaccountResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subaccountResp.Subaccount, nil
+}
+
+func (c *Client) StorageBoxSubaccountUpdate(id int, username string, input *StorageBoxSubaccountInput) error {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/subaccount/%s", id, username)
+
+	_, err := c.doPutFormRequest(url, subaccountFormData(input))
+	return err
+}
+
+func (c *Client) StorageBoxSubaccountDelete(id int, username string) error {
+	url := fmt.Sprintf(c.baseURL+"/storagebox/%d/subaccount/%s", id, username)
+
+	_, err := c.doDeleteRequest(url)
+	return err
+}
+
+func parseStorageBox(bytes []byte) (*StorageBox, error) {
+	var storageBoxResp StorageBoxResponse
+	err := json.Unmarshal(bytes, &storageBoxResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storageBoxResp.StorageBox, nil
+}
+
+// parseStorageBoxSnapshotPlan accepts the plan as single object and wrapped
+// in a list, the webservice documentation shows both variants
+func parseStorageBoxSnapshotPlan(bytes []byte) (*StorageBoxSnapshotPlan, error) {
+	var plansResp []StorageBoxSnapshotPlanResponse
+	if err := json.Unmarshal(bytes, &plansResp); err == nil {
+		if len(plansResp) == 0 {
+			return nil, errors.New("empty snapshot plan response")
+		}
+
+		return &plansResp[0].SnapshotPlan, nil
+	}
+
+	var planResp StorageBoxSnapshotPlanResponse
+	err := json.Unmarshal(bytes, &planResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &planResp.SnapshotPlan, nil
+}
+
+func subaccountFormData(input *StorageBoxSubaccountInput) neturl.Values {
+	formData := neturl.Values{}
+	formData.Set("homedirectory", input.HomeDirectory)
+	formData.Set("samba", strconv.FormatBool(input.Samba))
+	formData.Set("ssh", strconv.FormatBool(input.SSH))
+	formData.Set("external_reachability", strconv.FormatBool(input.ExternalReachability))
+	formData.Set("webdav", strconv.FormatBool(input.WebDAV))
+	formData.Set("readonly", strconv.FormatBool(input.ReadOnly))
+	formData.Set("comment", input.Comment)
+
+	return formData
+}
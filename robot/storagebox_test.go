@@ -0,0 +1,134 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestStorageBoxGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "storagebox_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/storagebox")
+	})
+	defer closeFn()
+
+	storageBoxes, err := robotClient.StorageBoxGetList()
+	c.Assert(err, IsNil)
+	c.Assert(storageBoxes, HasLen, 2)
+	c.Assert(storageBoxes[0].Login, Equals, "u12345")
+	c.Assert(storageBoxes[1].LinkedServer, Equals, 0)
+}
+
+func (s *RobotSuite) TestStorageBoxGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "storagebox_get.json", func(r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456")
+	})
+	defer closeFn()
+
+	storageBox, err := robotClient.StorageBoxGet(123456)
+	c.Assert(err, IsNil)
+	c.Assert(storageBox.DiskQuota, Equals, 10240000)
+	c.Assert(storageBox.SSH, Equals, true)
+	c.Assert(storageBox.ZFS, Equals, false)
+}
+
+func (s *RobotSuite) TestStorageBoxUpdateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "storagebox_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("storagebox_name"), Equals, "Backup Server 1")
+		c.Assert(r.PostForm.Get("samba"), Equals, "false")
+		c.Assert(r.PostForm.Get("zfs"), Equals, "true")
+	})
+	defer closeFn()
+
+	_, err := robotClient.StorageBoxUpdate(123456, &robot.StorageBoxInput{Name: "Backup Server 1", ZFS: true})
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestStorageBoxSnapshotGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "storagebox_snapshot_list.json", func(r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456/snapshot")
+	})
+	defer closeFn()
+
+	snapshots, err := robotClient.StorageBoxSnapshotGetList(123456)
+	c.Assert(err, IsNil)
+	c.Assert(snapshots, HasLen, 1)
+	c.Assert(snapshots[0].Name, Equals, "2015-12-21T12-40-38")
+	c.Assert(snapshots[0].Size, Equals, 400)
+}
+
+func (s *RobotSuite) TestStorageBoxSnapshotRevertSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456/snapshot/2015-12-21T12-40-38")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("revert"), Equals, "true")
+	})
+	defer closeFn()
+
+	err := robotClient.StorageBoxSnapshotRevert(123456, "2015-12-21T12-40-38")
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestStorageBoxSnapshotPlanGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "storagebox_snapshotplan_get.json", func(r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456/snapshotplan")
+	})
+	defer closeFn()
+
+	plan, err := robotClient.StorageBoxSnapshotPlanGet(123456)
+	c.Assert(err, IsNil)
+	c.Assert(plan.Status, Equals, robot.SnapshotPlanStatusEnabled)
+	c.Assert(plan.Hour, Equals, 12)
+	c.Assert(plan.DayOfWeek, IsNil)
+	c.Assert(*plan.DayOfMonth, Equals, 3)
+}
+
+func (s *RobotSuite) TestStorageBoxSnapshotPlanSetDisabled(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "storagebox_snapshotplan_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("status"), Equals, "disabled")
+		_, ok := r.PostForm["hour"]
+		c.Assert(ok, Equals, false)
+	})
+	defer closeFn()
+
+	_, err := robotClient.StorageBoxSnapshotPlanSet(123456, &robot.StorageBoxSnapshotPlan{Status: robot.SnapshotPlanStatusDisabled, Hour: 12})
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestStorageBoxSubaccountCreateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "storagebox_subaccount_create.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456/subaccount")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("homedirectory"), Equals, "test")
+		c.Assert(r.PostForm.Get("readonly"), Equals, "true")
+	})
+	defer closeFn()
+
+	subaccount, err := robotClient.StorageBoxSubaccountCreate(123456, &robot.StorageBoxSubaccountInput{HomeDirectory: "test", ReadOnly: true})
+	c.Assert(err, IsNil)
+	c.Assert(subaccount.Username, Equals, "u2342-sub1")
+	c.Assert(subaccount.Password, Equals, "E6jqdPyn4AU2Uw6a")
+}
+
+func (s *RobotSuite) TestStorageBoxSubaccountUpdateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "PUT")
+		c.Assert(r.URL.Path, Equals, "/storagebox/123456/subaccount/u2342-sub1")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("ssh"), Equals, "true")
+	})
+	defer closeFn()
+
+	err := robotClient.StorageBoxSubaccountUpdate(123456, "u2342-sub1", &robot.StorageBoxSubaccountInput{HomeDirectory: "test", SSH: true})
+	c.Assert(err, IsNil)
+}
@@ -0,0 +1,63 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type SubnetResponse struct {
+	Subnet Subnet `json:"subnet"`
+}
+
+type Subnet struct {
+	IP              string `json:"ip"`
+	Mask            int    `json:"mask"`
+	Gateway         string `json:"gateway"`
+	ServerIP        string `json:"server_ip"`
+	ServerNumber    int    `json:"server_number"`
+	Failover        bool   `json:"failover"`
+	Locked          bool   `json:"locked"`
+	TrafficWarnings bool   `json:"traffic_warnings"`
+	TrafficHourly   int    `json:"traffic_hourly"`
+	TrafficDaily    int    `json:"traffic_daily"`
+	TrafficMonthly  int    `json:"traffic_monthly"`
+}
+
+func (c *Client) SubnetGetList() ([]Subnet, error) {
+	url := c.baseURL + "/subnet"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnetsResp []SubnetResponse
+	err = json.Unmarshal(bytes, &subnetsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Subnet
+	for _, subnet := range subnetsResp {
+		data = append(data, subnet.Subnet)
+	}
+
+	return data, nil
+}
+
+func (c *Client) SubnetGet(ip string) (*Subnet, error) {
+	url := fmt.Sprintf(c.baseURL+"/subnet/%s", ip)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnetResp SubnetResponse
+	err = json.Unmarshal(bytes, &subnetResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subnetResp.Subnet, nil
+}
@@ -0,0 +1,22 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *RobotSuite) TestSubnetGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "subnet_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/subnet")
+	})
+	defer closeFn()
+
+	subnets, err := robotClient.SubnetGetList()
+	c.Assert(err, IsNil)
+	c.Assert(subnets, HasLen, 2)
+	c.Assert(subnets[0].IP, Equals, "123.123.123.96")
+	c.Assert(subnets[0].Mask, Equals, 29)
+	c.Assert(subnets[1].Gateway, Equals, "fe80::1")
+}
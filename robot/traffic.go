@@ -0,0 +1,69 @@
+package robot
+
+import (
+	"encoding/json"
+	neturl "net/url"
+)
+
+const (
+	TrafficTypeDay   = "day"
+	TrafficTypeMonth = "month"
+	TrafficTypeYear  = "year"
+)
+
+type TrafficResponse struct {
+	Traffic Traffic `json:"traffic"`
+}
+
+// Traffic holds the traffic in GB per IP or subnet
+type Traffic struct {
+	Type string                 `json:"type"`
+	From string                 `json:"from"`
+	To   string                 `json:"to"`
+	Data map[string]TrafficData `json:"data"`
+}
+
+type TrafficData struct {
+	In  float64 `json:"in"`
+	Out float64 `json:"out"`
+	Sum float64 `json:"sum"`
+}
+
+// TrafficGetInput selects the IP's and subnets and the time range, the format
+// of From and To depends on the type: 2006-01-02T15 for day, 2006-01-02 for
+// month and 2006-01 for year
+type TrafficGetInput struct {
+	Type    string
+	From    string
+	To      string
+	IPs     []string
+	Subnets []string
+}
+
+func (c *Client) TrafficGet(input *TrafficGetInput) (*Traffic, error) {
+	url := c.baseURL + "/traffic"
+
+	formData := neturl.Values{}
+	formData.Set("type", input.Type)
+	formData.Set("from", input.From)
+	formData.Set("to", input.To)
+	for _, ip := range input.IPs {
+		formData.Add("ip[]", ip)
+	}
+	for _, subnet := range input.Subnets {
+		formData.Add("subnet[]", subnet)
+	}
+
+	bytes, err := c.doPostFormRequest(url, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	var trafficResp TrafficResponse
+	err = json.Unmarshal(bytes, &trafficResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trafficResp.Traffic, nil
+}
@@ -0,0 +1,42 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestTrafficGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "traffic_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/traffic")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("type"), Equals, robot.TrafficTypeMonth)
+		c.Assert(r.PostForm["ip[]"], DeepEquals, []string{"123.123.123.123", "124.124.124.124"})
+		c.Assert(r.PostForm["subnet[]"], DeepEquals, []string{"2a01:4f8:111:4221::"})
+	})
+	defer closeFn()
+
+	traffic, err := robotClient.TrafficGet(&robot.TrafficGetInput{
+		Type:    robot.TrafficTypeMonth,
+		From:    "2020-01-01",
+		To:      "2020-01-31",
+		IPs:     []string{"123.123.123.123", "124.124.124.124"},
+		Subnets: []string{"2a01:4f8:111:4221::"},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(traffic.Type, Equals, robot.TrafficTypeMonth)
+	c.Assert(traffic.Data, HasLen, 2)
+	c.Assert(traffic.Data["123.123.123.123"].Out, Equals, 2.5)
+}
+
+func (s *RobotSuite) TestTrafficGetError(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusBadRequest, "error_invalid_input.json", nil)
+	defer closeFn()
+
+	_, err := robotClient.TrafficGet(&robot.TrafficGetInput{Type: robot.TrafficTypeMonth})
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, "(?s).*INVALID_INPUT.*")
+}
@@ -0,0 +1,50 @@
+package robot
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// StatusError is returned for server errors without webservice error body,
+// e.g. the HTML pages of proxies and load balancers in front of the webservice
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return "webservice returned " + e.Status
+}
+
+// Transport turns server errors without webservice error body into a
+// StatusError. The client only returns the body of failed requests, the status
+// of such errors would be lost otherwise.
+type Transport struct {
+	base http.RoundTripper
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 500 {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if json.Valid(body) {
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+}
@@ -0,0 +1,67 @@
+package robot_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+// newTransportServer returns a test server answering with the given status and
+// body and a client using the transport
+func newTransportServer(status int, body string) (*httptest.Server, *http.Client) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+
+	return ts, &http.Client{Transport: robot.NewTransport(http.DefaultTransport)}
+}
+
+func (s *RobotSuite) TestTransportStatusError(c *C) {
+	ts, client := newTransportServer(http.StatusBadGateway, "<html><body>502 Bad Gateway</body></html>")
+	defer ts.Close()
+
+	_, err := client.Get(ts.URL + "/server")
+
+	var statusErr *robot.StatusError
+	c.Assert(errors.As(err, &statusErr), Equals, true)
+	c.Assert(statusErr.StatusCode, Equals, http.StatusBadGateway)
+	c.Assert(statusErr, ErrorMatches, "webservice returned 502 Bad Gateway")
+}
+
+func (s *RobotSuite) TestTransportWebserviceError(c *C) {
+	body := `{"error":{"status":500,"code":"INTERNAL_ERROR","message":"Internal error"}}`
+
+	ts, client := newTransportServer(http.StatusInternalServerError, body)
+	defer ts.Close()
+
+	resp, err := client.Get(ts.URL + "/server")
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+
+	c.Assert(resp.StatusCode, Equals, http.StatusInternalServerError)
+}
+
+func (s *RobotSuite) TestClientTimeout(c *C) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	robotClient := robot.NewClient("user", "pass", robot.NewHTTPClient(10*time.Millisecond))
+	robotClient.SetBaseURL(ts.URL)
+
+	_, err := robotClient.KeyGetList()
+
+	var netErr net.Error
+	c.Assert(errors.As(err, &netErr), Equals, true)
+	c.Assert(netErr.Timeout(), Equals, true)
+}
@@ -0,0 +1,147 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+// VSwitch is a virtual switch connecting dedicated servers, the attached
+// servers, subnets and cloud networks are only returned for a single vSwitch
+type VSwitch struct {
+	ID            int                   `json:"id"`
+	Name          string                `json:"name"`
+	Vlan          int                   `json:"vlan"`
+	Cancelled     bool                  `json:"cancelled"`
+	Servers       []VSwitchServer       `json:"server"`
+	Subnets       []VSwitchSubnet       `json:"subnet"`
+	CloudNetworks []VSwitchCloudNetwork `json:"cloud_network"`
+}
+
+type VSwitchServer struct {
+	ServerIP      string `json:"server_ip"`
+	ServerIPv6Net string `json:"server_ipv6_net"`
+	ServerNumber  int    `json:"server_number"`
+	Status        string `json:"status"`
+}
+
+type VSwitchSubnet struct {
+	IP      string `json:"ip"`
+	Mask    int    `json:"mask"`
+	Gateway string `json:"gateway"`
+}
+
+type VSwitchCloudNetwork struct {
+	ID      int    `json:"id"`
+	IP      string `json:"ip"`
+	Mask    int    `json:"mask"`
+	Gateway string `json:"gateway"`
+}
+
+type VSwitchInput struct {
+	Name string
+	Vlan int
+}
+
+func (c *Client) VSwitchGetList() ([]VSwitch, error) {
+	url := c.baseURL + "/vswitch"
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var vSwitches []VSwitch
+	err = json.Unmarshal(bytes, &vSwitches)
+	if err != nil {
+		return nil, err
+	}
+
+	return vSwitches, nil
+}
+
+func (c *Client) VSwitchGet(id int) (*VSwitch, error) {
+	url := fmt.Sprintf(c.baseURL+"/vswitch/%d", id)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var vSwitch VSwitch
+	err = json.Unmarshal(bytes, &vSwitch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vSwitch, nil
+}
+
+func (c *Client) VSwitchCreate(input *VSwitchInput) (*VSwitch, error) {
+	url := c.baseURL + "/vswitch"
+
+	bytes, err := c.doPostFormRequest(url, vSwitchFormData(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var vSwitch VSwitch
+	err = json.Unmarshal(bytes, &vSwitch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vSwitch, nil
+}
+
+func (c *Client) VSwitchUpdate(id int, input *VSwitchInput) error {
+	url := fmt.Sprintf(c.baseURL+"/vswitch/%d", id)
+
+	_, err := c.doPostFormRequest(url, vSwitchFormData(input))
+	return err
+}
+
+// VSwitchCancel cancels the vSwitch at the given date (2006-01-02) or "now"
+func (c *Client) VSwitchCancel(id int, cancellationDate string) error {
+	url := fmt.Sprintf(c.baseURL+"/vswitch/%d", id)
+
+	formData := neturl.Values{}
+	formData.Set("cancellation_date", cancellationDate)
+
+	_, err := c.doFormRequest("DELETE", url, formData)
+	return err
+}
+
+// VSwitchAddServers attaches the servers given by IP or number, the webservice
+// processes the change in the background
+func (c *Client) VSwitchAddServers(id int, servers []string) error {
+	url := fmt.Sprintf(c.baseURL+"/vswitch/%d/server", id)
+
+	_, err := c.doPostFormRequest(url, vSwitchServerFormData(servers))
+	return err
+}
+
+func (c *Client) VSwitchRemoveServers(id int, servers []string) error {
+	url := fmt.Sprintf(c.baseURL+"/vswitch/%d/server", id)
+
+	_, err := c.doFormRequest("DELETE", url, vSwitchServerFormData(servers))
+	return err
+}
+
+func vSwitchFormData(input *VSwitchInput) neturl.Values {
+	formData := neturl.Values{}
+	formData.Set("name", input.Name)
+	formData.Set("vlan", strconv.Itoa(input.Vlan))
+
+	return formData
+}
+
+func vSwitchServerFormData(servers []string) neturl.Values {
+	formData := neturl.Values{}
+	for _, server := range servers {
+		formData.Add("server[]", server)
+	}
+
+	return formData
+}
@@ -0,0 +1,77 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestVSwitchGetListSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "vswitch_list.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/vswitch")
+	})
+	defer closeFn()
+
+	vSwitches, err := robotClient.VSwitchGetList()
+	c.Assert(err, IsNil)
+	c.Assert(vSwitches, HasLen, 2)
+	c.Assert(vSwitches[1].Vlan, Equals, 4001)
+	c.Assert(vSwitches[1].Cancelled, Equals, true)
+}
+
+func (s *RobotSuite) TestVSwitchGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "vswitch_get.json", func(r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/vswitch/4321")
+	})
+	defer closeFn()
+
+	vSwitch, err := robotClient.VSwitchGet(4321)
+	c.Assert(err, IsNil)
+	c.Assert(vSwitch.Name, Equals, "vswitch 1")
+	c.Assert(vSwitch.Servers, HasLen, 1)
+	c.Assert(vSwitch.Servers[0].ServerNumber, Equals, 321)
+	c.Assert(vSwitch.Subnets[0].Mask, Equals, 29)
+	c.Assert(vSwitch.CloudNetworks[0].ID, Equals, 123)
+}
+
+func (s *RobotSuite) TestVSwitchCreateSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusCreated, "vswitch_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/vswitch")
+		c.Assert(r.ParseForm(), IsNil)
+		c.Assert(r.PostForm.Get("name"), Equals, "vswitch 1")
+		c.Assert(r.PostForm.Get("vlan"), Equals, "4000")
+	})
+	defer closeFn()
+
+	vSwitch, err := robotClient.VSwitchCreate(&robot.VSwitchInput{Name: "vswitch 1", Vlan: 4000})
+	c.Assert(err, IsNil)
+	c.Assert(vSwitch.ID, Equals, 4321)
+}
+
+func (s *RobotSuite) TestVSwitchRemoveServersSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "DELETE")
+		c.Assert(r.URL.Path, Equals, "/vswitch/4321/server")
+		c.Assert(readForm(c, r)["server[]"], DeepEquals, []string{"123.123.123.123", "124.124.124.124"})
+	})
+	defer closeFn()
+
+	err := robotClient.VSwitchRemoveServers(4321, []string{"123.123.123.123", "124.124.124.124"})
+	c.Assert(err, IsNil)
+}
+
+func (s *RobotSuite) TestVSwitchCancelSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "DELETE")
+		c.Assert(r.URL.Path, Equals, "/vswitch/4321")
+		c.Assert(readForm(c, r).Get("cancellation_date"), Equals, "now")
+	})
+	defer closeFn()
+
+	err := robotClient.VSwitchCancel(4321, "now")
+	c.Assert(err, IsNil)
+}
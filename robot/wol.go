@@ -0,0 +1,55 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+)
+
+// CodeWolNotAvailable is returned for servers without Wake on LAN support
+const CodeWolNotAvailable = "WOL_NOT_AVAILABLE"
+
+type WolResponse struct {
+	Wol Wol `json:"wol"`
+}
+
+type Wol struct {
+	ServerIP      string `json:"server_ip"`
+	ServerIPv6Net string `json:"server_ipv6_net"`
+	ServerNumber  int    `json:"server_number"`
+}
+
+// WolGet returns the Wake on LAN data of the server, servers without Wake on
+// LAN support return an error with code WOL_NOT_AVAILABLE
+func (c *Client) WolGet(serverIP string) (*Wol, error) {
+	url := fmt.Sprintf(c.baseURL+"/wol/%s", serverIP)
+
+	bytes, err := c.doGetRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWol(bytes)
+}
+
+// WolSend sends a Wake on LAN packet to the server
+func (c *Client) WolSend(serverIP string) (*Wol, error) {
+	url := fmt.Sprintf(c.baseURL+"/wol/%s", serverIP)
+
+	bytes, err := c.doPostFormRequest(url, neturl.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWol(bytes)
+}
+
+func parseWol(bytes []byte) (*Wol, error) {
+	var wolResp WolResponse
+	err := json.Unmarshal(bytes, &wolResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wolResp.Wol, nil
+}
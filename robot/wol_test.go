@@ -0,0 +1,41 @@
+package robot_test
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/robot"
+)
+
+func (s *RobotSuite) TestWolGetSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "wol_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "GET")
+		c.Assert(r.URL.Path, Equals, "/wol/123.123.123.123")
+	})
+	defer closeFn()
+
+	wol, err := robotClient.WolGet("123.123.123.123")
+	c.Assert(err, IsNil)
+	c.Assert(wol.ServerNumber, Equals, 321)
+}
+
+func (s *RobotSuite) TestWolGetNotAvailable(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusNotFound, "error_wol_not_available.json", nil)
+	defer closeFn()
+
+	_, err := robotClient.WolGet("123.123.123.123")
+	c.Assert(err, NotNil)
+	c.Assert(robot.ErrorCode(err), Equals, robot.CodeWolNotAvailable)
+}
+
+func (s *RobotSuite) TestWolSendSuccess(c *C) {
+	robotClient, closeFn := newTestClient(c, http.StatusOK, "wol_get.json", func(r *http.Request) {
+		c.Assert(r.Method, Equals, "POST")
+		c.Assert(r.URL.Path, Equals, "/wol/123.123.123.123")
+	})
+	defer closeFn()
+
+	_, err := robotClient.WolSend("123.123.123.123")
+	c.Assert(err, IsNil)
+}
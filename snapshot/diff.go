@@ -0,0 +1,117 @@
+package snapshot
+
+import (
+	"sort"
+)
+
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is an added, removed or changed object, Fields holds the changed
+// fields only
+type Change struct {
+	Resource string        `json:"resource"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name,omitempty"`
+	Type     string        `json:"type"`
+	Fields   []FieldChange `json:"fields,omitempty"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Diff returns the changes between both snapshots ordered by resource type
+// and ID
+func Diff(from *Snapshot, to *Snapshot) []Change {
+	changes := []Change{}
+
+	for _, resource := range Resources {
+		fromEntries := from.Resources[resource]
+		toEntries := to.Resources[resource]
+
+		for _, id := range sortedIDs(fromEntries, toEntries) {
+			fromEntry, inFrom := fromEntries[id]
+			toEntry, inTo := toEntries[id]
+
+			change := Change{Resource: resource, ID: id}
+
+			switch {
+			case !inFrom:
+				change.Type = ChangeAdded
+				change.Name = toEntry["name"]
+			case !inTo:
+				change.Type = ChangeRemoved
+				change.Name = fromEntry["name"]
+			default:
+				change.Fields = diffEntries(fromEntry, toEntry)
+				if len(change.Fields) == 0 {
+					continue
+				}
+
+				change.Type = ChangeChanged
+				change.Name = toEntry["name"]
+			}
+
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// diffEntries compares the fields of both entries, fields missing in one of
+// the entries are compared as empty values
+func diffEntries(from Entry, to Entry) []FieldChange {
+	var fields []FieldChange
+
+	for _, field := range sortedFields(from, to) {
+		if from[field] != to[field] {
+			fields = append(fields, FieldChange{Field: field, Old: from[field], New: to[field]})
+		}
+	}
+
+	return fields
+}
+
+func sortedIDs(from map[string]Entry, to map[string]Entry) []string {
+	var ids []string
+	for id := range from {
+		ids = append(ids, id)
+	}
+	for id := range to {
+		ids = append(ids, id)
+	}
+
+	return uniqueSorted(ids)
+}
+
+func sortedFields(from Entry, to Entry) []string {
+	var fields []string
+	for field := range from {
+		fields = append(fields, field)
+	}
+	for field := range to {
+		fields = append(fields, field)
+	}
+
+	return uniqueSorted(fields)
+}
+
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+
+	var unique []string
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
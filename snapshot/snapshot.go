@@ -0,0 +1,178 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nl2go/hrobot-cli/robot"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+const (
+	ResourceServers   = "servers"
+	ResourceIPs       = "ips"
+	ResourceRDns      = "rdns"
+	ResourceFailovers = "failovers"
+	ResourceKeys      = "keys"
+)
+
+// Resources lists the resource types in the order they are diffed
+var Resources = []string{ResourceServers, ResourceIPs, ResourceRDns, ResourceFailovers, ResourceKeys}
+
+// Entry holds the normalized fields of a single object
+type Entry map[string]string
+
+// Snapshot holds the objects of the account per resource type keyed by
+// their ID: server number, IP or key fingerprint. Fields changing without
+// user interaction like the paid until date are left out.
+type Snapshot struct {
+	Time      time.Time                   `json:"time"`
+	Resources map[string]map[string]Entry `json:"resources"`
+}
+
+// Take fetches the resources of the account, lists reported as not found by
+// the webservice are empty
+func Take(client robot.RobotClient, now time.Time) (*Snapshot, error) {
+	snap := &Snapshot{
+		Time:      now.UTC(),
+		Resources: make(map[string]map[string]Entry),
+	}
+	for _, resource := range Resources {
+		snap.Resources[resource] = make(map[string]Entry)
+	}
+
+	servers, err := client.ServerGetList()
+	if err = robot.ListError(err); err != nil {
+		return nil, err
+	}
+
+	for _, server := range servers {
+		snap.Resources[ResourceServers][strconv.Itoa(server.ServerNumber)] = serverEntry(server)
+	}
+
+	ips, err := client.IPGetList()
+	if err = robot.ListError(err); err != nil {
+		return nil, err
+	}
+
+	for _, ip := range ips {
+		snap.Resources[ResourceIPs][ip.IP] = Entry{
+			"server_ip":        ip.ServerIP,
+			"server_number":    strconv.Itoa(ip.ServerNumber),
+			"locked":           strconv.FormatBool(ip.Locked),
+			"separate_mac":     ip.SeparateMac,
+			"traffic_warnings": strconv.FormatBool(ip.TrafficWarnings),
+			"traffic_hourly":   strconv.Itoa(ip.TrafficHourly),
+			"traffic_daily":    strconv.Itoa(ip.TrafficDaily),
+			"traffic_monthly":  strconv.Itoa(ip.TrafficMonthly),
+		}
+	}
+
+	rdns, err := client.RDnsGetList()
+	if err = robot.ListError(err); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range rdns {
+		snap.Resources[ResourceRDns][entry.IP] = Entry{"ptr": entry.Ptr}
+	}
+
+	failovers, err := client.FailoverGetList()
+	if err = robot.ListError(err); err != nil {
+		return nil, err
+	}
+
+	for _, failover := range failovers {
+		snap.Resources[ResourceFailovers][failover.IP] = Entry{
+			"netmask":          failover.Netmask,
+			"server_ip":        failover.ServerIP,
+			"server_number":    strconv.Itoa(failover.ServerNumber),
+			"active_server_ip": failover.ActiveServerIP,
+		}
+	}
+
+	keys, err := client.KeyGetList()
+	if err = robot.ListError(err); err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		snap.Resources[ResourceKeys][key.Fingerprint] = Entry{
+			"name": key.Name,
+			"type": key.Type,
+			"size": strconv.Itoa(key.Size),
+		}
+	}
+
+	return snap, nil
+}
+
+func serverEntry(server models.Server) Entry {
+	ips := append([]string{}, server.IP...)
+	sort.Strings(ips)
+
+	var subnets []string
+	for _, subnet := range server.Subnet {
+		subnets = append(subnets, subnet.IP+"/"+subnet.Mask)
+	}
+	sort.Strings(subnets)
+
+	return Entry{
+		"name":      server.ServerName,
+		"ip":        server.ServerIP,
+		"product":   server.Product,
+		"dc":        server.Dc,
+		"status":    server.Status,
+		"traffic":   server.Traffic,
+		"flatrate":  strconv.FormatBool(server.Flatrate),
+		"throttled": strconv.FormatBool(server.Throttled),
+		"cancelled": strconv.FormatBool(server.Cancelled),
+		"ips":       strings.Join(ips, ","),
+		"subnets":   strings.Join(subnets, ","),
+	}
+}
+
+func listError(err error) error {
+	if robot.ErrorCode(err) == robot.CodeNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// Load reads a snapshot written by Save
+func Load(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %s", path, err)
+	}
+
+	return &snap, nil
+}
+
+// Save writes the snapshot as indented JSON, the keys are sorted so equal
+// snapshots are written identically
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(data, '\n'), 0600)
+}
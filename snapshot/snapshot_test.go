@@ -0,0 +1,139 @@
+package snapshot_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "gopkg.in/check.v1"
+
+	"github.com/nl2go/hrobot-cli/snapshot"
+	"github.com/nl2go/hrobot-cli/test/mock"
+	"github.com/nl2go/hrobot-go/models"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type SnapshotSuite struct{}
+
+var _ = Suite(&SnapshotSuite{})
+
+var now = time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *SnapshotSuite) TestTake(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Return([]models.Server{
+		{
+			ServerIP:     "123.123.123.123",
+			ServerNumber: 321,
+			ServerName:   "app-1",
+			Product:      "EX41",
+			Dc:           "FSN1-DC1",
+			Status:       "ready",
+			PaidUntil:    "2020-05-01",
+			IP:           []string{"123.123.123.124", "123.123.123.123"},
+			Subnet:       []models.Subnet{{IP: "2a01:4f8::", Mask: "64"}},
+		},
+	}, nil)
+	mockRobotClient.EXPECT().IPGetList().Return([]models.IP{{IP: "123.123.123.123", ServerIP: "123.123.123.123", ServerNumber: 321}}, nil)
+	mockRobotClient.EXPECT().RDnsGetList().Return([]models.Rdns{{IP: "123.123.123.123", Ptr: "app-1.example.com"}}, nil)
+	mockRobotClient.EXPECT().FailoverGetList().Return(nil, errors.New(`{"error":{"status":404,"code":"NOT_FOUND","message":"Failover not found"}}`))
+	mockRobotClient.EXPECT().KeyGetList().Return([]models.Key{{Name: "deploy", Fingerprint: "aa:bb", Type: "ED25519", Size: 256}}, nil)
+
+	snap, err := snapshot.Take(mockRobotClient, now)
+	c.Assert(err, IsNil)
+	c.Assert(snap.Time, Equals, now)
+	c.Assert(snap.Resources[snapshot.ResourceServers]["321"], DeepEquals, snapshot.Entry{
+		"name":      "app-1",
+		"ip":        "123.123.123.123",
+		"product":   "EX41",
+		"dc":        "FSN1-DC1",
+		"status":    "ready",
+		"traffic":   "",
+		"flatrate":  "false",
+		"throttled": "false",
+		"cancelled": "false",
+		"ips":       "123.123.123.123,123.123.123.124",
+		"subnets":   "2a01:4f8::/64",
+	})
+	c.Assert(snap.Resources[snapshot.ResourceRDns]["123.123.123.123"], DeepEquals, snapshot.Entry{"ptr": "app-1.example.com"})
+	c.Assert(snap.Resources[snapshot.ResourceFailovers], HasLen, 0)
+	c.Assert(snap.Resources[snapshot.ResourceKeys]["aa:bb"]["name"], Equals, "deploy")
+}
+
+func (s *SnapshotSuite) TestTakeFailed(c *C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRobotClient := mock.NewMockRobotClient(ctrl)
+	mockRobotClient.EXPECT().ServerGetList().Return(nil, errors.New("unauthorized"))
+
+	_, err := snapshot.Take(mockRobotClient, now)
+	c.Assert(err, ErrorMatches, "unauthorized")
+}
+
+func (s *SnapshotSuite) TestSaveLoad(c *C) {
+	path := filepath.Join(c.MkDir(), "snapshots", "snapshot.json")
+
+	snap := &snapshot.Snapshot{
+		Time: now,
+		Resources: map[string]map[string]snapshot.Entry{
+			snapshot.ResourceRDns: {"123.123.123.123": {"ptr": "app-1.example.com"}},
+		},
+	}
+
+	c.Assert(snap.Save(path), IsNil)
+
+	loaded, err := snapshot.Load(path)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, snap)
+}
+
+func (s *SnapshotSuite) TestDiff(c *C) {
+	old := &snapshot.Snapshot{
+		Resources: map[string]map[string]snapshot.Entry{
+			snapshot.ResourceServers: {
+				"321": {"name": "app-1", "status": "ready"},
+				"322": {"name": "app-2", "status": "ready"},
+			},
+			snapshot.ResourceRDns: {
+				"123.123.123.123": {"ptr": "app-1.example.com"},
+			},
+		},
+	}
+	new := &snapshot.Snapshot{
+		Resources: map[string]map[string]snapshot.Entry{
+			snapshot.ResourceServers: {
+				"321": {"name": "web-1", "status": "ready"},
+				"323": {"name": "app-3", "status": "in process"},
+			},
+			snapshot.ResourceRDns: {
+				"123.123.123.123": {"ptr": "app-1.example.com"},
+			},
+			snapshot.ResourceKeys: {
+				"aa:bb": {"name": "deploy"},
+			},
+		},
+	}
+
+	c.Assert(snapshot.Diff(old, new), DeepEquals, []snapshot.Change{
+		{
+			Resource: snapshot.ResourceServers,
+			ID:       "321",
+			Name:     "web-1",
+			Type:     snapshot.ChangeChanged,
+			Fields:   []snapshot.FieldChange{{Field: "name", Old: "app-1", New: "web-1"}},
+		},
+		{Resource: snapshot.ResourceServers, ID: "322", Name: "app-2", Type: snapshot.ChangeRemoved},
+		{Resource: snapshot.ResourceServers, ID: "323", Name: "app-3", Type: snapshot.ChangeAdded},
+		{Resource: snapshot.ResourceKeys, ID: "aa:bb", Name: "deploy", Type: snapshot.ChangeAdded},
+	})
+
+	c.Assert(snapshot.Diff(new, new), HasLen, 0)
+}
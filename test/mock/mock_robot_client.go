@@ -1,11 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/nl2go/hrobot-go (interfaces: RobotClient)
+// Source: github.com/nl2go/hrobot-cli/robot (interfaces: RobotClient)
 
 // Package mock is a generated GoMock package.
 package mock
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	robot "github.com/nl2go/hrobot-cli/robot"
 	models "github.com/nl2go/hrobot-go/models"
 	reflect "reflect"
 )
@@ -33,6 +34,51 @@ func (m *MockRobotClient) EXPECT() *MockRobotClientMockRecorder {
 	return m.recorder
 }
 
+// AddonProductGetList mocks base method
+func (m *MockRobotClient) AddonProductGetList(arg0 int) ([]robot.AddonProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddonProductGetList", arg0)
+	ret0, _ := ret[0].([]robot.AddonProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddonProductGetList indicates an expected call of AddonProductGetList
+func (mr *MockRobotClientMockRecorder) AddonProductGetList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddonProductGetList", reflect.TypeOf((*MockRobotClient)(nil).AddonProductGetList), arg0)
+}
+
+// AddonTransactionCreate mocks base method
+func (m *MockRobotClient) AddonTransactionCreate(arg0 *robot.AddonOrderInput) (*robot.AddonTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddonTransactionCreate", arg0)
+	ret0, _ := ret[0].(*robot.AddonTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddonTransactionCreate indicates an expected call of AddonTransactionCreate
+func (mr *MockRobotClientMockRecorder) AddonTransactionCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddonTransactionCreate", reflect.TypeOf((*MockRobotClient)(nil).AddonTransactionCreate), arg0)
+}
+
+// AddonTransactionGet mocks base method
+func (m *MockRobotClient) AddonTransactionGet(arg0 string) (*robot.AddonTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddonTransactionGet", arg0)
+	ret0, _ := ret[0].(*robot.AddonTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddonTransactionGet indicates an expected call of AddonTransactionGet
+func (mr *MockRobotClientMockRecorder) AddonTransactionGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddonTransactionGet", reflect.TypeOf((*MockRobotClient)(nil).AddonTransactionGet), arg0)
+}
+
 // BootRescueGet mocks base method
 func (m *MockRobotClient) BootRescueGet(arg0 string) (*models.Rescue, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +139,96 @@ func (mr *MockRobotClientMockRecorder) FailoverGetList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailoverGetList", reflect.TypeOf((*MockRobotClient)(nil).FailoverGetList))
 }
 
+// FirewallApplyTemplate mocks base method
+func (m *MockRobotClient) FirewallApplyTemplate(arg0 string, arg1 int) (*robot.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirewallApplyTemplate", arg0, arg1)
+	ret0, _ := ret[0].(*robot.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirewallApplyTemplate indicates an expected call of FirewallApplyTemplate
+func (mr *MockRobotClientMockRecorder) FirewallApplyTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirewallApplyTemplate", reflect.TypeOf((*MockRobotClient)(nil).FirewallApplyTemplate), arg0, arg1)
+}
+
+// FirewallGet mocks base method
+func (m *MockRobotClient) FirewallGet(arg0 string) (*robot.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirewallGet", arg0)
+	ret0, _ := ret[0].(*robot.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirewallGet indicates an expected call of FirewallGet
+func (mr *MockRobotClientMockRecorder) FirewallGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirewallGet", reflect.TypeOf((*MockRobotClient)(nil).FirewallGet), arg0)
+}
+
+// FirewallSet mocks base method
+func (m *MockRobotClient) FirewallSet(arg0 string, arg1 *robot.FirewallInput) (*robot.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirewallSet", arg0, arg1)
+	ret0, _ := ret[0].(*robot.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirewallSet indicates an expected call of FirewallSet
+func (mr *MockRobotClientMockRecorder) FirewallSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirewallSet", reflect.TypeOf((*MockRobotClient)(nil).FirewallSet), arg0, arg1)
+}
+
+// FirewallTemplateCreate mocks base method
+func (m *MockRobotClient) FirewallTemplateCreate(arg0 *robot.FirewallTemplateInput) (*robot.FirewallTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirewallTemplateCreate", arg0)
+	ret0, _ := ret[0].(*robot.FirewallTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirewallTemplateCreate indicates an expected call of FirewallTemplateCreate
+func (mr *MockRobotClientMockRecorder) FirewallTemplateCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirewallTemplateCreate", reflect.TypeOf((*MockRobotClient)(nil).FirewallTemplateCreate), arg0)
+}
+
+// FirewallTemplateGet mocks base method
+func (m *MockRobotClient) FirewallTemplateGet(arg0 int) (*robot.FirewallTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirewallTemplateGet", arg0)
+	ret0, _ := ret[0].(*robot.FirewallTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirewallTemplateGet indicates an expected call of FirewallTemplateGet
+func (mr *MockRobotClientMockRecorder) FirewallTemplateGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirewallTemplateGet", reflect.TypeOf((*MockRobotClient)(nil).FirewallTemplateGet), arg0)
+}
+
+// FirewallTemplateGetList mocks base method
+func (m *MockRobotClient) FirewallTemplateGetList() ([]robot.FirewallTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirewallTemplateGetList")
+	ret0, _ := ret[0].([]robot.FirewallTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirewallTemplateGetList indicates an expected call of FirewallTemplateGetList
+func (mr *MockRobotClientMockRecorder) FirewallTemplateGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirewallTemplateGetList", reflect.TypeOf((*MockRobotClient)(nil).FirewallTemplateGetList))
+}
+
 // GetVersion mocks base method
 func (m *MockRobotClient) GetVersion() string {
 	m.ctrl.T.Helper()
@@ -107,6 +243,21 @@ func (mr *MockRobotClientMockRecorder) GetVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockRobotClient)(nil).GetVersion))
 }
 
+// IPGet mocks base method
+func (m *MockRobotClient) IPGet(arg0 string) (*robot.IP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPGet", arg0)
+	ret0, _ := ret[0].(*robot.IP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPGet indicates an expected call of IPGet
+func (mr *MockRobotClientMockRecorder) IPGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPGet", reflect.TypeOf((*MockRobotClient)(nil).IPGet), arg0)
+}
+
 // IPGetList mocks base method
 func (m *MockRobotClient) IPGetList() ([]models.IP, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +273,66 @@ func (mr *MockRobotClientMockRecorder) IPGetList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPGetList", reflect.TypeOf((*MockRobotClient)(nil).IPGetList))
 }
 
+// IPMacDelete mocks base method
+func (m *MockRobotClient) IPMacDelete(arg0 string) (*robot.Mac, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPMacDelete", arg0)
+	ret0, _ := ret[0].(*robot.Mac)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPMacDelete indicates an expected call of IPMacDelete
+func (mr *MockRobotClientMockRecorder) IPMacDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPMacDelete", reflect.TypeOf((*MockRobotClient)(nil).IPMacDelete), arg0)
+}
+
+// IPMacGenerate mocks base method
+func (m *MockRobotClient) IPMacGenerate(arg0 string) (*robot.Mac, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPMacGenerate", arg0)
+	ret0, _ := ret[0].(*robot.Mac)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPMacGenerate indicates an expected call of IPMacGenerate
+func (mr *MockRobotClientMockRecorder) IPMacGenerate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPMacGenerate", reflect.TypeOf((*MockRobotClient)(nil).IPMacGenerate), arg0)
+}
+
+// IPMacGet mocks base method
+func (m *MockRobotClient) IPMacGet(arg0 string) (*robot.Mac, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPMacGet", arg0)
+	ret0, _ := ret[0].(*robot.Mac)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPMacGet indicates an expected call of IPMacGet
+func (mr *MockRobotClientMockRecorder) IPMacGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPMacGet", reflect.TypeOf((*MockRobotClient)(nil).IPMacGet), arg0)
+}
+
+// IPUpdate mocks base method
+func (m *MockRobotClient) IPUpdate(arg0 string, arg1 *robot.IPUpdateInput) (*robot.IP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*robot.IP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPUpdate indicates an expected call of IPUpdate
+func (mr *MockRobotClientMockRecorder) IPUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPUpdate", reflect.TypeOf((*MockRobotClient)(nil).IPUpdate), arg0, arg1)
+}
+
 // KeyGetList mocks base method
 func (m *MockRobotClient) KeyGetList() ([]models.Key, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +348,111 @@ func (mr *MockRobotClientMockRecorder) KeyGetList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyGetList", reflect.TypeOf((*MockRobotClient)(nil).KeyGetList))
 }
 
+// MarketProductGetList mocks base method
+func (m *MockRobotClient) MarketProductGetList() ([]robot.MarketProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarketProductGetList")
+	ret0, _ := ret[0].([]robot.MarketProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarketProductGetList indicates an expected call of MarketProductGetList
+func (mr *MockRobotClientMockRecorder) MarketProductGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketProductGetList", reflect.TypeOf((*MockRobotClient)(nil).MarketProductGetList))
+}
+
+// MarketTransactionCreate mocks base method
+func (m *MockRobotClient) MarketTransactionCreate(arg0 *robot.MarketOrderInput) (*robot.MarketTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarketTransactionCreate", arg0)
+	ret0, _ := ret[0].(*robot.MarketTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarketTransactionCreate indicates an expected call of MarketTransactionCreate
+func (mr *MockRobotClientMockRecorder) MarketTransactionCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketTransactionCreate", reflect.TypeOf((*MockRobotClient)(nil).MarketTransactionCreate), arg0)
+}
+
+// MarketTransactionGet mocks base method
+func (m *MockRobotClient) MarketTransactionGet(arg0 string) (*robot.MarketTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarketTransactionGet", arg0)
+	ret0, _ := ret[0].(*robot.MarketTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarketTransactionGet indicates an expected call of MarketTransactionGet
+func (mr *MockRobotClientMockRecorder) MarketTransactionGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketTransactionGet", reflect.TypeOf((*MockRobotClient)(nil).MarketTransactionGet), arg0)
+}
+
+// OrderServerProductGetList mocks base method
+func (m *MockRobotClient) OrderServerProductGetList() ([]robot.ServerProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderServerProductGetList")
+	ret0, _ := ret[0].([]robot.ServerProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderServerProductGetList indicates an expected call of OrderServerProductGetList
+func (mr *MockRobotClientMockRecorder) OrderServerProductGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderServerProductGetList", reflect.TypeOf((*MockRobotClient)(nil).OrderServerProductGetList))
+}
+
+// OrderServerTransactionCreate mocks base method
+func (m *MockRobotClient) OrderServerTransactionCreate(arg0 *robot.ServerOrderInput) (*robot.ServerTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderServerTransactionCreate", arg0)
+	ret0, _ := ret[0].(*robot.ServerTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderServerTransactionCreate indicates an expected call of OrderServerTransactionCreate
+func (mr *MockRobotClientMockRecorder) OrderServerTransactionCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderServerTransactionCreate", reflect.TypeOf((*MockRobotClient)(nil).OrderServerTransactionCreate), arg0)
+}
+
+// OrderServerTransactionGet mocks base method
+func (m *MockRobotClient) OrderServerTransactionGet(arg0 string) (*robot.ServerTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderServerTransactionGet", arg0)
+	ret0, _ := ret[0].(*robot.ServerTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderServerTransactionGet indicates an expected call of OrderServerTransactionGet
+func (mr *MockRobotClientMockRecorder) OrderServerTransactionGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderServerTransactionGet", reflect.TypeOf((*MockRobotClient)(nil).OrderServerTransactionGet), arg0)
+}
+
+// OrderServerTransactionGetList mocks base method
+func (m *MockRobotClient) OrderServerTransactionGetList() ([]robot.ServerTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderServerTransactionGetList")
+	ret0, _ := ret[0].([]robot.ServerTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderServerTransactionGetList indicates an expected call of OrderServerTransactionGetList
+func (mr *MockRobotClientMockRecorder) OrderServerTransactionGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderServerTransactionGetList", reflect.TypeOf((*MockRobotClient)(nil).OrderServerTransactionGetList))
+}
+
 // RDnsGet mocks base method
 func (m *MockRobotClient) RDnsGet(arg0 string) (*models.Rdns, error) {
 	m.ctrl.T.Helper()
@@ -167,6 +483,21 @@ func (mr *MockRobotClientMockRecorder) RDnsGetList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RDnsGetList", reflect.TypeOf((*MockRobotClient)(nil).RDnsGetList))
 }
 
+// RDnsSet mocks base method
+func (m *MockRobotClient) RDnsSet(arg0, arg1 string) (*models.Rdns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RDnsSet", arg0, arg1)
+	ret0, _ := ret[0].(*models.Rdns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RDnsSet indicates an expected call of RDnsSet
+func (mr *MockRobotClientMockRecorder) RDnsSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RDnsSet", reflect.TypeOf((*MockRobotClient)(nil).RDnsSet), arg0, arg1)
+}
+
 // ResetGet mocks base method
 func (m *MockRobotClient) ResetGet(arg0 string) (*models.Reset, error) {
 	m.ctrl.T.Helper()
@@ -197,6 +528,21 @@ func (mr *MockRobotClientMockRecorder) ResetSet(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSet", reflect.TypeOf((*MockRobotClient)(nil).ResetSet), arg0, arg1)
 }
 
+// ServerCancellationGet mocks base method
+func (m *MockRobotClient) ServerCancellationGet(arg0 string) (*models.Cancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerCancellationGet", arg0)
+	ret0, _ := ret[0].(*models.Cancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerCancellationGet indicates an expected call of ServerCancellationGet
+func (mr *MockRobotClientMockRecorder) ServerCancellationGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerCancellationGet", reflect.TypeOf((*MockRobotClient)(nil).ServerCancellationGet), arg0)
+}
+
 // ServerGet mocks base method
 func (m *MockRobotClient) ServerGet(arg0 string) (*models.Server, error) {
 	m.ctrl.T.Helper()
@@ -280,3 +626,415 @@ func (mr *MockRobotClientMockRecorder) SetUserAgent(arg0 interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAgent", reflect.TypeOf((*MockRobotClient)(nil).SetUserAgent), arg0)
 }
+
+// StorageBoxGet mocks base method
+func (m *MockRobotClient) StorageBoxGet(arg0 int) (*robot.StorageBox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxGet", arg0)
+	ret0, _ := ret[0].(*robot.StorageBox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxGet indicates an expected call of StorageBoxGet
+func (mr *MockRobotClientMockRecorder) StorageBoxGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxGet", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxGet), arg0)
+}
+
+// StorageBoxGetList mocks base method
+func (m *MockRobotClient) StorageBoxGetList() ([]robot.StorageBox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxGetList")
+	ret0, _ := ret[0].([]robot.StorageBox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxGetList indicates an expected call of StorageBoxGetList
+func (mr *MockRobotClientMockRecorder) StorageBoxGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxGetList", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxGetList))
+}
+
+// StorageBoxSnapshotCreate mocks base method
+func (m *MockRobotClient) StorageBoxSnapshotCreate(arg0 int) (*robot.StorageBoxSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSnapshotCreate", arg0)
+	ret0, _ := ret[0].(*robot.StorageBoxSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxSnapshotCreate indicates an expected call of StorageBoxSnapshotCreate
+func (mr *MockRobotClientMockRecorder) StorageBoxSnapshotCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSnapshotCreate", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSnapshotCreate), arg0)
+}
+
+// StorageBoxSnapshotDelete mocks base method
+func (m *MockRobotClient) StorageBoxSnapshotDelete(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSnapshotDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorageBoxSnapshotDelete indicates an expected call of StorageBoxSnapshotDelete
+func (mr *MockRobotClientMockRecorder) StorageBoxSnapshotDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSnapshotDelete", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSnapshotDelete), arg0, arg1)
+}
+
+// StorageBoxSnapshotGetList mocks base method
+func (m *MockRobotClient) StorageBoxSnapshotGetList(arg0 int) ([]robot.StorageBoxSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSnapshotGetList", arg0)
+	ret0, _ := ret[0].([]robot.StorageBoxSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxSnapshotGetList indicates an expected call of StorageBoxSnapshotGetList
+func (mr *MockRobotClientMockRecorder) StorageBoxSnapshotGetList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSnapshotGetList", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSnapshotGetList), arg0)
+}
+
+// StorageBoxSnapshotPlanGet mocks base method
+func (m *MockRobotClient) StorageBoxSnapshotPlanGet(arg0 int) (*robot.StorageBoxSnapshotPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSnapshotPlanGet", arg0)
+	ret0, _ := ret[0].(*robot.StorageBoxSnapshotPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxSnapshotPlanGet indicates an expected call of StorageBoxSnapshotPlanGet
+func (mr *MockRobotClientMockRecorder) StorageBoxSnapshotPlanGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSnapshotPlanGet", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSnapshotPlanGet), arg0)
+}
+
+// StorageBoxSnapshotPlanSet mocks base method
+func (m *MockRobotClient) StorageBoxSnapshotPlanSet(arg0 int, arg1 *robot.StorageBoxSnapshotPlan) (*robot.StorageBoxSnapshotPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSnapshotPlanSet", arg0, arg1)
+	ret0, _ := ret[0].(*robot.StorageBoxSnapshotPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxSnapshotPlanSet indicates an expected call of StorageBoxSnapshotPlanSet
+func (mr *MockRobotClientMockRecorder) StorageBoxSnapshotPlanSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSnapshotPlanSet", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSnapshotPlanSet), arg0, arg1)
+}
+
+// StorageBoxSnapshotRevert mocks base method
+func (m *MockRobotClient) StorageBoxSnapshotRevert(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSnapshotRevert", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorageBoxSnapshotRevert indicates an expected call of StorageBoxSnapshotRevert
+func (mr *MockRobotClientMockRecorder) StorageBoxSnapshotRevert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSnapshotRevert", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSnapshotRevert), arg0, arg1)
+}
+
+// StorageBoxSubaccountCreate mocks base method
+func (m *MockRobotClient) StorageBoxSubaccountCreate(arg0 int, arg1 *robot.StorageBoxSubaccountInput) (*robot.StorageBoxSubaccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSubaccountCreate", arg0, arg1)
+	ret0, _ := ret[0].(*robot.StorageBoxSubaccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxSubaccountCreate indicates an expected call of StorageBoxSubaccountCreate
+func (mr *MockRobotClientMockRecorder) StorageBoxSubaccountCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSubaccountCreate", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSubaccountCreate), arg0, arg1)
+}
+
+// StorageBoxSubaccountDelete mocks base method
+func (m *MockRobotClient) StorageBoxSubaccountDelete(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSubaccountDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorageBoxSubaccountDelete indicates an expected call of StorageBoxSubaccountDelete
+func (mr *MockRobotClientMockRecorder) StorageBoxSubaccountDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSubaccountDelete", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSubaccountDelete), arg0, arg1)
+}
+
+// StorageBoxSubaccountGetList mocks base method
+func (m *MockRobotClient) StorageBoxSubaccountGetList(arg0 int) ([]robot.StorageBoxSubaccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSubaccountGetList", arg0)
+	ret0, _ := ret[0].([]robot.StorageBoxSubaccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxSubaccountGetList indicates an expected call of StorageBoxSubaccountGetList
+func (mr *MockRobotClientMockRecorder) StorageBoxSubaccountGetList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSubaccountGetList", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSubaccountGetList), arg0)
+}
+
+// StorageBoxSubaccountUpdate mocks base method
+func (m *MockRobotClient) StorageBoxSubaccountUpdate(arg0 int, arg1 string, arg2 *robot.StorageBoxSubaccountInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxSubaccountUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorageBoxSubaccountUpdate indicates an expected call of StorageBoxSubaccountUpdate
+func (mr *MockRobotClientMockRecorder) StorageBoxSubaccountUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxSubaccountUpdate", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxSubaccountUpdate), arg0, arg1, arg2)
+}
+
+// StorageBoxUpdate mocks base method
+func (m *MockRobotClient) StorageBoxUpdate(arg0 int, arg1 *robot.StorageBoxInput) (*robot.StorageBox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageBoxUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*robot.StorageBox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageBoxUpdate indicates an expected call of StorageBoxUpdate
+func (mr *MockRobotClientMockRecorder) StorageBoxUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageBoxUpdate", reflect.TypeOf((*MockRobotClient)(nil).StorageBoxUpdate), arg0, arg1)
+}
+
+// SubnetGet mocks base method
+func (m *MockRobotClient) SubnetGet(arg0 string) (*robot.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetGet", arg0)
+	ret0, _ := ret[0].(*robot.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetGet indicates an expected call of SubnetGet
+func (mr *MockRobotClientMockRecorder) SubnetGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetGet", reflect.TypeOf((*MockRobotClient)(nil).SubnetGet), arg0)
+}
+
+// SubnetGetList mocks base method
+func (m *MockRobotClient) SubnetGetList() ([]robot.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetGetList")
+	ret0, _ := ret[0].([]robot.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetGetList indicates an expected call of SubnetGetList
+func (mr *MockRobotClientMockRecorder) SubnetGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetGetList", reflect.TypeOf((*MockRobotClient)(nil).SubnetGetList))
+}
+
+// SubnetMacDelete mocks base method
+func (m *MockRobotClient) SubnetMacDelete(arg0 string) (*robot.Mac, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetMacDelete", arg0)
+	ret0, _ := ret[0].(*robot.Mac)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetMacDelete indicates an expected call of SubnetMacDelete
+func (mr *MockRobotClientMockRecorder) SubnetMacDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetMacDelete", reflect.TypeOf((*MockRobotClient)(nil).SubnetMacDelete), arg0)
+}
+
+// SubnetMacGet mocks base method
+func (m *MockRobotClient) SubnetMacGet(arg0 string) (*robot.Mac, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetMacGet", arg0)
+	ret0, _ := ret[0].(*robot.Mac)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetMacGet indicates an expected call of SubnetMacGet
+func (mr *MockRobotClientMockRecorder) SubnetMacGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetMacGet", reflect.TypeOf((*MockRobotClient)(nil).SubnetMacGet), arg0)
+}
+
+// SubnetMacSet mocks base method
+func (m *MockRobotClient) SubnetMacSet(arg0, arg1 string) (*robot.Mac, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetMacSet", arg0, arg1)
+	ret0, _ := ret[0].(*robot.Mac)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetMacSet indicates an expected call of SubnetMacSet
+func (mr *MockRobotClientMockRecorder) SubnetMacSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetMacSet", reflect.TypeOf((*MockRobotClient)(nil).SubnetMacSet), arg0, arg1)
+}
+
+// TrafficGet mocks base method
+func (m *MockRobotClient) TrafficGet(arg0 *robot.TrafficGetInput) (*robot.Traffic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrafficGet", arg0)
+	ret0, _ := ret[0].(*robot.Traffic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrafficGet indicates an expected call of TrafficGet
+func (mr *MockRobotClientMockRecorder) TrafficGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrafficGet", reflect.TypeOf((*MockRobotClient)(nil).TrafficGet), arg0)
+}
+
+// VSwitchAddServers mocks base method
+func (m *MockRobotClient) VSwitchAddServers(arg0 int, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchAddServers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VSwitchAddServers indicates an expected call of VSwitchAddServers
+func (mr *MockRobotClientMockRecorder) VSwitchAddServers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchAddServers", reflect.TypeOf((*MockRobotClient)(nil).VSwitchAddServers), arg0, arg1)
+}
+
+// VSwitchCancel mocks base method
+func (m *MockRobotClient) VSwitchCancel(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchCancel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VSwitchCancel indicates an expected call of VSwitchCancel
+func (mr *MockRobotClientMockRecorder) VSwitchCancel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchCancel", reflect.TypeOf((*MockRobotClient)(nil).VSwitchCancel), arg0, arg1)
+}
+
+// VSwitchCreate mocks base method
+func (m *MockRobotClient) VSwitchCreate(arg0 *robot.VSwitchInput) (*robot.VSwitch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchCreate", arg0)
+	ret0, _ := ret[0].(*robot.VSwitch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VSwitchCreate indicates an expected call of VSwitchCreate
+func (mr *MockRobotClientMockRecorder) VSwitchCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchCreate", reflect.TypeOf((*MockRobotClient)(nil).VSwitchCreate), arg0)
+}
+
+// VSwitchGet mocks base method
+func (m *MockRobotClient) VSwitchGet(arg0 int) (*robot.VSwitch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchGet", arg0)
+	ret0, _ := ret[0].(*robot.VSwitch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VSwitchGet indicates an expected call of VSwitchGet
+func (mr *MockRobotClientMockRecorder) VSwitchGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchGet", reflect.TypeOf((*MockRobotClient)(nil).VSwitchGet), arg0)
+}
+
+// VSwitchGetList mocks base method
+func (m *MockRobotClient) VSwitchGetList() ([]robot.VSwitch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchGetList")
+	ret0, _ := ret[0].([]robot.VSwitch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VSwitchGetList indicates an expected call of VSwitchGetList
+func (mr *MockRobotClientMockRecorder) VSwitchGetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchGetList", reflect.TypeOf((*MockRobotClient)(nil).VSwitchGetList))
+}
+
+// VSwitchRemoveServers mocks base method
+func (m *MockRobotClient) VSwitchRemoveServers(arg0 int, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchRemoveServers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VSwitchRemoveServers indicates an expected call of VSwitchRemoveServers
+func (mr *MockRobotClientMockRecorder) VSwitchRemoveServers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchRemoveServers", reflect.TypeOf((*MockRobotClient)(nil).VSwitchRemoveServers), arg0, arg1)
+}
+
+// VSwitchUpdate mocks base method
+func (m *MockRobotClient) VSwitchUpdate(arg0 int, arg1 *robot.VSwitchInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VSwitchUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VSwitchUpdate indicates an expected call of VSwitchUpdate
+func (mr *MockRobotClientMockRecorder) VSwitchUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VSwitchUpdate", reflect.TypeOf((*MockRobotClient)(nil).VSwitchUpdate), arg0, arg1)
+}
+
+// WolGet mocks base method
+func (m *MockRobotClient) WolGet(arg0 string) (*robot.Wol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WolGet", arg0)
+	ret0, _ := ret[0].(*robot.Wol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WolGet indicates an expected call of WolGet
+func (mr *MockRobotClientMockRecorder) WolGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WolGet", reflect.TypeOf((*MockRobotClient)(nil).WolGet), arg0)
+}
+
+// WolSend mocks base method
+func (m *MockRobotClient) WolSend(arg0 string) (*robot.Wol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WolSend", arg0)
+	ret0, _ := ret[0].(*robot.Wol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WolSend indicates an expected call of WolSend
+func (mr *MockRobotClientMockRecorder) WolSend(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WolSend", reflect.TypeOf((*MockRobotClient)(nil).WolSend), arg0)
+}